@@ -0,0 +1,24 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"syl-listing-pro/internal/app"
+)
+
+var authCmd = &cobra.Command{
+	Use:   "auth",
+	Short: "认证相关检查",
+}
+
+var authCheckCmd = &cobra.Command{
+	Use:   "check",
+	Short: "检查 KEY 能否换取令牌，并给出失败原因",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return app.RunAuthCheck(cmd.Context(), cmd.OutOrStdout(), keyProfile)
+	},
+}
+
+func init() {
+	authCmd.AddCommand(authCheckCmd)
+}
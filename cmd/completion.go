@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+	"syl-listing-pro/internal/app"
+)
+
+// completeRunID 为第一个位置参数补全本地运行编号。
+func completeRunID(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return filterPrefix(app.RecentRunIDs(), toComplete), cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveKeepOrder
+}
+
+// completeValues 返回固定候选值的补全函数。
+func completeValues(values ...string) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return filterPrefix(values, toComplete), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// completeKeyProfile 补全已保存 KEY 的配置名。
+func completeKeyProfile(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return filterPrefix(app.KeyProfiles(), toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+func filterPrefix(values []string, prefix string) []string {
+	out := make([]string, 0, len(values))
+	for _, v := range values {
+		if strings.HasPrefix(v, prefix) {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// registerCompletions 在全部命令与参数定义完成后注册动态补全。
+func registerCompletions() {
+	requeueCmd.ValidArgsFunction = completeRunID
+	historyShowCmd.ValidArgsFunction = completeRunID
+	useCmd.ValidArgsFunction = completeKeyProfile
+	_ = rootCmd.RegisterFlagCompletionFunc("key-profile", completeKeyProfile)
+	_ = rootCmd.RegisterFlagCompletionFunc("on-conflict", completeValues("overwrite", "skip", "suffix"))
+	_ = rootCmd.RegisterFlagCompletionFunc("color", completeValues("auto", "always", "never"))
+	_ = rootCmd.RegisterFlagCompletionFunc("log-format", completeValues("text", "json"))
+	_ = rootCmd.RegisterFlagCompletionFunc("marketplace", completeValues("us", "de", "fr", "jp"))
+	_ = rootCmd.RegisterFlagCompletionFunc("lang", completeValues("zh", "en"))
+	_ = rootCmd.RegisterFlagCompletionFunc("normalize", completeValues("blank-lines", "bullets", "straight-quotes", "curly-quotes", "strip-emoji"))
+	_ = rootCmd.RegisterFlagCompletionFunc("out-layout", completeValues("flat", "per-input", "per-date"))
+	_ = rootCmd.RegisterFlagCompletionFunc("stdout", completeValues("en", "cn", "both"))
+	_ = rootCmd.RegisterFlagCompletionFunc("out-encoding", completeValues("utf8", "utf8bom"))
+	_ = rootCmd.RegisterFlagCompletionFunc("out-newlines", completeValues("lf", "crlf"))
+	_ = rootCmd.RegisterFlagCompletionFunc("format", completeValues("shopify", "ebay"))
+}
@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"syl-listing-pro/internal/app"
+	"syl-listing-pro/internal/i18n"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "配置文件工具",
+}
+
+var configValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "检查配置文件与本地状态目录，一次列出全部问题",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return app.RunConfigValidate(cmd.OutOrStdout(), allFlagNames(rootCmd))
+	},
+}
+
+func init() {
+	configCmd.AddCommand(configValidateCmd)
+}
+
+// allFlagNames 收集命令树中全部参数名，供校验 SYL_DEFAULT_<参数名>。
+func allFlagNames(cmd *cobra.Command) []string {
+	var names []string
+	collect := func(f *pflag.Flag) { names = append(names, f.Name) }
+	cmd.Flags().VisitAll(collect)
+	cmd.PersistentFlags().VisitAll(collect)
+	for _, sub := range cmd.Commands() {
+		names = append(names, allFlagNames(sub)...)
+	}
+	return names
+}
+
+// cfgPath 为 --config 指定的配置文件，为空时使用 ~/.syl-listing-pro/.env。
+var cfgPath string
+
+// applyConfigDefaults 加载配置文件，并把其中 SYL_DEFAULT_<参数名> 的取值填入命令行未指定的参数；命令行参数优先。
+func applyConfigDefaults(cmd *cobra.Command) error {
+	defaults, err := app.UseConfig(strings.TrimSpace(cfgPath))
+	if err != nil {
+		return err
+	}
+	var setErr error
+	cmd.Flags().VisitAll(func(f *pflag.Flag) {
+		if setErr != nil || f.Changed || f.Name == "config" {
+			return
+		}
+		v, ok := defaults[f.Name]
+		if !ok {
+			return
+		}
+		if err := cmd.Flags().Set(f.Name, v); err != nil {
+			setErr = i18n.Errorf("配置 SYL_DEFAULT_%s 无效：%v", strings.ToUpper(strings.ReplaceAll(f.Name, "-", "_")), err)
+		}
+	})
+	return setErr
+}
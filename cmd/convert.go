@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"syl-listing-pro/internal/app"
+)
+
+var highlightWords []string
+
+var convertCmd = &cobra.Command{
+	Use:   "convert <md ...>",
+	Short: "把已生成的 Markdown 转为 Word",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return app.RunConvert(cmd.Context(), app.ConvertOptions{
+			Verbose:        verbose,
+			LogFile:        logFile,
+			LogFormat:      logFormat,
+			Inputs:         args,
+			HighlightWords: highlightWords,
+		})
+	},
+}
+
+func init() {
+	convertCmd.Flags().StringSliceVar(&highlightWords, "highlight-words", nil, "Word 中需要高亮的关键词，逗号分隔")
+}
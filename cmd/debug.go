@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"syl-listing-pro/internal/app"
+)
+
+var debugCmd = &cobra.Command{
+	Use:   "debug",
+	Short: "排查问题用的辅助命令",
+}
+
+var debugBundleCmd = &cobra.Command{
+	Use:   "bundle",
+	Short: "打包版本、脱敏配置、最近运行记录与日志，便于提交工单",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return app.RunDebugBundle(cmd.OutOrStdout(), app.DebugBundleOptions{
+			OutputDir:   outDir,
+			VersionText: versionText(),
+			LogFile:     logFile,
+			LogDir:      logDir,
+		})
+	},
+}
+
+func init() {
+	debugCmd.AddCommand(debugBundleCmd)
+}
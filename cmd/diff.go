@@ -0,0 +1,15 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"syl-listing-pro/internal/app"
+)
+
+var diffCmd = &cobra.Command{
+	Use:   "diff <a.md> <b.md>",
+	Short: "按分节比较两份生成的 listing",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return app.RunDiff(cmd.OutOrStdout(), args[0], args[1])
+	},
+}
@@ -0,0 +1,15 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"syl-listing-pro/internal/app"
+)
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "检查运行环境",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return app.RunDoctor(cmd.Context(), cmd.OutOrStdout(), outDir, keyProfile)
+	},
+}
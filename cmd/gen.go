@@ -1,22 +1,98 @@
 package cmd
 
 import (
+	"time"
+
 	"github.com/spf13/cobra"
 	"syl-listing-pro/internal/app"
+	"syl-listing-pro/internal/client"
+	"syl-listing-pro/internal/processor"
 )
 
 var genCmd = &cobra.Command{
 	Use:   "gen [file_or_dir ...]",
 	Short: "生成 listing",
-	Args:  cobra.MinimumNArgs(1),
-	RunE: func(cmd *cobra.Command, args []string) error {
-		opts := app.GenOptions{
-			Verbose:   verbose,
-			LogFile:   logFile,
-			OutputDir: outDir,
-			Num:       num,
-			Inputs:    args,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if resumeLast {
+			return nil
 		}
-		return app.RunGen(cmd.Context(), opts)
+		return cobra.MinimumNArgs(1)(cmd, args)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return app.RunGen(cmd.Context(), buildGenOptions(args))
 	},
 }
+
+func buildGenOptions(args []string) app.GenOptions {
+	procs := make([]processor.Processor, 0, len(processorCmds))
+	for _, c := range processorCmds {
+		procs = append(procs, processor.Exec{Command: c})
+	}
+	return app.GenOptions{
+		Verbose:           verbose,
+		LogFile:           logFile,
+		OutputDir:         outDir,
+		Num:               num,
+		Inputs:            args,
+		DedupContent:      dedupContent,
+		Incremental:       incremental,
+		ResumeLast:        resumeLast,
+		RetryFailed:       retryFailed,
+		SkipDocx:          skipDocx,
+		OnConflict:        onConflict,
+		OutLayout:         outLayout,
+		OutEncoding:       outEncoding,
+		OutNewlines:       outNewlines,
+		Combined:          combinedPath,
+		ProvenanceHeader:  provenanceHeader,
+		Formats:           exportFormats,
+		Append:            appendCatalog,
+		Stdout:            stdoutMode,
+		EventsFD:          eventsFD,
+		EventsFile:        eventsFile,
+		CLIVersion:        Version,
+		Publish:           publishOutputs,
+		Processors:        procs,
+		ConsistencyReport: consistencyReport,
+		HTMLReport:        htmlReport,
+		Color:             colorMode,
+		Timestamps:        timestamps,
+		KeyProfile:        keyProfile,
+		Sections:          sections,
+		Normalize:         normalizeRules,
+		Pick:              pickCandidate,
+		AutoPick:          autoPick,
+		Keywords:          keywords,
+		Attributes: client.ProductAttributes{
+			Brand:          productBrand,
+			Category:       productCategory,
+			TargetAudience: targetAudience,
+			KeyFeatures:    keyFeatures,
+		},
+		Marketplace:      marketplace,
+		SearchTerms:      searchTerms,
+		BannedWordsFile:  bannedWordsFile,
+		StrictCompliance: strictCompliance,
+		Spellcheck:       spellcheck,
+		SpellDictFile:    spellDictFile,
+		Copy:             copyTarget,
+		Open:             openDocx,
+		Label:            runLabel,
+		TraceBodyLimit:   traceBodyLimit,
+		TraceSample:      traceSample,
+		LogDir:           logDir,
+		LogFormat:        logFormat,
+		Timeout:          runTimeout,
+		Confirm:          confirmRun,
+		SubmitRate:       submitRate,
+		SubmitWindow:     submitWindow,
+		Record:           recordCassette,
+		Replay:           replayCassette,
+		RouteFile:        routeFile,
+		StageTimeouts: map[client.Stage]time.Duration{
+			client.StageExchange: exchangeTimeout,
+			client.StageSubmit:   submitTimeout,
+			client.StageResult:   resultTimeout,
+		},
+	}
+}
@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"syl-listing-pro/internal/app"
+)
+
+var (
+	historySince string
+	historyLabel string
+)
+
+var historyCmd = &cobra.Command{
+	Use:   "history",
+	Short: "列出本地运行记录",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return app.RunHistory(cmd.OutOrStdout(), historySince, historyLabel)
+	},
+}
+
+var historyShowCmd = &cobra.Command{
+	Use:   "show <run_id>",
+	Short: "显示某次运行的任务明细",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return app.RunHistoryShow(cmd.OutOrStdout(), args[0])
+	},
+}
+
+func init() {
+	historyCmd.Flags().StringVar(&historySince, "since", "", "只显示最近一段时间的运行，例如 7d、12h")
+	historyCmd.Flags().StringVar(&historyLabel, "label", "", "只显示带有该运行标签的运行")
+	historyCmd.AddCommand(historyShowCmd)
+}
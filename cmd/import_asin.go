@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"syl-listing-pro/internal/app"
+)
+
+var (
+	importFrom  string
+	importForce bool
+)
+
+var importASINCmd = &cobra.Command{
+	Use:   "import-asin <ASIN>",
+	Short: "把亚马逊上已有的 listing 转为需求 Markdown 骨架，用于改写",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return app.RunImportASIN(cmd.Context(), cmd.OutOrStdout(), cmd.InOrStdin(), app.ImportASINOptions{
+			ASIN:      args[0],
+			From:      importFrom,
+			OutputDir: outDir,
+			Force:     importForce,
+		})
+	},
+}
+
+func init() {
+	importASINCmd.Flags().StringVar(&importFrom, "from", "", "从商品详情页 HTML 文件读取（- 为标准输入），不使用 SP-API")
+	importASINCmd.Flags().BoolVar(&importForce, "force", false, "覆盖已存在的 <ASIN>.md")
+}
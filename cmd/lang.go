@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"syl-listing-pro/internal/i18n"
+)
+
+var langFlag string
+
+// langFromArgs 在 cobra 解析之前取出 --lang，使帮助文本也能按所选语言输出。
+func langFromArgs(args []string) string {
+	for i, a := range args {
+		if a == "--" {
+			break
+		}
+		if v, ok := strings.CutPrefix(a, "--lang="); ok {
+			return v
+		}
+		if a == "--lang" && i+1 < len(args) {
+			return args[i+1]
+		}
+	}
+	return ""
+}
+
+// applyLang 设置界面语言并翻译各命令的简介与参数说明。
+func applyLang(args []string) error {
+	lang, err := i18n.Parse(langFromArgs(args))
+	if err != nil {
+		return err
+	}
+	i18n.Set(lang)
+	localizeHelp(rootCmd)
+	return nil
+}
+
+func localizeHelp(cmd *cobra.Command) {
+	cmd.Short = i18n.T(cmd.Short)
+	translate := func(f *pflag.Flag) { f.Usage = i18n.T(f.Usage) }
+	cmd.Flags().VisitAll(translate)
+	cmd.PersistentFlags().VisitAll(translate)
+	for _, sub := range cmd.Commands() {
+		localizeHelp(sub)
+	}
+}
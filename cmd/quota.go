@@ -0,0 +1,15 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"syl-listing-pro/internal/app"
+)
+
+var quotaCmd = &cobra.Command{
+	Use:   "quota",
+	Short: "查询剩余额度",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return app.RunQuota(cmd.Context(), cmd.OutOrStdout(), keyProfile)
+	},
+}
@@ -0,0 +1,15 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"syl-listing-pro/internal/app"
+)
+
+var requeueCmd = &cobra.Command{
+	Use:   "requeue <run_id>",
+	Short: "重新生成某次运行中失败的任务",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return app.RunRequeue(cmd.Context(), args[0], buildGenOptions(nil))
+	},
+}
@@ -4,49 +4,126 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/spf13/cobra"
 	"syl-listing-pro/internal/app"
+	"syl-listing-pro/internal/i18n"
 )
 
 var (
-	verbose     bool
-	logFile     string
-	outDir      string
-	num         int
-	showVersion bool
+	verbose           bool
+	logFile           string
+	outDir            string
+	num               int
+	showVersion       bool
+	dedupContent      bool
+	incremental       bool
+	resumeLast        bool
+	retryFailed       int
+	skipDocx          bool
+	onConflict        string
+	outLayout         string
+	outEncoding       string
+	outNewlines       string
+	combinedPath      string
+	stdoutMode        string
+	eventsFD          int
+	eventsFile        string
+	publishOutputs    bool
+	processorCmds     []string
+	consistencyReport bool
+	htmlReport        bool
+	colorMode         string
+	timestamps        bool
+	keyProfile        string
+	sections          []string
+	normalizeRules    []string
+	pickCandidate     bool
+	autoPick          bool
+	keywords          []string
+	productBrand      string
+	productCategory   string
+	targetAudience    string
+	keyFeatures       []string
+	marketplace       string
+	searchTerms       bool
+	bannedWordsFile   string
+	strictCompliance  bool
+	spellcheck        bool
+	spellDictFile     string
+	copyTarget        string
+	openDocx          bool
+	runLabel          string
+	traceBodyLimit    int
+	traceSample       int
+	logDir            string
+	logFormat         string
+	runTimeout        time.Duration
+	submitRate        string
+	provenanceHeader  bool
+	exportFormats     []string
+	appendCatalog     string
+	confirmRun        bool
+	submitWindow      string
+	exchangeTimeout   time.Duration
+	submitTimeout     time.Duration
+	resultTimeout     time.Duration
+	recordCassette    string
+	replayCassette    string
+	routeFile         string
+	pprofAddr         string
+	cpuProfile        string
+	memProfile        string
+	// stopProfiling 写出剖析文件，Execute 退出前调用。
+	stopProfiling = func() {}
 )
 
 var rootCmd = &cobra.Command{
 	Use:   "syl-listing-pro [file_or_dir ...]",
 	Short: "生成双语 listing（新架构 CLI）",
 	Args:  cobra.ArbitraryArgs,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if err := applyConfigDefaults(cmd); err != nil {
+			return err
+		}
+		stop, err := app.StartProfiling(cmd.ErrOrStderr(), app.ProfileOptions{PprofAddr: pprofAddr, CPUProfile: cpuProfile, MemProfile: memProfile})
+		if err != nil {
+			return err
+		}
+		stopProfiling = stop
+		return nil
+	},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if showVersion {
 			printVersion(cmd.OutOrStdout())
 			return nil
 		}
-		if len(args) == 0 {
+		if len(args) == 0 && !resumeLast {
 			return cmd.Help()
 		}
-		opts := app.GenOptions{
-			Verbose:   verbose,
-			LogFile:   logFile,
-			OutputDir: outDir,
-			Num:       num,
-			Inputs:    args,
-		}
-		return app.RunGen(cmd.Context(), opts)
+		return app.RunGen(cmd.Context(), buildGenOptions(args))
 	},
 }
 
 func Execute() {
-	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
-	defer stop()
-	if err := rootCmd.ExecuteContext(ctx); err != nil {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sigCh := make(chan os.Signal, 2)
+	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sigCh)
+	go handleInterrupts(sigCh, cancel, os.Stderr, os.Exit)
+	if err := applyLang(os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	err := rootCmd.ExecuteContext(ctx)
+	stopProfiling()
+	if err != nil {
 		if errors.Is(err, context.Canceled) {
 			os.Exit(130)
 		}
@@ -55,18 +132,107 @@ func Execute() {
 	}
 }
 
+// handleInterrupts 第一次中断触发优雅取消，第二次中断不再等待直接退出。
+func handleInterrupts(sigCh <-chan os.Signal, cancel context.CancelFunc, w io.Writer, exit func(int)) {
+	if _, ok := <-sigCh; !ok {
+		return
+	}
+	cancel()
+	if _, ok := <-sigCh; !ok {
+		return
+	}
+	fmt.Fprintln(w, i18n.T("再次中断，立即退出；已提交的任务可能仍在服务端运行，可用 history 查看本次运行"))
+	exit(130)
+}
+
 func init() {
 	rootCmd.SilenceUsage = true
 	rootCmd.SilenceErrors = true
-	rootCmd.CompletionOptions.HiddenDefaultCmd = true
 
+	rootCmd.PersistentFlags().StringVar(&cfgPath, "config", "", "配置文件路径（默认 ~/.syl-listing-pro/.env）；KEY 等配置从该文件读写，其中 SYL_DEFAULT_<参数名> 作为参数默认值，命令行优先")
 	rootCmd.PersistentFlags().BoolVar(&verbose, "verbose", false, "输出 NDJSON 详细日志")
 	rootCmd.PersistentFlags().StringVar(&logFile, "log-file", "", "日志文件路径")
+	rootCmd.PersistentFlags().StringVar(&logFormat, "log-format", "text", "标准输出格式：text|json（json 把每条日志输出为 NDJSON，不需要 --verbose）")
+	rootCmd.PersistentFlags().StringVar(&recordCassette, "record", "", "把本次运行的 API 请求与响应（已隐去 KEY 与令牌）录制到该 JSON 文件，便于反馈问题")
+	rootCmd.PersistentFlags().StringVar(&replayCassette, "replay", "", "用 --record 录制的文件应答 API 请求，离线复现问题（不访问服务端）")
+	rootCmd.PersistentFlags().StringVar(&logDir, "log-dir", "", "每个任务的日志与 NDJSON 事件另写到该目录下 <run_id>/<文件名>_<序号>.log|.ndjson")
+	rootCmd.PersistentFlags().IntVar(&traceBodyLimit, "trace-body-limit", 64<<10, "--verbose 日志中每个 HTTP 请求/响应体最多保留的字节数，0 表示不限制")
+	rootCmd.PersistentFlags().IntVar(&traceSample, "trace-sample", 1, "--verbose 下同类高频追踪事件每 N 条记录 1 条（错误与警告总是记录）")
 	rootCmd.PersistentFlags().StringVarP(&outDir, "out", "o", ".", "输出目录")
 	rootCmd.PersistentFlags().IntVarP(&num, "num", "n", 1, "每个需求文件生成候选数量")
+	rootCmd.PersistentFlags().BoolVar(&dedupContent, "dedup-content", false, "内容相同的需求文件只提交一次，结果复用到各自输出")
+	rootCmd.PersistentFlags().BoolVar(&incremental, "incremental", false, "增量模式：跳过输出目录处理记录中内容未变化的需求文件")
+	rootCmd.PersistentFlags().BoolVar(&resumeLast, "resume-last", false, "续跑最近一次运行：跳过已完成任务，重新接入已提交任务")
+	rootCmd.PersistentFlags().DurationVar(&runTimeout, "timeout", 0, "整次运行的期限，例如 45m；到期取消已提交任务并输出已完成部分的汇总（默认不限制）")
+	rootCmd.PersistentFlags().DurationVar(&exchangeTimeout, "exchange-timeout", 0, "KEY 换取访问令牌的单次请求超时（默认 20s，或 .env 中 SYL_TIMEOUT_EXCHANGE）")
+	rootCmd.PersistentFlags().DurationVar(&submitTimeout, "submit-timeout", 0, "提交生成任务的单次请求超时（默认 120s，或 .env 中 SYL_TIMEOUT_SUBMIT）")
+	rootCmd.PersistentFlags().DurationVar(&resultTimeout, "result-timeout", 0, "拉取任务结果的单次请求超时（默认 120s，或 .env 中 SYL_TIMEOUT_RESULT）")
+	rootCmd.PersistentFlags().BoolVar(&confirmRun, "confirm", false, "任务数达到 20 个时，先输出耗时、用量估算与剩余额度，在终端确认后再提交")
+	rootCmd.PersistentFlags().StringVar(&submitRate, "submit-rate", "", "新任务提交速率上限，如 10/min（单位 sec|min|hour），均匀间隔提交以免超出租户配额")
+	rootCmd.PersistentFlags().StringVar(&submitWindow, "submit-window", "", "只在每天该时段内提交新任务，如 00:00-06:00（本地时间，可跨午夜）；时段外等待")
+	rootCmd.PersistentFlags().IntVar(&retryFailed, "retry-failed", 0, "首轮结束后对超时、5xx、网络抖动等可重试失败再补跑的轮数")
+	rootCmd.PersistentFlags().BoolVar(&skipDocx, "skip-docx", false, "跳过 Word 转换，只写 Markdown")
+	rootCmd.PersistentFlags().StringVar(&onConflict, "on-conflict", "", "使用固定文件名，已存在时的处理：overwrite|skip|suffix（默认随机后缀命名）")
+	rootCmd.PersistentFlags().StringVar(&outLayout, "out-layout", "flat", "输出目录组织方式：flat|per-input|per-date")
+	rootCmd.PersistentFlags().StringVar(&outEncoding, "out-encoding", "utf8", "Markdown 产物编码：utf8|utf8bom（部分 Windows 工具需要 BOM）")
+	rootCmd.PersistentFlags().StringVar(&outNewlines, "out-newlines", "lf", "Markdown 产物换行符：lf|crlf")
+	rootCmd.PersistentFlags().StringVar(&appendCatalog, "append", "", "每个任务成功后把主稿追加到已有的 Markdown 总目录（每个商品一个二级标题，写入时加文件锁）")
+	rootCmd.PersistentFlags().StringSliceVar(&exportFormats, "format", nil, "运行结束后额外导出批量导入文件，逗号分隔：shopify,ebay（写为 <输出目录>/<格式>_<run_id>.csv）")
+	rootCmd.PersistentFlags().BoolVar(&provenanceHeader, "provenance-header", false, "在 Markdown 产物第一行写入来源注释 <!-- syl: job=... rules=... generated=... -->")
+	rootCmd.PersistentFlags().StringVar(&combinedPath, "combined", "", "运行结束后把全部成功的 listing 合并为一份带目录的文档（.md 或 .docx）")
+	rootCmd.PersistentFlags().BoolVar(&publishOutputs, "publish", false, "任务成功后把产物上传到 .env 中配置的对象存储（S3/OSS/GCS）")
+	rootCmd.PersistentFlags().StringArrayVar(&processorCmds, "processor", nil, "任务成功后执行的外部处理器命令，结果 JSON 写入其标准输入（可重复）")
+	rootCmd.PersistentFlags().BoolVar(&consistencyReport, "consistency-report", false, "逐节比较 EN/CN（分节、列表项、数值、高亮词），不一致写入汇总与同名 .json 报告")
+	rootCmd.PersistentFlags().StringVar(&bannedWordsFile, "banned-words", "", "禁用词表文件，每行一个词（默认 ~/.syl-listing-pro/banned_words.txt，不存在则不检查）")
+	rootCmd.PersistentFlags().BoolVar(&strictCompliance, "strict-compliance", false, "命中禁用词的任务记为失败（产物仍会写出）")
+	rootCmd.PersistentFlags().BoolVar(&spellcheck, "spellcheck", false, "对主稿英文做拼写检查，疑似拼错的词写入汇总与同名 .json 报告（品牌词加到 ~/.syl-listing-pro/dictionary.txt）")
+	rootCmd.PersistentFlags().StringVar(&spellDictFile, "spell-dict", "", "英文词典：hunspell .dic 或每行一词的单词表（默认查找系统词典）")
+	rootCmd.PersistentFlags().BoolVar(&htmlReport, "html-report", false, "运行结束后在输出目录生成 report_<run_id>.html 运行报告")
+	rootCmd.PersistentFlags().StringVar(&colorMode, "color", "auto", "彩色输出：auto|always|never（auto 遵循 NO_COLOR 并在非终端时关闭）")
+	rootCmd.PersistentFlags().BoolVar(&timestamps, "timestamps", false, "普通日志每行前加本地时间（HH:MM:SS）")
+	rootCmd.PersistentFlags().StringSliceVar(&normalizeRules, "normalize", nil, "写出前规范化 Markdown，逗号分隔：blank-lines,bullets,straight-quotes|curly-quotes,strip-emoji")
+	rootCmd.PersistentFlags().StringSliceVar(&sections, "sections", nil, "只重新生成指定分节：title,bullets,description（需服务端支持）")
+	rootCmd.PersistentFlags().StringVar(&marketplace, "marketplace", "", "目标站点：us|de|fr|jp，决定输出语言对（默认由服务端决定）")
+	rootCmd.PersistentFlags().BoolVar(&searchTerms, "search-terms", false, "额外生成后台搜索词，按 Amazon 249 字节上限写为 <文件名>_search_terms.txt（需服务端支持）")
+	rootCmd.PersistentFlags().StringVar(&productBrand, "brand", "", "随任务提交的品牌名；需求文件 frontmatter 的 brand 优先")
+	rootCmd.PersistentFlags().StringVar(&productCategory, "category", "", "随任务提交的商品类目；需求文件 frontmatter 的 category 优先")
+	rootCmd.PersistentFlags().StringVar(&targetAudience, "target-audience", "", "随任务提交的目标人群；需求文件 frontmatter 的 target_audience 优先")
+	rootCmd.PersistentFlags().StringSliceVar(&keyFeatures, "key-features", nil, "随任务提交的核心卖点，逗号分隔；与需求文件 frontmatter 的 key_features 合并")
+	rootCmd.PersistentFlags().StringSliceVar(&keywords, "keywords", nil, "必须融入输出的 SEO 关键词，逗号分隔；与需求文件 frontmatter 的 keywords 合并")
+	rootCmd.PersistentFlags().BoolVar(&pickCandidate, "pick", false, "候选数大于 1 时，运行结束后在终端比较候选并选定最终稿（复制为 _final 文件）")
+	rootCmd.PersistentFlags().BoolVar(&autoPick, "auto-pick", false, "候选数大于 1 时，运行结束后按约束符合度、关键词覆盖、长度均衡给候选评分，把最高分复制为 _best 文件")
+	rootCmd.PersistentFlags().StringVar(&runLabel, "label", "", "运行标签（如营销活动名），记录在运行记录与附带文件中，可在 history / stats 中用 --label 筛选")
+	rootCmd.PersistentFlags().IntVar(&eventsFD, "events-fd", 0, "向该文件描述符输出带版本的 NDJSON 事件流（如 3），供 GUI 与包装脚本使用")
+	rootCmd.PersistentFlags().StringVar(&eventsFile, "events-file", "", "把带版本的 NDJSON 事件流写入该文件；与 --events-fd 二选一")
+	rootCmd.PersistentFlags().StringVar(&stdoutMode, "stdout", "", "单个需求文件时把结果 Markdown 输出到标准输出而不写文件：en|cn|both（日志改写到标准错误）")
+	rootCmd.PersistentFlags().StringVar(&copyTarget, "copy", "", "单个需求文件生成成功后把 Markdown 复制到剪贴板：en|cn")
+	rootCmd.PersistentFlags().BoolVar(&openDocx, "open", false, "单个需求文件生成成功后用默认程序打开主稿 Word")
+	rootCmd.PersistentFlags().StringVar(&routeFile, "route", "", "路由文件：每行“<路径或通配符> <KEY 配置名>”，按租户并发生成，产物写到 <输出目录>/<KEY 配置名>")
+	rootCmd.PersistentFlags().StringVar(&keyProfile, "key-profile", "", "本次使用的 KEY 配置（默认取 use 选中的配置）")
+	rootCmd.PersistentFlags().StringVar(&pprofAddr, "pprof", "", "在该地址提供 /debug/pprof/ 用于排查性能问题，例如 127.0.0.1:6060")
+	rootCmd.PersistentFlags().StringVar(&cpuProfile, "cpuprofile", "", "把整个运行的 CPU 剖析写到该文件")
+	rootCmd.PersistentFlags().StringVar(&memProfile, "memprofile", "", "退出前把堆内存剖析写到该文件")
+	rootCmd.PersistentFlags().StringVar(&langFlag, "lang", "", "界面语言：zh|en（默认按 LANG 环境变量）")
 	rootCmd.PersistentFlags().BoolVarP(&showVersion, "version", "v", false, "显示版本信息")
 
 	rootCmd.AddCommand(genCmd)
 	rootCmd.AddCommand(versionCmd)
 	rootCmd.AddCommand(setCmd)
+	rootCmd.AddCommand(requeueCmd)
+	rootCmd.AddCommand(convertCmd)
+	rootCmd.AddCommand(diffCmd)
+	rootCmd.AddCommand(verifyOutputCmd)
+	rootCmd.AddCommand(historyCmd)
+	rootCmd.AddCommand(statsCmd)
+	rootCmd.AddCommand(quotaCmd)
+	rootCmd.AddCommand(tenantCmd)
+	rootCmd.AddCommand(importASINCmd)
+	rootCmd.AddCommand(doctorCmd)
+	rootCmd.AddCommand(configCmd)
+	rootCmd.AddCommand(smokeCmd)
+	rootCmd.AddCommand(debugCmd)
+	rootCmd.AddCommand(useCmd)
+	rootCmd.AddCommand(whoamiCmd)
+	rootCmd.AddCommand(authCmd)
+	registerCompletions()
 }
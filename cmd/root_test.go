@@ -2,6 +2,9 @@ package cmd
 
 import (
 	"bytes"
+	"context"
+	"os"
+	"strings"
 	"testing"
 )
 
@@ -34,3 +37,46 @@ func TestRootRunE_NoArgsShowsHelp(t *testing.T) {
 		t.Fatal("expected help output")
 	}
 }
+
+func TestLangFromArgs(t *testing.T) {
+	cases := map[string][]string{
+		"en": {"gen", "--lang", "en", "a.md"},
+		"zh": {"--lang=zh"},
+		"":   {"gen", "--", "--lang", "en"},
+	}
+	for want, args := range cases {
+		if got := langFromArgs(args); got != want {
+			t.Fatalf("langFromArgs(%v)=%q want %q", args, got, want)
+		}
+	}
+}
+
+func TestHandleInterrupts_SecondSignalExits(t *testing.T) {
+	sigCh := make(chan os.Signal, 2)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	buf := &bytes.Buffer{}
+	exited := make(chan int, 1)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		handleInterrupts(sigCh, cancel, buf, func(code int) { exited <- code })
+	}()
+
+	sigCh <- os.Interrupt
+	<-ctx.Done()
+	select {
+	case <-exited:
+		t.Fatal("first interrupt should not exit")
+	default:
+	}
+
+	sigCh <- os.Interrupt
+	<-done
+	if code := <-exited; code != 130 {
+		t.Fatalf("unexpected exit code: %d", code)
+	}
+	if !strings.Contains(buf.String(), "服务端") {
+		t.Fatalf("unexpected message: %q", buf.String())
+	}
+}
@@ -3,6 +3,12 @@ package cmd
 import (
 	"github.com/spf13/cobra"
 	"syl-listing-pro/internal/app"
+	"syl-listing-pro/internal/i18n"
+)
+
+var (
+	setKeyName  string
+	setKeyStdin bool
 )
 
 var setCmd = &cobra.Command{
@@ -11,14 +17,32 @@ var setCmd = &cobra.Command{
 }
 
 var setKeyCmd = &cobra.Command{
-	Use:   "key <syl_listing_key>",
+	Use:   "key [--name <profile>] <syl_listing_key | --stdin>",
 	Short: "设置 SYL_LISTING_KEY",
-	Args:  cobra.ExactArgs(1),
+	// 没有 --stdin 时沿用 cobra 的参数个数检查，KEY 必须且只能传一个。
+	Args: func(cmd *cobra.Command, args []string) error {
+		if setKeyStdin {
+			return cobra.MaximumNArgs(1)(cmd, args)
+		}
+		return cobra.ExactArgs(1)(cmd, args)
+	},
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return app.RunSetKey(cmd.Context(), args[0])
+		if setKeyStdin {
+			if len(args) > 0 {
+				return i18n.Errorf("使用 --stdin 时不要再在命令行传入 KEY")
+			}
+			key, err := app.ReadKey(cmd.InOrStdin())
+			if err != nil {
+				return err
+			}
+			return app.RunSetKey(cmd.Context(), setKeyName, key)
+		}
+		return app.RunSetKey(cmd.Context(), setKeyName, args[0])
 	},
 }
 
 func init() {
+	setKeyCmd.Flags().BoolVar(&setKeyStdin, "stdin", false, "从标准输入读取 KEY，避免出现在 shell 历史和进程列表中")
+	setKeyCmd.Flags().StringVar(&setKeyName, "name", "", "保存为指定名称的 KEY 配置（默认 default）")
 	setCmd.AddCommand(setKeyCmd)
 }
@@ -0,0 +1,19 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"syl-listing-pro/internal/app"
+)
+
+var smokeCmd = &cobra.Command{
+	Use:   "smoke [file]",
+	Short: "用内置小需求走一遍完整链路，逐阶段输出耗时",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		inputPath := ""
+		if len(args) == 1 {
+			inputPath = args[0]
+		}
+		return app.RunSmoke(cmd.Context(), cmd.OutOrStdout(), inputPath, keyProfile, skipDocx)
+	},
+}
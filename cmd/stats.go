@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"syl-listing-pro/internal/app"
+)
+
+var (
+	statsBy    string
+	statsSince string
+	statsLabel string
+)
+
+var statsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "按天或按周汇总生成情况",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return app.RunStats(cmd.OutOrStdout(), statsBy, statsSince, statsLabel)
+	},
+}
+
+func init() {
+	statsCmd.Flags().StringVar(&statsBy, "by", "day", "汇总周期：day|week")
+	statsCmd.Flags().StringVar(&statsSince, "since", "", "只统计最近一段时间的运行，例如 30d")
+	statsCmd.Flags().StringVar(&statsLabel, "label", "", "只统计带有该运行标签的运行")
+	_ = statsCmd.RegisterFlagCompletionFunc("by", completeValues("day", "week"))
+}
@@ -0,0 +1,24 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"syl-listing-pro/internal/app"
+)
+
+var tenantCmd = &cobra.Command{
+	Use:   "tenant",
+	Short: "租户相关查询",
+}
+
+var tenantInfoCmd = &cobra.Command{
+	Use:   "info",
+	Short: "查看服务端对当前租户的配置与限制（套餐、并发、候选数、规则通道）",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return app.RunTenantInfo(cmd.Context(), cmd.OutOrStdout(), keyProfile)
+	},
+}
+
+func init() {
+	tenantCmd.AddCommand(tenantInfoCmd)
+}
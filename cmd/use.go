@@ -0,0 +1,15 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"syl-listing-pro/internal/app"
+)
+
+var useCmd = &cobra.Command{
+	Use:   "use <profile>",
+	Short: "切换默认使用的 KEY 配置",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return app.RunUseKeyProfile(cmd.OutOrStdout(), args[0])
+	},
+}
@@ -0,0 +1,15 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"syl-listing-pro/internal/app"
+)
+
+var verifyOutputCmd = &cobra.Command{
+	Use:   "verify-output <file_or_dir ...>",
+	Short: "检查已生成的 listing：必需分节、禁用词与长度规则",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return app.RunVerifyOutput(cmd.OutOrStdout(), args, bannedWordsFile)
+	},
+}
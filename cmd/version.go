@@ -5,6 +5,8 @@ import (
 	"io"
 
 	"github.com/hooziwang/daddylovesyl"
+
+	"syl-listing-pro/internal/i18n"
 )
 
 var (
@@ -14,7 +16,7 @@ var (
 )
 
 func versionText() string {
-	return fmt.Sprintf("syl-listing-pro 版本：%s（commit: %s，构建时间: %s）", Version, Commit, BuildTime)
+	return i18n.T("syl-listing-pro 版本：%s（commit: %s，构建时间: %s）", Version, Commit, BuildTime)
 }
 
 func loveBanner(w io.Writer) string {
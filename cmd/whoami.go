@@ -0,0 +1,15 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"syl-listing-pro/internal/app"
+)
+
+var whoamiCmd = &cobra.Command{
+	Use:   "whoami",
+	Short: "显示当前 KEY 对应的租户与令牌信息",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return app.RunWhoami(cmd.Context(), cmd.OutOrStdout(), keyProfile)
+	},
+}
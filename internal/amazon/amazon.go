@@ -0,0 +1,115 @@
+// Package amazon 读取亚马逊上已有的 listing（商品详情页 HTML 或 SP-API 目录接口），
+// 转为需求 Markdown 骨架，用于“改写现有 listing”。
+package amazon
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+)
+
+// Listing 为从亚马逊读取到的现有 listing。
+type Listing struct {
+	ASIN        string
+	Title       string
+	Brand       string
+	Bullets     []string
+	Description string
+}
+
+var asinPattern = regexp.MustCompile(`^[A-Z0-9]{10}$`)
+
+// NormalizeASIN 去掉空白并转为大写，校验为 10 位字母数字。
+func NormalizeASIN(asin string) (string, error) {
+	asin = strings.ToUpper(strings.TrimSpace(asin))
+	if !asinPattern.MatchString(asin) {
+		return "", fmt.Errorf("ASIN 应为 10 位字母或数字：%s", asin)
+	}
+	return asin, nil
+}
+
+var (
+	titlePattern       = regexp.MustCompile(`(?is)<span[^>]*id="productTitle"[^>]*>(.*?)</span>`)
+	bylinePattern      = regexp.MustCompile(`(?is)<a[^>]*id="bylineInfo"[^>]*>(.*?)</a>`)
+	bulletsPattern     = regexp.MustCompile(`(?is)<div[^>]*id="feature-bullets"[^>]*>(.*?)</div>`)
+	listItemPattern    = regexp.MustCompile(`(?is)<li[^>]*>(.*?)</li>`)
+	descriptionPattern = regexp.MustCompile(`(?is)<div[^>]*id="productDescription"[^>]*>(.*?)</div>`)
+	asinInputPattern   = regexp.MustCompile(`(?is)<input[^>]*id="ASIN"[^>]*value="([A-Z0-9]{10})"`)
+	scriptPattern      = regexp.MustCompile(`(?is)<(script|style)[^>]*>.*?</(script|style)>`)
+	tagPattern         = regexp.MustCompile(`(?s)<[^>]+>`)
+	brandPrefixes      = []string{"Visit the ", "Brand: ", "品牌："}
+)
+
+// ParseProductHTML 从商品详情页 HTML（浏览器“另存为”或复制的源代码）中提取标题、品牌、五点与描述。
+// 页面结构以亚马逊桌面版为准；一项都没找到时返回错误。
+func ParseProductHTML(page string) (Listing, error) {
+	page = scriptPattern.ReplaceAllString(page, "")
+	var l Listing
+	if m := asinInputPattern.FindStringSubmatch(page); m != nil {
+		l.ASIN = m[1]
+	}
+	if m := titlePattern.FindStringSubmatch(page); m != nil {
+		l.Title = plainText(m[1])
+	}
+	if m := bylinePattern.FindStringSubmatch(page); m != nil {
+		brand := plainText(m[1])
+		for _, p := range brandPrefixes {
+			brand = strings.TrimPrefix(brand, p)
+		}
+		l.Brand = strings.TrimSuffix(strings.TrimSuffix(brand, " Store"), "品牌店")
+	}
+	if m := bulletsPattern.FindStringSubmatch(page); m != nil {
+		for _, item := range listItemPattern.FindAllStringSubmatch(m[1], -1) {
+			if text := plainText(item[1]); text != "" {
+				l.Bullets = append(l.Bullets, text)
+			}
+		}
+	}
+	if m := descriptionPattern.FindStringSubmatch(page); m != nil {
+		l.Description = plainText(m[1])
+	}
+	if l.Title == "" && len(l.Bullets) == 0 && l.Description == "" {
+		return Listing{}, fmt.Errorf("页面中未找到商品标题、五点或描述，请确认是亚马逊商品详情页")
+	}
+	return l, nil
+}
+
+// plainText 去掉标签、解码实体并把连续空白压成一个空格。
+func plainText(s string) string {
+	s = tagPattern.ReplaceAllString(s, " ")
+	return strings.Join(strings.Fields(html.UnescapeString(s)), " ")
+}
+
+// RequirementMarkdown 把现有 listing 转为需求 Markdown 骨架：frontmatter 记录 ASIN，
+// 正文列出现有标题、品牌、五点与描述，末尾留出改写要求由用户填写。
+func (l Listing) RequirementMarkdown() string {
+	var b strings.Builder
+	b.WriteString("---\n")
+	if l.ASIN != "" {
+		fmt.Fprintf(&b, "asin: %s\n", l.ASIN)
+	}
+	b.WriteString("source: amazon\n---\n\n")
+	title := l.Title
+	if title == "" {
+		title = l.ASIN
+	}
+	fmt.Fprintf(&b, "# 改写现有 listing：%s\n", title)
+	if l.Brand != "" {
+		fmt.Fprintf(&b, "\n## 品牌\n\n%s\n", l.Brand)
+	}
+	if l.Title != "" {
+		fmt.Fprintf(&b, "\n## 现有标题\n\n%s\n", l.Title)
+	}
+	if len(l.Bullets) > 0 {
+		b.WriteString("\n## 现有五点描述\n\n")
+		for _, item := range l.Bullets {
+			fmt.Fprintf(&b, "- %s\n", item)
+		}
+	}
+	if l.Description != "" {
+		fmt.Fprintf(&b, "\n## 现有详情描述\n\n%s\n", l.Description)
+	}
+	b.WriteString("\n## 改写要求\n\n<!-- 在此填写改写方向：目标关键词、卖点侧重、需要保留或删除的内容等 -->\n")
+	return b.String()
+}
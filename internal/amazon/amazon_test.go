@@ -0,0 +1,60 @@
+package amazon
+
+import (
+	"strings"
+	"testing"
+)
+
+const productPage = `<html><head><script>var x = "<span id=\"productTitle\">fake</span>";</script></head><body>
+<input type="hidden" id="ASIN" name="ASIN" value="B0TEST1234">
+<span id="productTitle" class="a-size-large">
+   Yoga Mat &amp; Strap, Non-Slip 6mm
+</span>
+<a id="bylineInfo" href="/stores/acme">Visit the ACME Store</a>
+<div id="feature-bullets" class="a-section"><ul>
+  <li><span class="a-list-item"> Non-slip <b>surface</b> </span></li>
+  <li><span class="a-list-item">Extra thick &ndash; 6mm</span></li>
+  <li><span class="a-list-item">  </span></li>
+</ul></div>
+<div id="productDescription"><p>Made for daily practice.</p><p>Easy to clean.</p></div>
+</body></html>`
+
+func TestParseProductHTML(t *testing.T) {
+	l, err := ParseProductHTML(productPage)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l.ASIN != "B0TEST1234" || l.Title != "Yoga Mat & Strap, Non-Slip 6mm" || l.Brand != "ACME" {
+		t.Fatalf("unexpected listing: %+v", l)
+	}
+	if len(l.Bullets) != 2 || l.Bullets[0] != "Non-slip surface" || l.Bullets[1] != "Extra thick – 6mm" {
+		t.Fatalf("unexpected bullets: %q", l.Bullets)
+	}
+	if l.Description != "Made for daily practice. Easy to clean." {
+		t.Fatalf("unexpected description: %q", l.Description)
+	}
+	if _, err := ParseProductHTML("<html><body>nothing</body></html>"); err == nil {
+		t.Fatal("expected error for a page without listing content")
+	}
+}
+
+func TestRequirementMarkdown(t *testing.T) {
+	md := Listing{ASIN: "B0TEST1234", Title: "Yoga Mat", Brand: "ACME", Bullets: []string{"a", "b"}}.RequirementMarkdown()
+	for _, want := range []string{"---\nasin: B0TEST1234\nsource: amazon\n---\n", "# 改写现有 listing：Yoga Mat", "## 品牌\n\nACME", "- a\n- b\n", "## 改写要求"} {
+		if !strings.Contains(md, want) {
+			t.Fatalf("missing %q in:\n%s", want, md)
+		}
+	}
+	if strings.Contains(md, "现有详情描述") {
+		t.Fatalf("empty description should be omitted:\n%s", md)
+	}
+}
+
+func TestNormalizeASIN(t *testing.T) {
+	if got, err := NormalizeASIN(" b0test1234 "); err != nil || got != "B0TEST1234" {
+		t.Fatalf("got %q, %v", got, err)
+	}
+	if _, err := NormalizeASIN("B0SHORT"); err == nil {
+		t.Fatal("expected error for short ASIN")
+	}
+}
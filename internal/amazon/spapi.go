@@ -0,0 +1,169 @@
+package amazon
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"syl-listing-pro/internal/i18n"
+)
+
+const (
+	// DefaultSPAPIEndpoint 为北美区 SP-API 地址；欧洲、远东区通过配置改为对应地址。
+	DefaultSPAPIEndpoint = "https://sellingpartnerapi-na.amazon.com"
+	// DefaultMarketplaceID 为美国站。
+	DefaultMarketplaceID = "ATVPDKIKX0DER"
+	defaultLWAEndpoint   = "https://api.amazon.com/auth/o2/token"
+)
+
+// ErrSPAPINotConfigured 表示没有配置 SP-API 凭据。
+var ErrSPAPINotConfigured = errors.New("spapi_not_configured")
+
+// SPAPIConfig 为 SP-API 凭据：LWA 应用的 client id / secret 与卖家授权的 refresh token。
+type SPAPIConfig struct {
+	ClientID      string
+	ClientSecret  string
+	RefreshToken  string
+	Endpoint      string
+	MarketplaceID string
+	// LWAEndpoint 为换取访问令牌的地址；为空时使用亚马逊官方地址。
+	LWAEndpoint string
+}
+
+// SPAPI 通过 Catalog Items API（2022-04-01）读取商品信息。
+type SPAPI struct {
+	cfg  SPAPIConfig
+	http *http.Client
+}
+
+func NewSPAPI(cfg SPAPIConfig) (*SPAPI, error) {
+	if cfg.ClientID == "" || cfg.ClientSecret == "" || cfg.RefreshToken == "" {
+		return nil, ErrSPAPINotConfigured
+	}
+	if cfg.Endpoint == "" {
+		cfg.Endpoint = DefaultSPAPIEndpoint
+	}
+	if cfg.MarketplaceID == "" {
+		cfg.MarketplaceID = DefaultMarketplaceID
+	}
+	if cfg.LWAEndpoint == "" {
+		cfg.LWAEndpoint = defaultLWAEndpoint
+	}
+	cfg.Endpoint = strings.TrimRight(cfg.Endpoint, "/")
+	return &SPAPI{cfg: cfg, http: &http.Client{Timeout: 30 * time.Second}}, nil
+}
+
+// accessToken 用 refresh token 换取 LWA 访问令牌。
+func (s *SPAPI) accessToken(ctx context.Context) (string, error) {
+	form := url.Values{
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {s.cfg.RefreshToken},
+		"client_id":     {s.cfg.ClientID},
+		"client_secret": {s.cfg.ClientSecret},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.cfg.LWAEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	var out struct {
+		AccessToken string `json:"access_token"`
+	}
+	if err := s.do(req, &out); err != nil {
+		return "", i18n.Errorf("SP-API 授权失败: %w", err)
+	}
+	if out.AccessToken == "" {
+		return "", i18n.Errorf("SP-API 授权失败: 未返回 access_token")
+	}
+	return out.AccessToken, nil
+}
+
+// catalogItem 为 Catalog Items API 响应中用到的字段。
+type catalogItem struct {
+	ASIN       string `json:"asin"`
+	Attributes struct {
+		ItemName           []attributeValue `json:"item_name"`
+		Brand              []attributeValue `json:"brand"`
+		BulletPoint        []attributeValue `json:"bullet_point"`
+		ProductDescription []attributeValue `json:"product_description"`
+	} `json:"attributes"`
+	Summaries []struct {
+		ItemName  string `json:"itemName"`
+		BrandName string `json:"brandName"`
+	} `json:"summaries"`
+}
+
+type attributeValue struct {
+	Value string `json:"value"`
+}
+
+// FetchListing 读取 ASIN 在配置站点上的标题、品牌、五点与描述。
+func (s *SPAPI) FetchListing(ctx context.Context, asin string) (Listing, error) {
+	token, err := s.accessToken(ctx)
+	if err != nil {
+		return Listing{}, err
+	}
+	q := url.Values{
+		"marketplaceIds": {s.cfg.MarketplaceID},
+		"includedData":   {"attributes,summaries"},
+	}
+	u := fmt.Sprintf("%s/catalog/2022-04-01/items/%s?%s", s.cfg.Endpoint, url.PathEscape(asin), q.Encode())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return Listing{}, err
+	}
+	req.Header.Set("x-amz-access-token", token)
+	var item catalogItem
+	if err := s.do(req, &item); err != nil {
+		return Listing{}, i18n.Errorf("读取商品 %s 失败: %w", asin, err)
+	}
+	l := Listing{ASIN: asin}
+	if v := firstValue(item.Attributes.ItemName); v != "" {
+		l.Title = v
+	} else if len(item.Summaries) > 0 {
+		l.Title = item.Summaries[0].ItemName
+	}
+	if v := firstValue(item.Attributes.Brand); v != "" {
+		l.Brand = v
+	} else if len(item.Summaries) > 0 {
+		l.Brand = item.Summaries[0].BrandName
+	}
+	for _, b := range item.Attributes.BulletPoint {
+		if text := plainText(b.Value); text != "" {
+			l.Bullets = append(l.Bullets, text)
+		}
+	}
+	l.Description = plainText(firstValue(item.Attributes.ProductDescription))
+	return l, nil
+}
+
+func firstValue(values []attributeValue) string {
+	for _, v := range values {
+		if s := strings.TrimSpace(v.Value); s != "" {
+			return s
+		}
+	}
+	return ""
+}
+
+func (s *SPAPI) do(req *http.Request, out any) error {
+	resp, err := s.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 4<<20))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("HTTP %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return json.Unmarshal(body, out)
+}
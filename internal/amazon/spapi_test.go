@@ -0,0 +1,49 @@
+package amazon
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSPAPIFetchListing(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/auth/o2/token":
+			_ = r.ParseForm()
+			if r.Form.Get("grant_type") != "refresh_token" || r.Form.Get("refresh_token") != "rt" {
+				t.Errorf("unexpected token request: %v", r.Form)
+			}
+			_, _ = io.WriteString(w, `{"access_token":"atz"}`)
+		case "/catalog/2022-04-01/items/B0TEST1234":
+			if r.Header.Get("x-amz-access-token") != "atz" || r.URL.Query().Get("marketplaceIds") != DefaultMarketplaceID {
+				t.Errorf("unexpected catalog request: %s %v", r.URL, r.Header)
+			}
+			_, _ = io.WriteString(w, `{"asin":"B0TEST1234","attributes":{"bullet_point":[{"value":"Non-slip"},{"value":"6mm"}],"product_description":[{"value":"<p>Great mat</p>"}]},"summaries":[{"itemName":"Yoga Mat","brandName":"ACME"}]}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer ts.Close()
+
+	api, err := NewSPAPI(SPAPIConfig{ClientID: "id", ClientSecret: "secret", RefreshToken: "rt", Endpoint: ts.URL, LWAEndpoint: ts.URL + "/auth/o2/token"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	l, err := api.FetchListing(context.Background(), "B0TEST1234")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l.Title != "Yoga Mat" || l.Brand != "ACME" || len(l.Bullets) != 2 || l.Description != "Great mat" {
+		t.Fatalf("unexpected listing: %+v", l)
+	}
+	if _, err := api.FetchListing(context.Background(), "B0MISSING0"); err == nil {
+		t.Fatal("expected error for unknown ASIN")
+	}
+	if _, err := NewSPAPI(SPAPIConfig{ClientID: "id"}); !errors.Is(err, ErrSPAPINotConfigured) {
+		t.Fatalf("err=%v", err)
+	}
+}
@@ -0,0 +1,118 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"syl-listing-pro/internal/client"
+	"syl-listing-pro/internal/i18n"
+)
+
+const (
+	// queueBackpressureWait 为判定服务端排队过长的等待时长：任务入队后超过该时长才开始执行即视为繁忙。
+	queueBackpressureWait = 60 * time.Second
+	// limiterBackoffCooldown 为两次降并发的最小间隔，避免同一波 429 把并发一路降到 1。
+	limiterBackoffCooldown = 10 * time.Second
+)
+
+// adaptiveLimiter 为按服务端压力调整的并发上限：遇到 429/503 或排队过长时减半，
+// 之后每连续成功“当前上限”个任务加 1，直到 max。
+type adaptiveLimiter struct {
+	mu        sync.Mutex
+	limit     int
+	max       int
+	inUse     int
+	successes int
+	lastDrop  time.Time
+	// changed 在有名额释放或上限变化时关闭，唤醒等待者。
+	changed chan struct{}
+	log     *Logger
+	now     func() time.Time
+}
+
+func newAdaptiveLimiter(max int, log *Logger) *adaptiveLimiter {
+	return &adaptiveLimiter{limit: max, max: max, changed: make(chan struct{}), log: log, now: time.Now}
+}
+
+// Acquire 等待一个名额；ctx 取消时返回其错误。
+func (l *adaptiveLimiter) Acquire(ctx context.Context) error {
+	for {
+		l.mu.Lock()
+		if l.inUse < l.limit {
+			l.inUse++
+			l.mu.Unlock()
+			return nil
+		}
+		changed := l.changed
+		l.mu.Unlock()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-changed:
+		}
+	}
+}
+
+func (l *adaptiveLimiter) Release() {
+	l.mu.Lock()
+	l.inUse--
+	l.notifyLocked()
+	l.mu.Unlock()
+}
+
+func (l *adaptiveLimiter) notifyLocked() {
+	close(l.changed)
+	l.changed = make(chan struct{})
+}
+
+// backoff 在服务端繁忙时把上限减半（最少 1）；冷却期内重复的信号忽略。l 为 nil 时不做任何事。
+func (l *adaptiveLimiter) backoff(reason string) {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	l.successes = 0
+	if l.limit <= 1 || (!l.lastDrop.IsZero() && now.Sub(l.lastDrop) < limiterBackoffCooldown) {
+		return
+	}
+	l.lastDrop = now
+	l.limit = max(1, l.limit/2)
+	l.log.Info(i18n.T("服务端繁忙（%s），并发降至 %d", reason, l.limit))
+}
+
+// success 记录一次成功；连续成功达到当前上限时上限加 1。
+func (l *adaptiveLimiter) success() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.limit >= l.max {
+		return
+	}
+	l.successes++
+	if l.successes < l.limit {
+		return
+	}
+	l.successes = 0
+	l.limit++
+	l.notifyLocked()
+	l.log.Info(i18n.T("服务端压力缓解，并发升至 %d", l.limit))
+}
+
+// observe 按任务结果调整上限：429（额度用完除外）与 503 视为服务端繁忙。
+func (l *adaptiveLimiter) observe(result taskResult) {
+	if result.ok {
+		l.success()
+		return
+	}
+	switch client.StatusCode(result.err) {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		if !errors.Is(result.err, client.ErrQuotaExceeded) {
+			l.backoff(fmt.Sprintf("HTTP %d", client.StatusCode(result.err)))
+		}
+	}
+}
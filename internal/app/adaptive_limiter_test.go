@@ -0,0 +1,59 @@
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestAdaptiveLimiter_BackoffAndRecover(t *testing.T) {
+	lg, _ := NewLogger(false, "")
+	l := newAdaptiveLimiter(8, lg)
+	now := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	l.now = func() time.Time { return now }
+
+	_ = captureStdout(t, func() {
+		l.backoff("HTTP 429")
+		l.backoff("HTTP 429") // 冷却期内忽略
+	})
+	if l.limit != 4 {
+		t.Fatalf("limit=%d want 4", l.limit)
+	}
+	now = now.Add(limiterBackoffCooldown)
+	_ = captureStdout(t, func() { l.backoff("HTTP 503") })
+	if l.limit != 2 {
+		t.Fatalf("limit=%d want 2", l.limit)
+	}
+	_ = captureStdout(t, func() {
+		for i := 0; i < 2+3; i++ {
+			l.success()
+		}
+	})
+	if l.limit != 4 {
+		t.Fatalf("limit=%d want 4 after ramp-up", l.limit)
+	}
+}
+
+func TestAdaptiveLimiter_AcquireBlocksAtLimit(t *testing.T) {
+	lg, _ := NewLogger(false, "")
+	l := newAdaptiveLimiter(1, lg)
+	if err := l.Acquire(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer cancel()
+	if err := l.Acquire(ctx); err == nil {
+		t.Fatal("second Acquire should block until ctx expires")
+	}
+	done := make(chan error, 1)
+	go func() { done <- l.Acquire(context.Background()) }()
+	l.Release()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Release did not wake waiter")
+	}
+}
@@ -0,0 +1,48 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"syl-listing-pro/internal/client"
+	"syl-listing-pro/internal/i18n"
+	"syl-listing-pro/internal/output"
+)
+
+// parseAppendPath 校验 --append，只接受 .md 文件。
+func parseAppendPath(p string) (string, error) {
+	p = strings.TrimSpace(p)
+	if p == "" {
+		return "", nil
+	}
+	if !strings.EqualFold(filepath.Ext(p), ".md") {
+		return "", i18n.Errorf("--append 仅支持 .md 文件：%s", p)
+	}
+	return p, nil
+}
+
+// appendCatalog 把任务的主稿追加到 --append 指定的总目录：一个商品一个二级标题（取需求文件名，候选加 #n），
+// 原 listing 标题整体下移两级。追加时锁定文件，多个任务或进程同时写入不会交错；失败只记录日志。
+func appendCatalog(log *Logger, opts GenOptions, prefix string, task generateTask, res client.ResultResp) {
+	if opts.Append == "" || strings.TrimSpace(res.ENMarkdown) == "" {
+		return
+	}
+	heading := strings.TrimSuffix(filepath.Base(task.file.Path), filepath.Ext(task.file.Path))
+	if opts.Num > 1 {
+		heading = fmt.Sprintf("%s #%d", heading, task.index)
+	}
+	section := fmt.Sprintf("## %s\n\n%s\n", heading, strings.TrimSpace(demoteHeadings(strings.ReplaceAll(res.ENMarkdown, "\r\n", "\n"), 2)))
+	enc, _ := output.ParseEncoding(opts.OutEncoding)
+	nl, _ := output.ParseNewlines(opts.OutNewlines)
+	err := os.MkdirAll(filepath.Dir(opts.Append), 0o755)
+	if err == nil {
+		err = output.AppendCatalog(opts.Append, section, enc, nl)
+	}
+	if err != nil {
+		log.Info(i18n.T("%s 追加到总目录失败：%v", prefix, err))
+		return
+	}
+	log.Info(i18n.T("%s 已追加到总目录：%s", prefix, mustAbsPath(opts.Append)))
+}
@@ -0,0 +1,68 @@
+package app
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseAppendPath(t *testing.T) {
+	if got, err := parseAppendPath(" catalog.MD "); err != nil || got != "catalog.MD" {
+		t.Fatalf("parseAppendPath = %q, %v", got, err)
+	}
+	if _, err := parseAppendPath("catalog.docx"); err == nil {
+		t.Fatal("expected unsupported extension error")
+	}
+}
+
+func TestRunGen_AppendCatalog(t *testing.T) {
+	prepareRunGenHome(t)
+	ts := newRunGenFastSuccessServer(t)
+	defer ts.Close()
+	oldBase := workerBaseURL
+	oldTimeout := streamTimeoutSecond
+	workerBaseURL = ts.URL
+	streamTimeoutSecond = 5
+	defer func() {
+		workerBaseURL = oldBase
+		streamTimeoutSecond = oldTimeout
+	}()
+
+	inDir := t.TempDir()
+	var inputs []string
+	for _, name := range []string{"mat.md", "bottle.md"} {
+		p := filepath.Join(inDir, name)
+		if err := os.WriteFile(p, []byte("# 输入"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		inputs = append(inputs, p)
+	}
+	catalog := filepath.Join(t.TempDir(), "catalog.md")
+	if err := os.WriteFile(catalog, []byte("# 商品总目录\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	out, err := captureStdoutRun(t, func() error {
+		return RunGen(context.Background(), GenOptions{OutputDir: t.TempDir(), Inputs: inputs, SkipDocx: true, Append: catalog})
+	})
+	if err != nil {
+		t.Fatalf("RunGen error: %v", err)
+	}
+	b, _ := os.ReadFile(catalog)
+	md := string(b)
+	if !strings.HasPrefix(md, "# 商品总目录\n\n## ") {
+		t.Fatalf("catalog header changed:\n%s", md)
+	}
+	for _, want := range []string{"## mat\n\n### EN\n", "## bottle\n\n### EN\n"} {
+		if !strings.Contains(md, want) {
+			t.Fatalf("catalog missing %q:\n%s", want, md)
+		}
+	}
+	if strings.Contains(md, "CN") {
+		t.Fatalf("catalog should only contain the primary listing:\n%s", md)
+	}
+	if strings.Count(out, "已追加到总目录") != 2 {
+		t.Fatalf("append not logged: %s", out)
+	}
+}
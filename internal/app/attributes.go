@@ -0,0 +1,32 @@
+package app
+
+import (
+	"strings"
+
+	"syl-listing-pro/internal/client"
+	"syl-listing-pro/internal/input"
+	"syl-listing-pro/internal/manifest"
+)
+
+// productAttributes 合并命令行与需求文件 frontmatter 中的商品属性：
+// brand、category、target_audience 以 frontmatter 为准，key_features 两者合并去重。
+func productAttributes(base client.ProductAttributes, fm map[string]string) client.ProductAttributes {
+	out := base
+	for key, dst := range map[string]*string{"brand": &out.Brand, "category": &out.Category, "target_audience": &out.TargetAudience} {
+		if v := strings.Trim(strings.TrimSpace(fm[key]), `"'`); v != "" {
+			*dst = v
+		}
+	}
+	out.KeyFeatures = mergeKeywords(base.KeyFeatures, input.SplitList(fm["key_features"])...)
+	return out
+}
+
+// manifestAttributes 取出运行记录中的商品属性，续跑与重新生成沿用原运行的设置。
+func manifestAttributes(m manifest.Manifest) client.ProductAttributes {
+	return client.ProductAttributes{
+		Brand:          m.Brand,
+		Category:       m.Category,
+		TargetAudience: m.TargetAudience,
+		KeyFeatures:    m.KeyFeatures,
+	}
+}
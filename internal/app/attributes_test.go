@@ -0,0 +1,96 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"syl-listing-pro/internal/client"
+)
+
+func TestProductAttributes(t *testing.T) {
+	base := client.ProductAttributes{Brand: "Flag", Category: "Sports", KeyFeatures: []string{"Thick", "Eco"}}
+	got := productAttributes(base, map[string]string{"brand": `"Acme"`, "target_audience": "Beginners", "key_features": "[eco, Non-slip]"})
+	if got.Brand != "Acme" || got.Category != "Sports" || got.TargetAudience != "Beginners" {
+		t.Fatalf("unexpected attributes: %+v", got)
+	}
+	if strings.Join(got.KeyFeatures, "|") != "Thick|Eco|Non-slip" {
+		t.Fatalf("key features=%v", got.KeyFeatures)
+	}
+	if got := productAttributes(client.ProductAttributes{}, nil); got.Brand != "" || len(got.KeyFeatures) != 0 {
+		t.Fatalf("expected empty attributes, got %+v", got)
+	}
+}
+
+func TestRunGen_SubmitsProductAttributes(t *testing.T) {
+	prepareRunGenHome(t)
+	inner := newRunGenFastSuccessServer(t)
+	defer inner.Close()
+	var mu sync.Mutex
+	var bodies []map[string]any
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost && r.URL.Path == "/v1/generate" {
+			b, _ := io.ReadAll(r.Body)
+			var body map[string]any
+			_ = json.Unmarshal(b, &body)
+			mu.Lock()
+			bodies = append(bodies, body)
+			mu.Unlock()
+			r.Body = io.NopCloser(bytes.NewReader(b))
+		}
+		inner.Config.Handler.ServeHTTP(w, r)
+	}))
+	defer ts.Close()
+	oldBase := workerBaseURL
+	oldTimeout := streamTimeoutSecond
+	workerBaseURL = ts.URL
+	streamTimeoutSecond = 5
+	defer func() {
+		workerBaseURL = oldBase
+		streamTimeoutSecond = oldTimeout
+	}()
+
+	dir := t.TempDir()
+	withFM := filepath.Join(dir, "a.md")
+	plain := filepath.Join(dir, "b.md")
+	if err := os.WriteFile(withFM, []byte("---\nbrand: Acme\nkey_features: eco\n---\ncontent\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(plain, []byte("content\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	_, err := captureStdoutRun(t, func() error {
+		return RunGen(context.Background(), GenOptions{OutputDir: t.TempDir(), Inputs: []string{withFM}, SkipDocx: true, Attributes: client.ProductAttributes{Category: " Yoga ", KeyFeatures: []string{"Thick"}}})
+	})
+	if err != nil {
+		t.Fatalf("RunGen error: %v", err)
+	}
+	_, err = captureStdoutRun(t, func() error {
+		return RunGen(context.Background(), GenOptions{OutputDir: t.TempDir(), Inputs: []string{plain}, SkipDocx: true})
+	})
+	if err != nil {
+		t.Fatalf("RunGen error: %v", err)
+	}
+	if len(bodies) != 2 {
+		t.Fatalf("expected two generate requests, got %d", len(bodies))
+	}
+	if bodies[0]["brand"] != "Acme" || bodies[0]["category"] != "Yoga" {
+		t.Fatalf("unexpected attributes in request: %v", bodies[0])
+	}
+	if features, _ := bodies[0]["key_features"].([]any); len(features) != 2 || features[0] != "Thick" || features[1] != "eco" {
+		t.Fatalf("key_features=%v", bodies[0]["key_features"])
+	}
+	for _, k := range []string{"brand", "category", "target_audience", "key_features"} {
+		if _, ok := bodies[1][k]; ok {
+			t.Fatalf("empty attribute %s should be omitted: %v", k, bodies[1])
+		}
+	}
+}
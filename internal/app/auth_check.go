@@ -0,0 +1,63 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"syl-listing-pro/internal/client"
+	"syl-listing-pro/internal/i18n"
+)
+
+// RunAuthCheck 执行一次换取令牌，失败时区分 KEY 无效、KEY 过期、时钟偏差、网络与服务端问题。
+func RunAuthCheck(ctx context.Context, w io.Writer, profile string) error {
+	profile, err := resolveKeyProfile(profile)
+	if err != nil {
+		return err
+	}
+	sylKey, err := loadSYLKeyForRun(profile)
+	if err != nil {
+		return err
+	}
+	baseURL := resolveWorkerBaseURL()
+	ex, err := client.New(baseURL).Exchange(ctx, sylKey)
+	if err == nil {
+		fmt.Fprintln(w, i18n.T("✓ KEY 有效：租户 %s（KEY 配置 %s）", ex.TenantID, profile))
+		return nil
+	}
+	if client.IsCanceled(err) {
+		return err
+	}
+	d := client.DiagnoseAuthError(err, time.Now())
+	reason, hint := authFailureText(d, baseURL)
+	fmt.Fprintf(w, "✗ %s\n", reason)
+	if d.Message != "" {
+		fmt.Fprintln(w, i18n.T("  服务端说明：%s", d.Message))
+	}
+	fmt.Fprintln(w, i18n.T("  建议：%s", hint))
+	return i18n.Errorf("认证检查未通过：%s", d.Failure)
+}
+
+func authFailureText(d client.AuthDiagnosis, baseURL string) (string, string) {
+	switch d.Failure {
+	case client.AuthFailureInvalidKey:
+		return i18n.T("KEY 无效或已被吊销"), i18n.T("确认 KEY 是否正确，重新执行 syl-listing-pro set key <SYL_LISTING_KEY>")
+	case client.AuthFailureExpiredKey:
+		return i18n.T("KEY 已过期"), i18n.T("联系管理员续期或更换 KEY")
+	case client.AuthFailureClockSkew:
+		if d.ClockSkew != 0 {
+			return i18n.T("本机时间与服务端相差 %s", d.ClockSkew), i18n.T("校准系统时间（开启 NTP 自动同步）后重试")
+		}
+		return i18n.T("本机时间与服务端不一致"), i18n.T("校准系统时间（开启 NTP 自动同步）后重试")
+	case client.AuthFailureNetwork:
+		return i18n.T("无法连接服务端 %s", baseURL), i18n.T("检查网络、代理设置或 SYL_LISTING_WORKER_URL")
+	case client.AuthFailureServer:
+		return i18n.T("服务端暂时不可用（HTTP %d）", d.StatusCode), i18n.T("稍后重试")
+	default:
+		if d.StatusCode > 0 {
+			return i18n.T("认证失败（HTTP %d）", d.StatusCode), i18n.T("使用 --verbose 查看完整请求与响应")
+		}
+		return i18n.T("认证失败"), i18n.T("使用 --verbose 查看完整请求与响应")
+	}
+}
@@ -0,0 +1,50 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRunAuthCheck(t *testing.T) {
+	prepareRunGenHome(t)
+	cases := []struct {
+		name    string
+		handler http.HandlerFunc
+		want    string
+	}{
+		{"ok", func(w http.ResponseWriter, r *http.Request) {
+			_, _ = io.WriteString(w, `{"access_token":"at","tenant_id":"demo","expires_in":3600}`)
+		}, "KEY 有效：租户 demo"},
+		{"invalid", func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, `{"error":"invalid_key"}`, http.StatusUnauthorized)
+		}, "KEY 无效"},
+		{"expired", func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, `{"error":"key_expired"}`, http.StatusUnauthorized)
+		}, "KEY 已过期"},
+		{"skew", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Date", time.Now().Add(time.Hour).UTC().Format(http.TimeFormat))
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+		}, "本机时间与服务端相差"},
+	}
+	oldBase := workerBaseURL
+	defer func() { workerBaseURL = oldBase }()
+	for _, tc := range cases {
+		ts := httptest.NewServer(tc.handler)
+		workerBaseURL = ts.URL
+		var buf bytes.Buffer
+		err := RunAuthCheck(context.Background(), &buf, "")
+		ts.Close()
+		if (tc.name == "ok") != (err == nil) {
+			t.Fatalf("%s: unexpected err: %v", tc.name, err)
+		}
+		if !strings.Contains(buf.String(), tc.want) {
+			t.Fatalf("%s: missing %q in:\n%s", tc.name, tc.want, buf.String())
+		}
+	}
+}
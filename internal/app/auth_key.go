@@ -2,16 +2,34 @@ package app
 
 import (
 	"errors"
-	"fmt"
 
 	"syl-listing-pro/internal/config"
+	"syl-listing-pro/internal/i18n"
 )
 
-func loadSYLKeyForRun() (string, error) {
-	key, err := config.LoadSYLListingKey()
+// resolveKeyProfile 返回本次使用的 KEY 配置：显式指定优先，否则取 use 选中的配置。
+func resolveKeyProfile(profile string) (string, error) {
+	if profile == "" {
+		return config.LoadActiveKeyProfile()
+	}
+	if err := config.ValidateKeyProfile(profile); err != nil {
+		return "", i18n.Errorf("KEY 配置名只能包含字母、数字和下划线: %s", profile)
+	}
+	return profile, nil
+}
+
+func loadSYLKeyForRun(profile string) (string, error) {
+	profile, err := resolveKeyProfile(profile)
+	if err != nil {
+		return "", err
+	}
+	key, err := config.LoadSYLListingKeyProfile(profile)
 	if err != nil {
 		if errors.Is(err, config.ErrSYLKeyNotConfigured) {
-			return "", fmt.Errorf("尚未配置 KEY，需要执行\nsyl-listing-pro set key <SYL_LISTING_KEY>")
+			if profile == config.DefaultKeyProfile {
+				return "", i18n.Errorf("尚未配置 KEY，需要执行\nsyl-listing-pro set key <SYL_LISTING_KEY>")
+			}
+			return "", i18n.Errorf("KEY 配置 %s 尚未配置 KEY，需要执行\nsyl-listing-pro set key --name %s <SYL_LISTING_KEY>", profile, profile)
 		}
 		return "", err
 	}
@@ -11,7 +11,7 @@ func TestLoadSYLKeyForRun(t *testing.T) {
 	home := t.TempDir()
 	t.Setenv("HOME", home)
 
-	_, err := loadSYLKeyForRun()
+	_, err := loadSYLKeyForRun("")
 	if err == nil || !strings.Contains(err.Error(), "尚未配置 KEY") {
 		t.Fatalf("unexpected err: %v", err)
 	}
@@ -23,7 +23,7 @@ func TestLoadSYLKeyForRun(t *testing.T) {
 	if err := os.WriteFile(envPath, []byte("SYL_LISTING_KEY=abc\n"), 0o644); err != nil {
 		t.Fatal(err)
 	}
-	key, err := loadSYLKeyForRun()
+	key, err := loadSYLKeyForRun("")
 	if err != nil {
 		t.Fatalf("load key error: %v", err)
 	}
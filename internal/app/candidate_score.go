@@ -0,0 +1,168 @@
+package app
+
+import (
+	"encoding/json"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"syl-listing-pro/internal/i18n"
+	"syl-listing-pro/internal/input"
+	"syl-listing-pro/internal/listing"
+	"syl-listing-pro/internal/manifest"
+	"syl-listing-pro/internal/output"
+)
+
+// 候选评分的各项权重，总分 100。
+const (
+	scoreWeightCompliance = 40
+	scoreWeightKeywords   = 40
+	scoreWeightBalance    = 20
+)
+
+// candidateScore 为 --auto-pick 的本地评分：各分项取 0~1，Score 为加权后的 0~100 总分。
+type candidateScore struct {
+	Score           float64 `json:"score"`
+	Compliance      float64 `json:"compliance"`
+	KeywordCoverage float64 `json:"keyword_coverage"`
+	LengthBalance   float64 `json:"length_balance"`
+	Best            bool    `json:"best,omitempty"`
+}
+
+// scoreCandidate 按约束符合度、关键词覆盖、长度均衡给候选打分。
+// 约束符合度：每条校验报告、命中的禁用词或缺失的标题/五点/描述分节扣 0.2；
+// 关键词覆盖：出现过的关键词占比，没有关键词时记满分；
+// 长度均衡：1 减去五点长度的变异系数，五点少于两条时记满分。
+func scoreCandidate(l listing.Listing, md string, validation []string, banned, keywords []string) candidateScore {
+	issues := len(validation)
+	if len(banned) > 0 {
+		issues += len(listing.FindBannedWords(md, banned))
+	}
+	for _, kind := range []string{listing.KindTitle, listing.KindBullets, listing.KindDescription} {
+		if s, ok := l.Section(kind); !ok || strings.TrimSpace(s.Body) == "" {
+			issues++
+		}
+	}
+	sc := candidateScore{
+		Compliance:      clamp01(1 - 0.2*float64(issues)),
+		KeywordCoverage: 1,
+		LengthBalance:   1,
+	}
+	if len(keywords) > 0 {
+		coverage := listing.CheckKeywordCoverage(md, keywords)
+		sc.KeywordCoverage = float64(len(coverage)-len(listing.MissingKeywords(coverage))) / float64(len(coverage))
+	}
+	if bullets, ok := l.Section(listing.KindBullets); ok && len(bullets.Items) > 1 {
+		sc.LengthBalance = clamp01(1 - variation(bullets.Items))
+	}
+	sc.Score = math.Round((sc.Compliance*scoreWeightCompliance+sc.KeywordCoverage*scoreWeightKeywords+sc.LengthBalance*scoreWeightBalance)*10) / 10
+	return sc
+}
+
+// variation 返回各项字符数的变异系数（标准差 / 平均值）。
+func variation(items []string) float64 {
+	var sum float64
+	lens := make([]float64, len(items))
+	for i, it := range items {
+		lens[i] = float64(len([]rune(it)))
+		sum += lens[i]
+	}
+	mean := sum / float64(len(lens))
+	if mean == 0 {
+		return 0
+	}
+	var sq float64
+	for _, v := range lens {
+		sq += (v - mean) * (v - mean)
+	}
+	return math.Sqrt(sq/float64(len(lens))) / mean
+}
+
+func clamp01(v float64) float64 {
+	return math.Max(0, math.Min(1, v))
+}
+
+// runAutoPick 给每个需求文件的候选本地评分，把得分最高的复制为 <base>_best_<lang>.md
+// （有 Word 时一并复制）并记入运行记录；各候选得分写入其 JSON 附带文件。
+// 已在此前运行中选定最终稿的需求文件跳过。
+func runAutoPick(log *Logger, opts GenOptions, cp *manifest.Checkpoint) {
+	if cp == nil {
+		return
+	}
+	m := cp.Snapshot()
+	tasks := map[string]manifest.Task{}
+	selected := map[string]bool{}
+	for _, t := range m.Tasks {
+		tasks[t.Key] = t
+		if t.Selected {
+			selected[t.InputPath] = true
+		}
+	}
+	for _, g := range candidateGroups(m) {
+		if selected[g.inputPath] {
+			continue
+		}
+		keywords := opts.Keywords
+		if b, err := os.ReadFile(g.inputPath); err == nil {
+			fm, _ := input.SplitFrontmatter(string(b))
+			keywords = mergeKeywords(opts.Keywords, input.SplitList(fm["keywords"])...)
+		}
+		scores := make([]candidateScore, len(g.candidates))
+		best := 0
+		var parts []string
+		for i, c := range g.candidates {
+			md, _ := os.ReadFile(c.en)
+			cnMD, _ := os.ReadFile(c.cn)
+			scores[i] = scoreCandidate(c.listing, string(md)+"\n"+string(cnMD), tasks[c.key].ValidationReport, opts.bannedWords, keywords)
+			if scores[i].Score > scores[best].Score {
+				best = i
+			}
+			parts = append(parts, i18n.T("候选 %d：%.1f", c.index, scores[i].Score))
+		}
+		scores[best].Best = true
+		c := g.candidates[best]
+		enBest, cnBest := output.BestPair(filepath.Dir(c.en), g.inputPath, candidateLangs(c))
+		finals, err := copyCandidateOutputs(c, enBest, cnBest)
+		if err != nil {
+			log.Info(i18n.T("保存最佳候选失败：%v", err))
+			continue
+		}
+		if err := cp.Update(c.key, func(t *manifest.Task) {
+			t.Selected = true
+			t.FinalOutputs = finals
+		}); err != nil {
+			log.Info(i18n.T("运行记录写入失败：%v", err))
+		}
+		for i, cand := range g.candidates {
+			if err := writeCandidateScore(cp, tasks[cand.key], cand, scores[i]); err != nil {
+				log.Info(err.Error())
+			}
+		}
+		log.Info(i18n.T("%s 候选评分：%s", g.inputPath, strings.Join(parts, "，")))
+		log.Info(i18n.T("已自动选定候选 %d（%.1f 分）：%s", c.index, scores[best].Score, strings.Join(finals, "、")))
+	}
+}
+
+// writeCandidateScore 把评分写入候选的 JSON 附带文件；附带文件不存在时新建并记入任务产物。
+func writeCandidateScore(cp *manifest.Checkpoint, t manifest.Task, c pickCandidate, sc candidateScore) error {
+	p := sidecarPath(c.en)
+	data := listingSidecar{InputPath: mustAbsPath(t.InputPath), JobID: t.JobID, Label: t.Label}
+	b, err := os.ReadFile(p)
+	exists := err == nil
+	if exists {
+		if err := json.Unmarshal(b, &data); err != nil {
+			return i18n.Errorf("读取报告失败：%s：%v", p, err)
+		}
+	}
+	data.CandidateScore = &sc
+	if _, err := writeSidecar(c.en, data); err != nil {
+		return err
+	}
+	if exists {
+		return nil
+	}
+	return cp.Update(c.key, func(t *manifest.Task) {
+		t.Outputs = append(t.Outputs, p)
+	})
+}
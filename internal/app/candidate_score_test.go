@@ -0,0 +1,24 @@
+package app
+
+import (
+	"testing"
+
+	"syl-listing-pro/internal/listing"
+)
+
+func TestScoreCandidate(t *testing.T) {
+	good := "# Title\nYoga Mat\n\n# Bullets\n- eco friendly grips\n- thick soft cushion\n\n# Description\nA yoga mat.\n"
+	sc := scoreCandidate(listing.Parse(good), good, nil, nil, []string{"yoga mat", "eco"})
+	if sc.Score != 100 || sc.Compliance != 1 || sc.KeywordCoverage != 1 {
+		t.Fatalf("unexpected score for good candidate: %+v", sc)
+	}
+
+	bad := "# Title\nMat\n\n# Bullets\n- best\n- a much longer bullet that drags on and on\n"
+	sc = scoreCandidate(listing.Parse(bad), bad, []string{"标题接近上限"}, []string{"best"}, []string{"yoga mat", "eco"})
+	if sc.Compliance > 0.41 || sc.KeywordCoverage != 0 || sc.LengthBalance >= 1 {
+		t.Fatalf("unexpected components for bad candidate: %+v", sc)
+	}
+	if sc.Score >= 40 {
+		t.Fatalf("bad candidate scored too high: %+v", sc)
+	}
+}
@@ -0,0 +1,108 @@
+package app
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"syl-listing-pro/internal/i18n"
+	"syl-listing-pro/internal/listing"
+)
+
+// lengthRule 是校验报告中给出的长度规则区间与容差区间。
+type lengthRule struct {
+	Min    int `json:"min"`
+	Max    int `json:"max"`
+	TolMin int `json:"tolerance_min"`
+	TolMax int `json:"tolerance_max"`
+}
+
+// itemCount 是列表项（如五点描述的一条）的长度。
+type itemCount struct {
+	Chars int         `json:"chars"`
+	Bytes int         `json:"bytes"`
+	Rule  *lengthRule `json:"rule,omitempty"`
+}
+
+// sectionCount 是一个分节正文的字符数与字节数（UTF-8）。
+type sectionCount struct {
+	Section string      `json:"section"`
+	Chars   int         `json:"chars"`
+	Bytes   int         `json:"bytes"`
+	Items   []itemCount `json:"items,omitempty"`
+}
+
+// countSections 统计 listing 各分节长度；rules 按条目序号（从 1 开始）给出列表项的规则区间。
+func countSections(md string, rules map[int]lengthRule) []sectionCount {
+	var out []sectionCount
+	for _, s := range listing.Parse(md).Sections {
+		c := sectionCount{Section: s.Key(), Chars: s.Chars(), Bytes: len(s.Body)}
+		for i, item := range s.Items {
+			ic := itemCount{Chars: utf8.RuneCountInString(item), Bytes: len(item)}
+			if r, ok := rules[i+1]; ok && s.Kind == listing.KindBullets {
+				rule := r
+				ic.Rule = &rule
+			}
+			c.Items = append(c.Items, ic)
+		}
+		out = append(out, c)
+	}
+	return out
+}
+
+// hasSectionCounts 表示至少一种语言统计到了分节，需要写入附带文件。
+func hasSectionCounts(counts map[string][]sectionCount) bool {
+	for _, c := range counts {
+		if len(c) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// lineLengthRules 从校验报告中取出“第 N 条长度不满足约束”给出的规则区间，复用报告的约束格式解析。
+func lineLengthRules(report []string) map[int]lengthRule {
+	rules := map[int]lengthRule{}
+	for _, item := range report {
+		m := lineLengthConstraintPattern.FindStringSubmatch(strings.TrimSpace(item))
+		if len(m) != 7 {
+			continue
+		}
+		n, _ := strconv.Atoi(m[1])
+		minV, _ := strconv.Atoi(m[3])
+		maxV, _ := strconv.Atoi(m[4])
+		tolMin, _ := strconv.Atoi(m[5])
+		tolMax, _ := strconv.Atoi(m[6])
+		rules[n] = lengthRule{Min: minV, Max: maxV, TolMin: tolMin, TolMax: tolMax}
+	}
+	return rules
+}
+
+// formatSectionCounts 生成一行分节长度摘要，例如“title 120 / bullets 198,251[200,250] / description 1500”。
+func formatSectionCounts(counts []sectionCount) string {
+	parts := make([]string, 0, len(counts))
+	for _, c := range counts {
+		if len(c.Items) == 0 {
+			parts = append(parts, fmt.Sprintf("%s %d", c.Section, c.Chars))
+			continue
+		}
+		items := make([]string, 0, len(c.Items))
+		for _, it := range c.Items {
+			s := strconv.Itoa(it.Chars)
+			if it.Rule != nil {
+				s += fmt.Sprintf("[%d,%d]", it.Rule.Min, it.Rule.Max)
+			}
+			items = append(items, s)
+		}
+		parts = append(parts, fmt.Sprintf("%s %s", c.Section, strings.Join(items, ",")))
+	}
+	return strings.Join(parts, " / ")
+}
+
+func logSectionCounts(log *Logger, prefix, lang string, counts []sectionCount) {
+	if len(counts) == 0 {
+		return
+	}
+	log.Info(i18n.T("%s %s 字符数：%s", prefix, strings.ToUpper(lang), formatSectionCounts(counts)))
+}
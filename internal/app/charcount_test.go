@@ -0,0 +1,27 @@
+package app
+
+import (
+	"testing"
+)
+
+func TestCountSections(t *testing.T) {
+	md := "# Title\nBottle 32oz\n## Bullet Points\n- Cold 24h\n- 保冷\n## Description\nGreat."
+	rules := lineLengthRules([]string{"第2条长度不满足约束: 251（规则区间 [200,250]，容差区间 [190,260]）", "其他问题"})
+	counts := countSections(md, rules)
+	if len(counts) != 3 {
+		t.Fatalf("counts=%+v", counts)
+	}
+	if c := counts[0]; c.Section != "title" || c.Chars != 11 || c.Bytes != 11 {
+		t.Fatalf("title=%+v", c)
+	}
+	bullets := counts[1]
+	if len(bullets.Items) != 2 || bullets.Items[1].Chars != 2 || bullets.Items[1].Bytes != 6 {
+		t.Fatalf("bullets=%+v", bullets)
+	}
+	if bullets.Items[0].Rule != nil || bullets.Items[1].Rule == nil || bullets.Items[1].Rule.Max != 250 {
+		t.Fatalf("rules not attached: %+v", bullets.Items)
+	}
+	if got, want := formatSectionCounts(counts), "title 11 / bullets 8,2[200,250] / description 6"; got != want {
+		t.Fatalf("summary=%q want=%q", got, want)
+	}
+}
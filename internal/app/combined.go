@@ -0,0 +1,220 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode"
+
+	"syl-listing-pro/internal/i18n"
+	"syl-listing-pro/internal/listing"
+	"syl-listing-pro/internal/manifest"
+	"syl-listing-pro/internal/output"
+)
+
+// parseCombinedPath 校验 --combined，只接受 .md 与 .docx。
+func parseCombinedPath(p string) (string, error) {
+	p = strings.TrimSpace(p)
+	if p == "" {
+		return "", nil
+	}
+	switch strings.ToLower(filepath.Ext(p)) {
+	case ".md", ".docx":
+		return p, nil
+	}
+	return "", i18n.Errorf("--combined 仅支持 .md 或 .docx 文件：%s", p)
+}
+
+// combinedEntry 为合并文档中的一个商品：主稿与对照稿 Markdown。
+type combinedEntry struct {
+	heading     string
+	primary     string
+	secondary   string
+	primaryMD   string
+	secondaryMD string
+}
+
+// writeCombined 把本次运行全部成功的 listing 按任务顺序合并为一份带目录的文档；
+// 选定过最终稿的需求文件只收录选定的候选。
+func writeCombined(ctx context.Context, log *Logger, opts GenOptions, tenantID string, cp *manifest.Checkpoint) {
+	if opts.Combined == "" {
+		return
+	}
+	if cp == nil {
+		log.Info(i18n.T("运行记录不可用，跳过合并文档"))
+		return
+	}
+	entries, err := combinedEntries(cp.Snapshot())
+	if err != nil {
+		log.Info(i18n.T("合并文档失败：%v", err))
+		return
+	}
+	if len(entries) == 0 {
+		log.Info(i18n.T("没有成功的 listing，跳过合并文档"))
+		return
+	}
+	enc, _ := output.ParseEncoding(opts.OutEncoding)
+	nl, _ := output.ParseNewlines(opts.OutNewlines)
+	if err := os.MkdirAll(filepath.Dir(opts.Combined), 0o755); err != nil {
+		log.Info(i18n.T("合并文档失败：%v", err))
+		return
+	}
+	md := buildCombinedMarkdown(entries)
+	path := opts.Combined
+	if strings.EqualFold(filepath.Ext(path), ".docx") {
+		path, err = convertCombinedDocx(ctx, md, path)
+		if err == nil {
+			meta := docxMetadata{RunID: opts.runID, TenantID: tenantID, GeneratedAt: time.Now().Format(time.RFC3339), CLIVersion: opts.CLIVersion}
+			if err := setDocxProperties(path, meta); err != nil {
+				log.Info(i18n.T("合并文档属性写入失败：%v", err))
+			}
+		}
+	} else {
+		err = os.WriteFile(path, output.EncodeText(md, enc, nl), 0o644)
+	}
+	if err != nil {
+		log.Info(i18n.T("合并文档失败：%v", err))
+		return
+	}
+	log.Info(i18n.T("合并文档已写入（%d 个 listing）：%s", len(entries), mustAbsPath(path)))
+}
+
+// convertCombinedDocx 把合并后的 Markdown 写到临时目录再转换为 Word。
+func convertCombinedDocx(ctx context.Context, md, target string) (string, error) {
+	dir, err := os.MkdirTemp("", "syl-combined-")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(dir)
+	src := filepath.Join(dir, strings.TrimSuffix(filepath.Base(target), filepath.Ext(target))+".md")
+	if err := os.WriteFile(src, []byte(md), 0o644); err != nil {
+		return "", err
+	}
+	return convertMarkdownToDocxFunc(ctx, src, target)
+}
+
+func combinedEntries(m manifest.Manifest) ([]combinedEntry, error) {
+	selected := map[string]bool{}
+	bases := map[string]map[string]bool{}
+	for _, t := range m.Tasks {
+		if t.Selected {
+			selected[t.InputPath] = true
+		}
+		base := strings.TrimSuffix(filepath.Base(t.InputPath), filepath.Ext(t.InputPath))
+		if bases[base] == nil {
+			bases[base] = map[string]bool{}
+		}
+		bases[base][t.InputPath] = true
+	}
+	var entries []combinedEntry
+	for _, t := range m.Tasks {
+		if !t.Done() || (selected[t.InputPath] && !t.Selected) {
+			continue
+		}
+		en, cn := markdownOutputs(t.Outputs)
+		if en == "" {
+			continue
+		}
+		primary, err := readMarkdownOutput(en)
+		if err != nil {
+			return nil, err
+		}
+		secondary, err := readMarkdownOutput(cn)
+		if err != nil {
+			return nil, err
+		}
+		heading := strings.TrimSuffix(filepath.Base(t.InputPath), filepath.Ext(t.InputPath))
+		if len(bases[heading]) > 1 {
+			// 不同目录下的同名需求文件用完整路径区分。
+			heading = t.InputPath
+		}
+		if m.Num > 1 && !t.Selected {
+			heading = fmt.Sprintf("%s #%d", heading, t.Index)
+		}
+		entries = append(entries, combinedEntry{
+			heading:     heading,
+			primary:     strings.ToUpper(output.LangOf(en)),
+			secondary:   strings.ToUpper(output.LangOf(cn)),
+			primaryMD:   primary,
+			secondaryMD: secondary,
+		})
+	}
+	return entries, nil
+}
+
+// readMarkdownOutput 读取产物 Markdown，去掉 BOM 与来源注释并统一为 LF 换行。
+func readMarkdownOutput(path string) (string, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return listing.StripProvenance(strings.ReplaceAll(strings.TrimPrefix(string(b), "\ufeff"), "\r\n", "\n")), nil
+}
+
+// buildCombinedMarkdown 生成合并文档：一级标题、目录，每个商品一个二级标题，
+// 其下按语言分三级标题，原 listing 的标题整体下移三级（最多到六级）。
+func buildCombinedMarkdown(entries []combinedEntry) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n## %s\n\n", i18n.T("Listing 汇总"), i18n.T("目录"))
+	anchors := map[string]int{headingAnchor(i18n.T("Listing 汇总"), nil): 1, headingAnchor(i18n.T("目录"), nil): 1}
+	for i, e := range entries {
+		fmt.Fprintf(&b, "%d. [%s](#%s)\n", i+1, e.heading, headingAnchor(e.heading, anchors))
+	}
+	for _, e := range entries {
+		fmt.Fprintf(&b, "\n## %s\n", e.heading)
+		for _, part := range []struct{ lang, md string }{{e.primary, e.primaryMD}, {e.secondary, e.secondaryMD}} {
+			if strings.TrimSpace(part.md) == "" {
+				continue
+			}
+			fmt.Fprintf(&b, "\n### %s\n\n%s\n", part.lang, strings.TrimSpace(demoteHeadings(part.md, 3)))
+		}
+	}
+	return b.String()
+}
+
+// demoteHeadings 把 ATX 标题下移 n 级，超过六级的按六级处理；``` 代码块内不变。
+func demoteHeadings(md string, n int) string {
+	lines := strings.Split(md, "\n")
+	inFence := false
+	for i, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inFence = !inFence
+			continue
+		}
+		if inFence || !strings.HasPrefix(line, "#") {
+			continue
+		}
+		level := len(line) - len(strings.TrimLeft(line, "#"))
+		if level > 6 || (level < len(line) && line[level] != ' ') {
+			continue
+		}
+		lines[i] = strings.Repeat("#", min(level+n, 6)) + line[level:]
+	}
+	return strings.Join(lines, "\n")
+}
+
+// headingAnchor 按 GitHub 规则由标题生成锚点：转小写、去标点、空格变连字符，重复的加 -1、-2 后缀。
+func headingAnchor(heading string, seen map[string]int) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(strings.TrimSpace(heading)) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '-' || r == '_':
+			b.WriteRune(r)
+		case r == ' ':
+			b.WriteRune('-')
+		}
+	}
+	anchor := b.String()
+	if seen == nil {
+		return anchor
+	}
+	if n := seen[anchor]; n > 0 {
+		seen[anchor] = n + 1
+		return fmt.Sprintf("%s-%d", anchor, n)
+	}
+	seen[anchor] = 1
+	return anchor
+}
@@ -0,0 +1,116 @@
+package app
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDemoteHeadingsAndAnchor(t *testing.T) {
+	got := demoteHeadings("# Title\n#hashtag\n### Deep\n```\n# code\n```", 3)
+	if want := "#### Title\n#hashtag\n###### Deep\n```\n# code\n```"; got != want {
+		t.Fatalf("got=%q want=%q", got, want)
+	}
+	seen := map[string]int{}
+	for _, c := range []struct{ heading, want string }{{"Yoga Mat (Blue)", "yoga-mat-blue"}, {"瑜伽垫 #2", "瑜伽垫-2"}, {"yoga mat blue", "yoga-mat-blue-1"}} {
+		if got := headingAnchor(c.heading, seen); got != c.want {
+			t.Fatalf("headingAnchor(%q)=%q want=%q", c.heading, got, c.want)
+		}
+	}
+	if _, err := parseCombinedPath("out/master.pdf"); err == nil {
+		t.Fatal("expected unsupported extension error")
+	}
+}
+
+func TestRunGen_CombinedMarkdown(t *testing.T) {
+	prepareRunGenHome(t)
+	ts := newRunGenFastSuccessServer(t)
+	defer ts.Close()
+	oldBase := workerBaseURL
+	oldTimeout := streamTimeoutSecond
+	workerBaseURL = ts.URL
+	streamTimeoutSecond = 5
+	defer func() {
+		workerBaseURL = oldBase
+		streamTimeoutSecond = oldTimeout
+	}()
+
+	inDir := t.TempDir()
+	var inputs []string
+	for _, name := range []string{"mat.md", "bottle.md"} {
+		p := filepath.Join(inDir, name)
+		if err := os.WriteFile(p, []byte("# 输入"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		inputs = append(inputs, p)
+	}
+	combined := filepath.Join(t.TempDir(), "deliver", "master.md")
+	out, err := captureStdoutRun(t, func() error {
+		return RunGen(context.Background(), GenOptions{OutputDir: t.TempDir(), Inputs: inputs, SkipDocx: true, Combined: combined})
+	})
+	if err != nil {
+		t.Fatalf("RunGen error: %v", err)
+	}
+	b, err := os.ReadFile(combined)
+	if err != nil {
+		t.Fatalf("combined not written: %v\n%s", err, out)
+	}
+	md := string(b)
+	for _, want := range []string{"# Listing 汇总\n", "[bottle](#bottle)", "[mat](#mat)", "\n## mat\n\n### EN\n\n#### EN\n\n### CN\n\n#### CN\n"} {
+		if !strings.Contains(md, want) {
+			t.Fatalf("combined missing %q:\n%s", want, md)
+		}
+	}
+	if !strings.Contains(out, "合并文档已写入（2 个 listing）") {
+		t.Fatalf("combined write not logged: %s", out)
+	}
+}
+
+func TestRunGen_ExportFormats(t *testing.T) {
+	prepareRunGenHome(t)
+	ts := newRunGenFastSuccessServer(t)
+	defer ts.Close()
+	oldBase := workerBaseURL
+	oldTimeout := streamTimeoutSecond
+	workerBaseURL = ts.URL
+	streamTimeoutSecond = 5
+	defer func() {
+		workerBaseURL = oldBase
+		streamTimeoutSecond = oldTimeout
+	}()
+
+	inDir := t.TempDir()
+	var inputs []string
+	for _, name := range []string{"Yoga Mat.md", "bottle.md"} {
+		p := filepath.Join(inDir, name)
+		if err := os.WriteFile(p, []byte("# 输入"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		inputs = append(inputs, p)
+	}
+	outDir := t.TempDir()
+	out, err := captureStdoutRun(t, func() error {
+		return RunGen(context.Background(), GenOptions{OutputDir: outDir, Inputs: inputs, SkipDocx: true, Formats: []string{"shopify", "ebay"}})
+	})
+	if err != nil {
+		t.Fatalf("RunGen error: %v", err)
+	}
+	for _, f := range []string{"shopify", "ebay"} {
+		matches, _ := filepath.Glob(filepath.Join(outDir, f+"_*.csv"))
+		if len(matches) != 1 {
+			t.Fatalf("%s export not written: %v\n%s", f, matches, out)
+		}
+		b, _ := os.ReadFile(matches[0])
+		if !strings.Contains(string(b), "yoga-mat,") || !strings.Contains(string(b), "bottle,") {
+			t.Fatalf("%s export missing rows:\n%s", f, b)
+		}
+	}
+	if !strings.Contains(out, "已导出 shopify（2 个商品）") {
+		t.Fatalf("export not logged: %s", out)
+	}
+	if err := RunGen(context.Background(), GenOptions{OutputDir: outDir, Inputs: inputs[:1], Formats: []string{"csv"}}); err == nil {
+		t.Fatal("expected unsupported format error")
+	}
+}
@@ -0,0 +1,13 @@
+package app
+
+import (
+	"syl-listing-pro/internal/config"
+	"syl-listing-pro/internal/util"
+)
+
+// UseConfig 让本进程后续的配置读写（KEY、超时、对象存储等）都使用 path，为空时使用默认的 ~/.syl-listing-pro/.env；
+// 返回配置文件中 SYL_DEFAULT_<参数名> 给出的参数默认值，文件不存在时为空。
+func UseConfig(path string) (map[string]string, error) {
+	util.SetEnvPath(path)
+	return config.LoadFlagDefaults()
+}
@@ -0,0 +1,88 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"syl-listing-pro/internal/config"
+	"syl-listing-pro/internal/i18n"
+	"syl-listing-pro/internal/util"
+)
+
+// RunConfigValidate 检查配置文件、相关环境变量与本地状态目录，一次列出全部问题；
+// flags 为全部命令行参数名，用于校验 SYL_DEFAULT_<参数名>。
+func RunConfigValidate(w io.Writer, flags []string) error {
+	known := make(map[string]bool, len(flags))
+	for _, f := range flags {
+		known[f] = true
+	}
+	path, exists, problems, err := config.ValidateEnvFile(known)
+	if err != nil {
+		return err
+	}
+	if exists {
+		fmt.Fprintln(w, i18n.T("配置文件：%s", path))
+	} else {
+		fmt.Fprintln(w, i18n.T("配置文件：%s（不存在，使用默认配置）", path))
+	}
+	if raw := os.Getenv("SYL_LISTING_WORKER_URL"); raw != "" {
+		if err := config.ValidateURL(raw); err != nil {
+			problems = append(problems, config.Problem{Key: "SYL_LISTING_WORKER_URL", Message: err.Error()})
+		}
+	}
+	if p := os.Getenv("SYL_LISTING_KEY_FILE"); p != "" {
+		if _, err := os.Stat(p); err != nil {
+			problems = append(problems, config.Problem{Key: "SYL_LISTING_KEY_FILE", Message: i18n.T("密钥文件不可读：%v", err)})
+		}
+	}
+	if dir, err := util.DefaultAppDir(); err != nil {
+		problems = append(problems, config.Problem{Key: util.StateDirEnvName, Message: err.Error()})
+	} else {
+		runsDir, _ := util.DefaultRunsDir()
+		for _, d := range []string{dir, runsDir} {
+			if err := checkDirWritable(d); err != nil {
+				problems = append(problems, config.Problem{Message: i18n.T("目录不可写：%s：%v", d, err)})
+			}
+		}
+	}
+	if len(problems) == 0 {
+		fmt.Fprintln(w, i18n.T("配置检查通过"))
+		return nil
+	}
+	for _, p := range problems {
+		fmt.Fprintln(w, i18n.T("[问题] %s", p))
+	}
+	return i18n.Errorf("发现 %d 个配置问题", len(problems))
+}
+
+// checkDirWritable 检查 dir 可写而不创建它：dir 不存在时检查最近的已存在上级目录。
+func checkDirWritable(dir string) error {
+	dir = mustAbsPath(dir)
+	for {
+		info, err := os.Stat(dir)
+		if err == nil {
+			if !info.IsDir() {
+				return i18n.Errorf("%s 不是目录", dir)
+			}
+			break
+		}
+		if !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return err
+		}
+		dir = parent
+	}
+	f, err := os.CreateTemp(dir, ".syl-config-*")
+	if err != nil {
+		return err
+	}
+	name := f.Name()
+	_ = f.Close()
+	return os.Remove(name)
+}
@@ -0,0 +1,41 @@
+package app
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunConfigValidate(t *testing.T) {
+	state := t.TempDir()
+	t.Setenv("SYL_STATE_DIR", state)
+	t.Setenv("SYL_LISTING_KEY_FILE", "")
+	t.Setenv("SYL_LISTING_WORKER_URL", "")
+	var out bytes.Buffer
+	if err := RunConfigValidate(&out, nil); err != nil {
+		t.Fatalf("RunConfigValidate error: %v\n%s", err, out.String())
+	}
+	if !strings.Contains(out.String(), "不存在，使用默认配置") || !strings.Contains(out.String(), "配置检查通过") {
+		t.Fatalf("unexpected output: %s", out.String())
+	}
+	if _, err := os.Stat(filepath.Join(state, "runs")); !os.IsNotExist(err) {
+		t.Fatal("validate should not create the runs directory")
+	}
+
+	if err := os.WriteFile(filepath.Join(state, ".env"), []byte("SYL_TIMEOUT_SUBMIT=0s\nSYL_DEFAULT_OUT=x\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("SYL_LISTING_WORKER_URL", "worker.example.test")
+	out.Reset()
+	err := RunConfigValidate(&out, []string{"out"})
+	if err == nil || !strings.Contains(err.Error(), "2 个配置问题") {
+		t.Fatalf("err=%v\n%s", err, out.String())
+	}
+	for _, want := range []string{"[问题] 第 1 行 SYL_TIMEOUT_SUBMIT", "[问题] SYL_LISTING_WORKER_URL：不是有效的 http(s) URL"} {
+		if !strings.Contains(out.String(), want) {
+			t.Fatalf("missing %q in:\n%s", want, out.String())
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package app
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"syl-listing-pro/internal/i18n"
+)
+
+type ConvertOptions struct {
+	Verbose        bool
+	LogFile        string
+	LogFormat      string
+	Inputs         []string
+	HighlightWords []string
+}
+
+// RunConvert 把已有的 Markdown 产物转换为同名 .docx，用于补做失败的 Word 转换。
+func RunConvert(ctx context.Context, opts ConvertOptions) error {
+	log, err := NewLogger(opts.Verbose, opts.LogFile)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = log.Close() }()
+	if err := log.SetLogFormat(opts.LogFormat); err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, in := range opts.Inputs {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		info, err := os.Stat(in)
+		if err != nil {
+			failed++
+			log.Info(i18n.T("%s 转换失败：%v", in, err))
+			continue
+		}
+		ext := strings.ToLower(filepath.Ext(in))
+		if info.IsDir() || (ext != ".md" && ext != ".markdown") {
+			failed++
+			log.Info(i18n.T("%s 转换失败：不是 markdown 文件", in))
+			continue
+		}
+		target := strings.TrimSuffix(in, filepath.Ext(in)) + ".docx"
+		docxPath, err := ConvertMarkdownToDocxWithOptions(ctx, in, target, DocxOptions{HighlightWords: opts.HighlightWords})
+		if err != nil {
+			failed++
+			log.Info(i18n.T("%s 转换失败：%v", in, err))
+			continue
+		}
+		log.Info(i18n.T("Word 已写入：%s", mustAbsPath(docxPath)))
+	}
+	if failed > 0 {
+		return i18n.Errorf("存在转换失败的文件")
+	}
+	return nil
+}
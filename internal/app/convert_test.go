@@ -0,0 +1,60 @@
+package app
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestRunConvert(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell script test is unix-only")
+	}
+	tmp := t.TempDir()
+	binDir := filepath.Join(tmp, "bin")
+	if err := os.MkdirAll(binDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	argsLog := filepath.Join(tmp, "args.log")
+	script := "#!/bin/sh\n" +
+		"echo \"$@\" >> '" + argsLog + "'\n" +
+		"while [ $# -gt 0 ]; do\n" +
+		"  if [ \"$1\" = \"--output\" ]; then shift; echo docx > \"$1\"; fi\n" +
+		"  shift\n" +
+		"done\n"
+	writeExecutable(t, filepath.Join(binDir, "syl-md2doc"), script)
+	t.Setenv("PATH", binDir+":"+os.Getenv("PATH"))
+
+	md := filepath.Join(tmp, "listing_ab12_en.md")
+	if err := os.WriteFile(md, []byte("# x\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	out, err := captureStdoutRun(t, func() error {
+		return RunConvert(context.Background(), ConvertOptions{Inputs: []string{md}, HighlightWords: []string{" a ", "", "b"}})
+	})
+	if err != nil {
+		t.Fatalf("RunConvert error: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(tmp, "listing_ab12_en.docx")); err != nil {
+		t.Fatalf("docx not written: %v", err)
+	}
+	if !strings.Contains(out, "Word 已写入") {
+		t.Fatalf("unexpected output: %s", out)
+	}
+	args, _ := os.ReadFile(argsLog)
+	if !strings.Contains(string(args), "--highlight-words a,b") {
+		t.Fatalf("highlight words not passed: %s", args)
+	}
+
+	txt := filepath.Join(tmp, "notes.txt")
+	_ = os.WriteFile(txt, []byte("x"), 0o644)
+	_, err = captureStdoutRun(t, func() error {
+		return RunConvert(context.Background(), ConvertOptions{Inputs: []string{txt}})
+	})
+	if err == nil || !strings.Contains(err.Error(), "存在转换失败的文件") {
+		t.Fatalf("unexpected err: %v", err)
+	}
+}
@@ -0,0 +1,55 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"syl-listing-pro/internal/i18n"
+	"syl-listing-pro/internal/util"
+)
+
+// runTaskRecovered 执行单个任务，把 panic 转为该任务的失败并写出崩溃报告，其余任务继续运行。
+func runTaskRecovered(log *Logger, runID string, task generateTask, fn func() taskResult) (result taskResult) {
+	defer func() {
+		r := recover()
+		if r == nil {
+			return
+		}
+		path, err := writeCrashReport(runID, task, r, debug.Stack())
+		if err != nil {
+			result = taskResult{err: i18n.Errorf("内部错误（panic）：%v（崩溃报告写入失败：%v）", r, err)}
+		} else {
+			result = taskResult{err: i18n.Errorf("内部错误（panic）：%v，崩溃报告：%s", r, path)}
+		}
+		log.Info(i18n.T("%s 生成失败：%v", taskPrefix("", 0, task.label), result.err))
+		log.Event("task_panic", map[string]any{"task": task.label, "panic": fmt.Sprint(r), "crash_report": path})
+	}()
+	return fn()
+}
+
+// writeCrashReport 把 panic 值与调用栈写到 ~/.syl-listing-pro/crash/<run_id>_<序号>.txt。
+func writeCrashReport(runID string, task generateTask, value any, stack []byte) (string, error) {
+	dir, err := util.DefaultCrashDir()
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	name := fmt.Sprintf("%s_%s_%d.txt", runID, strings.TrimSuffix(filepath.Base(task.file.Path), filepath.Ext(task.file.Path)), task.index)
+	path := filepath.Join(dir, name)
+	var b strings.Builder
+	fmt.Fprintf(&b, "time: %s\n", time.Now().Format(time.RFC3339))
+	fmt.Fprintf(&b, "run_id: %s\n", runID)
+	fmt.Fprintf(&b, "task: %s\n", task.label)
+	fmt.Fprintf(&b, "input: %s\n", mustAbsPath(task.file.Path))
+	fmt.Fprintf(&b, "panic: %v\n\n%s", value, stack)
+	if err := os.WriteFile(path, []byte(b.String()), 0o644); err != nil {
+		return "", err
+	}
+	return path, nil
+}
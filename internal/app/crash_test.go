@@ -0,0 +1,47 @@
+package app
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"syl-listing-pro/internal/input"
+)
+
+func TestRunTaskRecovered(t *testing.T) {
+	prepareRunGenHome(t)
+	log, err := NewLogger(false, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	task := generateTask{file: input.RequirementFile{Path: "/abs/req.md"}, index: 2, label: "req.md#2"}
+
+	want := errors.New("normal failure")
+	if got := runTaskRecovered(log, "run1", task, func() taskResult { return taskResult{err: want} }); got.err != want {
+		t.Fatalf("non-panicking task result changed: %+v", got)
+	}
+
+	got := runTaskRecovered(log, "run1", task, func() taskResult {
+		var m map[string]int
+		m["x"] = 1
+		return taskResult{ok: true}
+	})
+	if got.ok || got.retryable || got.err == nil || !strings.Contains(got.err.Error(), "崩溃报告") {
+		t.Fatalf("panic not converted to failure: %+v", got)
+	}
+	path := filepath.Join(os.Getenv("HOME"), ".syl-listing-pro", "crash", "run1_req_2.txt")
+	if !strings.Contains(got.err.Error(), path) {
+		t.Fatalf("err=%v want crash report %s", got.err, path)
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"run_id: run1", "task: req.md#2", "assignment to entry in nil map", "crash_test.go"} {
+		if !strings.Contains(string(b), want) {
+			t.Fatalf("crash report missing %q:\n%s", want, b)
+		}
+	}
+}
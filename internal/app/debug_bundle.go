@@ -0,0 +1,249 @@
+package app
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"syl-listing-pro/internal/client"
+	"syl-listing-pro/internal/config"
+	"syl-listing-pro/internal/i18n"
+	"syl-listing-pro/internal/manifest"
+	"syl-listing-pro/internal/util"
+)
+
+// bundleLogTailBytes 为每个日志文件放进诊断包的末尾字节数。
+const bundleLogTailBytes = 2 << 20
+
+// DebugBundleOptions 为 debug bundle 的输入。
+type DebugBundleOptions struct {
+	// OutputDir 为诊断包写入的目录，文件名为 syl-debug-<时间>.zip。
+	OutputDir string
+	// VersionText 为版本信息（版本号、commit、构建时间）。
+	VersionText string
+	// LogFile 非空时附带该日志文件的末尾部分。
+	LogFile string
+	// LogDir 非空时附带其中最近一次运行的单任务日志。
+	LogDir string
+}
+
+// RunDebugBundle 收集版本、脱敏后的配置、本地目录状态、最近一次运行记录与日志，打包为 zip 便于提交工单。
+// 所有文本写入前统一隐去 .env 中的 KEY、密钥以及令牌类字段。
+func RunDebugBundle(w io.Writer, opts DebugBundleOptions) error {
+	env, secrets, err := config.LoadSanitizedEnv()
+	if err != nil {
+		return err
+	}
+	for _, kv := range os.Environ() {
+		if k, v, _ := strings.Cut(kv, "="); strings.HasPrefix(k, "SYL_") && config.IsSensitiveEnvName(k) && v != "" {
+			secrets = append(secrets, v)
+		}
+	}
+	// KEY 也可能来自 *_FILE 指向的密钥文件，按当前配置实际读取一次。
+	if profile, err := resolveKeyProfile(""); err == nil {
+		if key, err := loadSYLKeyForRun(profile); err == nil {
+			secrets = append(secrets, key)
+		}
+	}
+	redact := func(s string) string { return client.RedactText(s, secrets...) }
+
+	if err := os.MkdirAll(opts.OutputDir, 0o755); err != nil {
+		return err
+	}
+	path := filepath.Join(opts.OutputDir, "syl-debug-"+time.Now().Format("20060102-150405")+".zip")
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	zw := zip.NewWriter(f)
+	add := func(name, content string) error {
+		fw, err := zw.Create(name)
+		if err != nil {
+			return err
+		}
+		_, err = io.WriteString(fw, redact(content))
+		return err
+	}
+
+	entries := []struct {
+		name    string
+		content func() string
+	}{
+		{"version.txt", func() string { return bundleVersionInfo(opts.VersionText) }},
+		{"config.txt", func() string { return bundleConfig(env) }},
+		{"app_dir.txt", bundleAppDirState},
+		{"last_run.json", bundleLastRun},
+	}
+	for _, e := range entries {
+		if err := add(e.name, e.content()); err != nil {
+			_ = zw.Close()
+			_ = f.Close()
+			return err
+		}
+	}
+	logs := bundleLogFiles(opts.LogFile, opts.LogDir)
+	for _, name := range sortedKeys(logs) {
+		content, err := readTail(logs[name], bundleLogTailBytes)
+		if err != nil {
+			content = i18n.T("读取失败：%v\n", err)
+		}
+		if err := add("logs/"+name, content); err != nil {
+			_ = zw.Close()
+			_ = f.Close()
+			return err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	fmt.Fprintln(w, i18n.T("诊断包已生成：%s", mustAbsPath(path)))
+	fmt.Fprintln(w, i18n.T("KEY 与令牌已隐去，提交前仍建议打开检查一遍"))
+	return nil
+}
+
+func bundleVersionInfo(versionText string) string {
+	var b strings.Builder
+	fmt.Fprintln(&b, versionText)
+	fmt.Fprint(&b, i18n.T("Go：%s\n", runtime.Version()))
+	fmt.Fprint(&b, i18n.T("系统：%s/%s\n", runtime.GOOS, runtime.GOARCH))
+	fmt.Fprint(&b, i18n.T("服务端：%s\n", resolveWorkerBaseURL()))
+	fmt.Fprint(&b, i18n.T("生成时间：%s\n", time.Now().Format(time.RFC3339)))
+	return b.String()
+}
+
+// bundleConfig 列出 .env 与以 SYL_ 开头的环境变量，敏感项只保留是否设置。
+func bundleConfig(env map[string]string) string {
+	var b strings.Builder
+	fmt.Fprintln(&b, "# ~/.syl-listing-pro/.env")
+	for _, k := range sortedKeys(env) {
+		fmt.Fprintf(&b, "%s=%s\n", k, env[k])
+	}
+	fmt.Fprintln(&b, i18n.T("\n# 环境变量"))
+	vars := map[string]string{}
+	for _, kv := range os.Environ() {
+		k, v, _ := strings.Cut(kv, "=")
+		if !strings.HasPrefix(k, "SYL_") {
+			continue
+		}
+		if config.IsSensitiveEnvName(k) && v != "" {
+			v = "[REDACTED]"
+		}
+		vars[k] = v
+	}
+	for _, k := range sortedKeys(vars) {
+		fmt.Fprintf(&b, "%s=%s\n", k, vars[k])
+	}
+	return b.String()
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// bundleAppDirState 列出 ~/.syl-listing-pro 下的文件与大小（不含内容），规则由服务端下发，本地没有规则缓存。
+func bundleAppDirState() string {
+	dir, err := util.DefaultAppDir()
+	if err != nil {
+		return err.Error() + "\n"
+	}
+	var b strings.Builder
+	fmt.Fprintln(&b, dir)
+	err = filepath.WalkDir(dir, func(p string, d os.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		rel, _ := filepath.Rel(dir, p)
+		fmt.Fprintf(&b, "%s\t%d\t%s\n", rel, info.Size(), info.ModTime().Format(time.RFC3339))
+		return nil
+	})
+	if err != nil {
+		fmt.Fprint(&b, i18n.T("读取失败：%v\n", err))
+	}
+	return b.String()
+}
+
+func bundleLastRun() string {
+	runsDir, err := util.DefaultRunsDir()
+	if err != nil {
+		return "{}\n"
+	}
+	_, path, err := manifest.LoadLatest(runsDir)
+	if err != nil {
+		return "{}\n"
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return "{}\n"
+	}
+	return string(b)
+}
+
+// bundleLogFiles 返回要附带的日志：--log-file 本身，以及 --log-dir 下最近一次运行目录中的文件。
+func bundleLogFiles(logFile, logDir string) map[string]string {
+	out := map[string]string{}
+	if logFile != "" {
+		out[filepath.Base(logFile)] = logFile
+	}
+	if logDir == "" {
+		return out
+	}
+	entries, err := os.ReadDir(logDir)
+	if err != nil {
+		return out
+	}
+	latest := ""
+	for _, e := range entries {
+		if e.IsDir() && e.Name() > latest {
+			latest = e.Name()
+		}
+	}
+	if latest == "" {
+		return out
+	}
+	files, _ := os.ReadDir(filepath.Join(logDir, latest))
+	for _, e := range files {
+		if !e.IsDir() {
+			out[latest+"/"+e.Name()] = filepath.Join(logDir, latest, e.Name())
+		}
+	}
+	return out
+}
+
+// readTail 读取文件末尾最多 n 字节。
+func readTail(path string, n int64) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	st, err := f.Stat()
+	if err != nil {
+		return "", err
+	}
+	if st.Size() > n {
+		if _, err := f.Seek(st.Size()-n, io.SeekStart); err != nil {
+			return "", err
+		}
+	}
+	b, err := io.ReadAll(f)
+	return string(b), err
+}
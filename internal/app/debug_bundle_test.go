@@ -0,0 +1,83 @@
+package app
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunDebugBundle(t *testing.T) {
+	prepareRunGenHome(t)
+	home := os.Getenv("HOME")
+	runsDir := filepath.Join(home, ".syl-listing-pro", "runs")
+	if err := os.MkdirAll(runsDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(runsDir, "20260101-000000-aaaa.json"), []byte(`{"run_id":"20260101-000000-aaaa"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(runsDir, "20260102-000000-bbbb.json"), []byte(`{"run_id":"20260102-000000-bbbb","note":"test-key"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	logFile := filepath.Join(t.TempDir(), "run.log")
+	if err := os.WriteFile(logFile, []byte("Authorization: Bearer abc.def\nkey=test-key\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	logDir := t.TempDir()
+	for _, run := range []string{"20260101-000000-aaaa", "20260102-000000-bbbb"} {
+		if err := os.MkdirAll(filepath.Join(logDir, run), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(logDir, run, "req_1.log"), []byte(run), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	out := t.TempDir()
+	var buf bytes.Buffer
+	if err := RunDebugBundle(&buf, DebugBundleOptions{OutputDir: out, VersionText: "syl-listing-pro 版本：test", LogFile: logFile, LogDir: logDir}); err != nil {
+		t.Fatalf("RunDebugBundle error: %v", err)
+	}
+	zips, _ := filepath.Glob(filepath.Join(out, "syl-debug-*.zip"))
+	if len(zips) != 1 || !strings.Contains(buf.String(), zips[0]) {
+		t.Fatalf("zips=%v output:\n%s", zips, buf.String())
+	}
+	zr, err := zip.OpenReader(zips[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer zr.Close()
+	files := map[string]string{}
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, _ := io.ReadAll(rc)
+		_ = rc.Close()
+		files[f.Name] = string(b)
+	}
+	for _, name := range []string{"version.txt", "config.txt", "app_dir.txt", "last_run.json", "logs/run.log", "logs/20260102-000000-bbbb/req_1.log"} {
+		if _, ok := files[name]; !ok {
+			t.Fatalf("missing %s in bundle: %v", name, files)
+		}
+	}
+	if _, ok := files["logs/20260101-000000-aaaa/req_1.log"]; ok {
+		t.Fatalf("only the latest --log-dir run should be bundled")
+	}
+	if !strings.Contains(files["version.txt"], "版本：test") || !strings.Contains(files["config.txt"], "SYL_LISTING_KEY=[REDACTED]") {
+		t.Fatalf("version/config unexpected:\n%s\n%s", files["version.txt"], files["config.txt"])
+	}
+	if !strings.Contains(files["last_run.json"], "20260102-000000-bbbb") {
+		t.Fatalf("last run not bundled: %s", files["last_run.json"])
+	}
+	for name, content := range files {
+		if strings.Contains(content, "test-key") || strings.Contains(content, "abc.def") {
+			t.Fatalf("%s leaks a secret:\n%s", name, content)
+		}
+	}
+}
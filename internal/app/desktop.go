@@ -0,0 +1,122 @@
+package app
+
+import (
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"syl-listing-pro/internal/i18n"
+	"syl-listing-pro/internal/manifest"
+)
+
+// desktopOS 与 desktopCommand 便于测试替换平台与外部命令。
+var (
+	desktopOS      = runtime.GOOS
+	desktopCommand = exec.Command
+	desktopEnv     = os.Getenv
+)
+
+// parseCopyTarget 校验 --copy：en 为主稿，cn 为对照稿（站点语言对不同时同样按位置对应）。
+func parseCopyTarget(s string) (string, error) {
+	switch v := strings.ToLower(strings.TrimSpace(s)); v {
+	case "", "en", "cn":
+		return v, nil
+	default:
+		return "", i18n.Errorf("--copy 只支持 en|cn: %s", s)
+	}
+}
+
+// clipboardCommand 返回当前平台写剪贴板的命令；Linux 下按 Wayland、X11 依次查找。
+func clipboardCommand() ([]string, error) {
+	switch desktopOS {
+	case "darwin":
+		return []string{"pbcopy"}, nil
+	case "windows":
+		return []string{"clip"}, nil
+	}
+	candidates := [][]string{
+		{"xclip", "-selection", "clipboard"},
+		{"xsel", "--clipboard", "--input"},
+	}
+	if desktopEnv("WAYLAND_DISPLAY") != "" {
+		candidates = append([][]string{{"wl-copy"}}, candidates...)
+	}
+	for _, c := range candidates {
+		if _, err := exec.LookPath(c[0]); err == nil {
+			return c, nil
+		}
+	}
+	return nil, i18n.New("未找到剪贴板工具（wl-copy、xclip 或 xsel）")
+}
+
+func copyToClipboard(text string) error {
+	argv, err := clipboardCommand()
+	if err != nil {
+		return err
+	}
+	cmd := desktopCommand(argv[0], argv[1:]...)
+	cmd.Stdin = strings.NewReader(text)
+	return cmd.Run()
+}
+
+// openWithDefaultApp 用系统默认程序打开文件，不等待程序退出。
+func openWithDefaultApp(path string) error {
+	var cmd *exec.Cmd
+	switch desktopOS {
+	case "darwin":
+		cmd = desktopCommand("open", path)
+	case "windows":
+		cmd = desktopCommand("cmd", "/c", "start", "", path)
+	default:
+		cmd = desktopCommand("xdg-open", path)
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	go func() { _ = cmd.Wait() }()
+	return nil
+}
+
+// runDesktopActions 在只有一个任务且成功时执行 --copy / --open；多任务运行跳过并提示。
+func runDesktopActions(log *Logger, opts GenOptions, cp *manifest.Checkpoint) {
+	if opts.Copy == "" && !opts.Open {
+		return
+	}
+	if cp == nil {
+		return
+	}
+	m := cp.Snapshot()
+	if len(m.Tasks) != 1 || !m.Tasks[0].Done() {
+		log.Info(i18n.T("--copy / --open 只用于单个需求文件且生成成功的运行，已跳过"))
+		return
+	}
+	en, cn := markdownOutputs(m.Tasks[0].Outputs)
+	if opts.Copy != "" {
+		path := en
+		if opts.Copy == "cn" {
+			path = cn
+		}
+		b, err := os.ReadFile(path)
+		if err == nil {
+			err = copyToClipboard(string(b))
+		}
+		if err != nil {
+			log.Info(i18n.T("复制到剪贴板失败：%v", err))
+		} else {
+			log.Info(i18n.T("已复制到剪贴板：%s", mustAbsPath(path)))
+		}
+	}
+	if opts.Open {
+		docx := strings.TrimSuffix(en, ".md") + ".docx"
+		if _, err := os.Stat(docx); err != nil {
+			log.Info(i18n.T("没有可打开的 Word 文件，已跳过 --open"))
+			return
+		}
+		if err := openWithDefaultApp(docx); err != nil {
+			log.Info(i18n.T("打开文件失败：%v", err))
+			return
+		}
+		log.Info(i18n.T("已打开：%s", mustAbsPath(docx)))
+	}
+}
@@ -0,0 +1,58 @@
+package app
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunGen_CopyToClipboard(t *testing.T) {
+	prepareRunGenHome(t)
+
+	ts := newRunGenFastSuccessServer(t)
+	defer ts.Close()
+	oldBase, oldTimeout := workerBaseURL, streamTimeoutSecond
+	oldOS, oldCmd := desktopOS, desktopCommand
+	workerBaseURL = ts.URL
+	streamTimeoutSecond = 5
+	desktopOS = "darwin"
+	clip := filepath.Join(t.TempDir(), "clipboard")
+	var invoked string
+	desktopCommand = func(name string, args ...string) *exec.Cmd {
+		invoked = name
+		return exec.Command("sh", "-c", `cat > "$0"`, clip)
+	}
+	defer func() {
+		workerBaseURL, streamTimeoutSecond = oldBase, oldTimeout
+		desktopOS, desktopCommand = oldOS, oldCmd
+	}()
+
+	inputPath := filepath.Join(t.TempDir(), "req.md")
+	if err := os.WriteFile(inputPath, []byte("# 输入"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	out, err := captureStdoutRun(t, func() error {
+		return RunGen(context.Background(), GenOptions{OutputDir: t.TempDir(), Inputs: []string{inputPath}, SkipDocx: true, Copy: "cn", Open: true})
+	})
+	if err != nil {
+		t.Fatalf("RunGen error: %v", err)
+	}
+	if invoked != "pbcopy" {
+		t.Fatalf("clipboard command=%q", invoked)
+	}
+	if b, _ := os.ReadFile(clip); string(b) != "# CN" {
+		t.Fatalf("clipboard=%q", b)
+	}
+	for _, want := range []string{"已复制到剪贴板", "没有可打开的 Word 文件"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("missing %q in output: %s", want, out)
+		}
+	}
+
+	if _, err := parseCopyTarget("de"); err == nil {
+		t.Fatal("expected error for unsupported --copy target")
+	}
+}
@@ -0,0 +1,25 @@
+package app
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"syl-listing-pro/internal/i18n"
+	"syl-listing-pro/internal/listing"
+)
+
+// RunDiff 按分节比较两份生成的 listing，输出差异与字符数变化，用于在 --num 候选间取舍。
+func RunDiff(w io.Writer, aPath string, bPath string) error {
+	a, err := os.ReadFile(aPath)
+	if err != nil {
+		return i18n.Errorf("读取 %s 失败: %w", aPath, err)
+	}
+	b, err := os.ReadFile(bPath)
+	if err != nil {
+		return i18n.Errorf("读取 %s 失败: %w", bPath, err)
+	}
+	fmt.Fprint(w, i18n.T("A：%s\nB：%s\n", mustAbsPath(aPath), mustAbsPath(bPath)))
+	listing.WriteDiff(w, listing.Diff(listing.Parse(string(a)), listing.Parse(string(b))))
+	return nil
+}
@@ -0,0 +1,29 @@
+package app
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunDiff(t *testing.T) {
+	dir := t.TempDir()
+	a := filepath.Join(dir, "a_en.md")
+	b := filepath.Join(dir, "b_en.md")
+	_ = os.WriteFile(a, []byte("# Title\nShort title\n## Bullet Points\n- one\n"), 0o644)
+	_ = os.WriteFile(b, []byte("# Title\nA longer title\n## Bullet Points\n- one\n"), 0o644)
+
+	var buf bytes.Buffer
+	if err := RunDiff(&buf, a, b); err != nil {
+		t.Fatalf("RunDiff error: %v", err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "== Title：11 → 14 字符（+3）") || !strings.Contains(out, "== Bullet Points：5 → 5 字符（+0）\n   相同") {
+		t.Fatalf("unexpected diff output:\n%s", out)
+	}
+	if err := RunDiff(&buf, a, filepath.Join(dir, "missing.md")); err == nil {
+		t.Fatal("expected read error")
+	}
+}
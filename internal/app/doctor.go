@@ -0,0 +1,103 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"syl-listing-pro/internal/client"
+	"syl-listing-pro/internal/i18n"
+)
+
+type doctorCheck struct {
+	name string
+	run  func(ctx context.Context) (detail string, hint string, err error)
+}
+
+// RunDoctor 逐项检查运行环境，输出每项结果与修复建议。
+func RunDoctor(ctx context.Context, w io.Writer, outDir, profile string) error {
+	var sylKey string
+	checks := []doctorCheck{
+		{name: i18n.T("KEY 已配置"), run: func(context.Context) (string, string, error) {
+			key, err := loadSYLKeyForRun(profile)
+			if err != nil {
+				return "", i18n.T("执行 syl-listing-pro set key <SYL_LISTING_KEY>"), err
+			}
+			sylKey = key
+			return "", "", nil
+		}},
+		{name: i18n.T("服务端可连接"), run: func(ctx context.Context) (string, string, error) {
+			if sylKey == "" {
+				return "", i18n.T("先配置 KEY"), i18n.Errorf("未配置 KEY，跳过")
+			}
+			ctx, cancel := context.WithTimeout(ctx, 20*time.Second)
+			defer cancel()
+			ex, err := client.New(resolveWorkerBaseURL()).Exchange(ctx, sylKey)
+			if err != nil {
+				return "", i18n.T("检查网络、代理设置与 KEY 是否有效；可通过 SYL_LISTING_WORKER_URL 指定服务端"), err
+			}
+			return i18n.T("%s（租户 %s）", resolveWorkerBaseURL(), ex.TenantID), "", nil
+		}},
+		{name: i18n.T("syl-md2doc 可用"), run: func(ctx context.Context) (string, string, error) {
+			hint := i18n.T("安装 syl-md2doc 并确保其在 PATH 中；只需要 Markdown 时可加 --skip-docx")
+			path, err := exec.LookPath("syl-md2doc")
+			if err != nil {
+				return "", hint, i18n.Errorf("PATH 中未找到 syl-md2doc")
+			}
+			ctx, cancel := context.WithTimeout(ctx, 20*time.Second)
+			defer cancel()
+			if out, err := exec.CommandContext(ctx, path, "--help").CombinedOutput(); err != nil {
+				return "", hint, i18n.Errorf("执行失败: %w: %s", err, strings.TrimSpace(shortText(string(out), 200)))
+			}
+			return path, "", nil
+		}},
+		{name: i18n.T("输出目录可写"), run: func(context.Context) (string, string, error) {
+			hint := i18n.T("用 -o 指定有写权限的目录")
+			if err := os.MkdirAll(outDir, 0o755); err != nil {
+				return "", hint, err
+			}
+			f, err := os.CreateTemp(outDir, ".syl-doctor-*")
+			if err != nil {
+				return "", hint, err
+			}
+			name := f.Name()
+			_ = f.Close()
+			_ = os.Remove(name)
+			abs, _ := filepath.Abs(outDir)
+			if free, err := diskFreeBytes(outDir); err == nil {
+				if free < preflightMinFreeBytes {
+					return "", i18n.T("清理磁盘或用 -o 换到空间充足的目录"), i18n.Errorf("%s 仅剩 %s", abs, formatBytes(free))
+				}
+				return i18n.T("%s（可用 %s）", abs, formatBytes(free)), "", nil
+			}
+			return abs, "", nil
+		}},
+	}
+
+	failed := 0
+	for _, c := range checks {
+		detail, hint, err := c.run(ctx)
+		if err != nil {
+			failed++
+			fmt.Fprint(w, i18n.T("[失败] %s：%v\n", c.name, err))
+			if hint != "" {
+				fmt.Fprint(w, i18n.T("       建议：%s\n", hint))
+			}
+			continue
+		}
+		if detail != "" {
+			fmt.Fprint(w, i18n.T("[通过] %s：%s\n", c.name, detail))
+		} else {
+			fmt.Fprint(w, i18n.T("[通过] %s\n", c.name))
+		}
+	}
+	if failed > 0 {
+		return i18n.Errorf("%d 项检查未通过", failed)
+	}
+	return nil
+}
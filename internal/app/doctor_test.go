@@ -0,0 +1,55 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestRunDoctor(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell script test is unix-only")
+	}
+	prepareRunGenHome(t)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `{"access_token":"at","tenant_id":"demo","expires_in":3600}`)
+	}))
+	defer ts.Close()
+	oldBase := workerBaseURL
+	workerBaseURL = ts.URL
+	defer func() { workerBaseURL = oldBase }()
+
+	binDir := t.TempDir()
+	writeExecutable(t, filepath.Join(binDir, "syl-md2doc"), "#!/bin/sh\nexit 0\n")
+	t.Setenv("PATH", binDir)
+
+	var buf bytes.Buffer
+	if err := RunDoctor(context.Background(), &buf, t.TempDir(), ""); err != nil {
+		t.Fatalf("RunDoctor error: %v\n%s", err, buf.String())
+	}
+	for _, want := range []string{"[通过] KEY 已配置", "[通过] 服务端可连接", "租户 demo", "[通过] syl-md2doc 可用", "[通过] 输出目录可写"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Fatalf("missing %q in:\n%s", want, buf.String())
+		}
+	}
+
+	t.Setenv("PATH", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+	fileAsDir := filepath.Join(t.TempDir(), "file")
+	_ = os.WriteFile(fileAsDir, []byte("x"), 0o644)
+	buf.Reset()
+	err := RunDoctor(context.Background(), &buf, fileAsDir, "")
+	if err == nil || !strings.Contains(err.Error(), "4 项检查未通过") {
+		t.Fatalf("unexpected err: %v\n%s", err, buf.String())
+	}
+	if !strings.Contains(buf.String(), "建议：执行 syl-listing-pro set key") || !strings.Contains(buf.String(), "--skip-docx") {
+		t.Fatalf("missing hints:\n%s", buf.String())
+	}
+}
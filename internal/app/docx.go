@@ -3,11 +3,12 @@ package app
 import (
 	"context"
 	"encoding/json"
-	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
+
+	"syl-listing-pro/internal/i18n"
 )
 
 var convertMarkdownToDocxFunc = ConvertMarkdownToDocx
@@ -20,17 +21,29 @@ type md2docSummaryLine struct {
 	} `json:"details"`
 }
 
+// DocxOptions 是传给 syl-md2doc 的可选转换参数。
+type DocxOptions struct {
+	HighlightWords []string
+}
+
 func ConvertMarkdownToDocx(ctx context.Context, markdownPath string, outputPath string) (string, error) {
+	return ConvertMarkdownToDocxWithOptions(ctx, markdownPath, outputPath, DocxOptions{})
+}
+
+func ConvertMarkdownToDocxWithOptions(ctx context.Context, markdownPath string, outputPath string, opts DocxOptions) (string, error) {
 	targetPath := outputPath
 	if abs, err := filepath.Abs(outputPath); err == nil {
 		targetPath = abs
 	}
 	args := []string{markdownPath, "--output", targetPath}
+	if words := cleanWords(opts.HighlightWords); len(words) > 0 {
+		args = append(args, "--highlight-words", strings.Join(words, ","))
+	}
 
 	cmd := exec.CommandContext(ctx, "syl-md2doc", args...)
 	out, err := cmd.CombinedOutput()
 	if err != nil {
-		return "", fmt.Errorf("syl-md2doc 执行失败: %w: %s", err, strings.TrimSpace(shortText(string(out), 300)))
+		return "", i18n.Errorf("syl-md2doc 执行失败: %w: %s", err, strings.TrimSpace(shortText(string(out), 300)))
 	}
 
 	if _, err := os.Stat(targetPath); err == nil {
@@ -39,7 +52,7 @@ func ConvertMarkdownToDocx(ctx context.Context, markdownPath string, outputPath
 
 	path := parseMD2DocOutputPath(out)
 	if strings.TrimSpace(path) == "" {
-		return "", fmt.Errorf("syl-md2doc 未返回输出路径且目标文件不存在: %s", strings.TrimSpace(shortText(string(out), 300)))
+		return "", i18n.Errorf("syl-md2doc 未返回输出路径且目标文件不存在: %s", strings.TrimSpace(shortText(string(out), 300)))
 	}
 	if abs, err := filepath.Abs(path); err == nil {
 		path = abs
@@ -48,7 +61,7 @@ func ConvertMarkdownToDocx(ctx context.Context, markdownPath string, outputPath
 		return path, nil
 	}
 	if err := os.Rename(path, targetPath); err != nil {
-		return "", fmt.Errorf("Word 输出文件名不一致，重命名失败: got=%s want=%s: %w", path, targetPath, err)
+		return "", i18n.Errorf("Word 输出文件名不一致，重命名失败: got=%s want=%s: %w", path, targetPath, err)
 	}
 	return targetPath, nil
 }
@@ -73,3 +86,13 @@ func parseMD2DocOutputPath(raw []byte) string {
 	}
 	return ""
 }
+
+func cleanWords(words []string) []string {
+	out := make([]string, 0, len(words))
+	for _, w := range words {
+		if w = strings.TrimSpace(w); w != "" {
+			out = append(out, w)
+		}
+	}
+	return out
+}
@@ -0,0 +1,186 @@
+package app
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"syl-listing-pro/internal/i18n"
+)
+
+// docxMetadata 为写入 Word 文档属性的追溯信息；空字段不写。
+type docxMetadata struct {
+	JobID        string
+	RunID        string
+	RulesVersion string
+	TenantID     string
+	GeneratedAt  string
+	CLIVersion   string
+}
+
+// customProperties 返回写入 docProps/custom.xml 的自定义属性，顺序固定。
+func (m docxMetadata) customProperties() [][2]string {
+	var out [][2]string
+	for _, p := range [][2]string{
+		{"SylJobID", m.JobID},
+		{"SylRunID", m.RunID},
+		{"SylRulesVersion", m.RulesVersion},
+		{"SylTenant", m.TenantID},
+		{"SylGeneratedAt", m.GeneratedAt},
+		{"SylCLIVersion", m.CLIVersion},
+	} {
+		if p[1] != "" {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+const (
+	customPropsPart        = "docProps/custom.xml"
+	corePropsPart          = "docProps/core.xml"
+	customPropsRelType     = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/custom-properties"
+	customPropsContentType = "application/vnd.openxmlformats-officedocument.custom-properties+xml"
+	customPropsFmtID       = "{D5CDD505-2E9C-101B-9397-08002B2CF9AE}"
+)
+
+var (
+	dcIdentifierPattern = regexp.MustCompile(`(?s)<dc:identifier\s*/>|<dc:identifier>.*?</dc:identifier>`)
+	relsEndPattern      = regexp.MustCompile(`</Relationships>\s*$`)
+	typesEndPattern     = regexp.MustCompile(`</Types>\s*$`)
+)
+
+// setDocxProperties 把追溯信息写入 docx：有核心属性部件时 job ID 写入 dc:identifier，
+// 全部字段写入自定义属性（Word“文件 > 属性 > 自定义”可见）。原文件整体替换，其余部件原样保留。
+func setDocxProperties(path string, meta docxMetadata) error {
+	props := meta.customProperties()
+	if len(props) == 0 {
+		return nil
+	}
+	b, err := rewriteDocxProperties(path, meta.JobID, props)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".docx-props-*")
+	if err != nil {
+		return i18n.Errorf("写 Word 属性失败: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		return i18n.Errorf("写 Word 属性失败: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return i18n.Errorf("写 Word 属性失败: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return i18n.Errorf("写 Word 属性失败: %w", err)
+	}
+	return nil
+}
+
+// rewriteDocxProperties 返回替换了属性部件的 docx 内容；已有的自定义属性整体替换为 props。
+func rewriteDocxProperties(path, jobID string, props [][2]string) ([]byte, error) {
+	zr, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, i18n.Errorf("读取 Word 文件失败: %w", err)
+	}
+	defer zr.Close()
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, f := range zr.File {
+		if f.Name == customPropsPart {
+			continue
+		}
+		b, err := readZipFile(f)
+		if err != nil {
+			return nil, i18n.Errorf("读取 Word 文件失败: %w", err)
+		}
+		switch f.Name {
+		case corePropsPart:
+			if jobID != "" {
+				b = setCoreIdentifier(b, jobID)
+			}
+		case "_rels/.rels":
+			if !bytes.Contains(b, []byte(customPropsRelType)) {
+				rel := fmt.Sprintf(`<Relationship Id="rIdSylCustom" Type="%s" Target="%s"/></Relationships>`, customPropsRelType, customPropsPart)
+				b = relsEndPattern.ReplaceAll(b, []byte(rel))
+			}
+		case "[Content_Types].xml":
+			if !bytes.Contains(b, []byte(`PartName="/`+customPropsPart+`"`)) {
+				override := fmt.Sprintf(`<Override PartName="/%s" ContentType="%s"/></Types>`, customPropsPart, customPropsContentType)
+				b = typesEndPattern.ReplaceAll(b, []byte(override))
+			}
+		}
+		if err := writeZipFile(zw, &f.FileHeader, b); err != nil {
+			return nil, err
+		}
+	}
+	header := &zip.FileHeader{Name: customPropsPart, Method: zip.Deflate}
+	if err := writeZipFile(zw, header, customPropertiesXML(props)); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, i18n.Errorf("写 Word 属性失败: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func readZipFile(f *zip.File) ([]byte, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return io.ReadAll(rc)
+}
+
+func writeZipFile(zw *zip.Writer, header *zip.FileHeader, b []byte) error {
+	h := *header
+	w, err := zw.CreateHeader(&h)
+	if err != nil {
+		return i18n.Errorf("写 Word 属性失败: %w", err)
+	}
+	if _, err := w.Write(b); err != nil {
+		return i18n.Errorf("写 Word 属性失败: %w", err)
+	}
+	return nil
+}
+
+// setCoreIdentifier 设置核心属性中的 dc:identifier，没有时插到 cp:coreProperties 末尾。
+func setCoreIdentifier(core []byte, id string) []byte {
+	elem := []byte("<dc:identifier>" + escapeXML(id) + "</dc:identifier>")
+	if dcIdentifierPattern.Match(core) {
+		return dcIdentifierPattern.ReplaceAllLiteral(core, elem)
+	}
+	end := []byte("</cp:coreProperties>")
+	if i := bytes.LastIndex(core, end); i >= 0 {
+		return append(append(append([]byte{}, core[:i]...), elem...), core[i:]...)
+	}
+	return core
+}
+
+func customPropertiesXML(props [][2]string) []byte {
+	var b strings.Builder
+	b.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n")
+	b.WriteString(`<Properties xmlns="http://schemas.openxmlformats.org/officeDocument/2006/custom-properties" xmlns:vt="http://schemas.openxmlformats.org/officeDocument/2006/docPropsVTypes">`)
+	for i, p := range props {
+		// pid 从 2 开始，0、1 为保留值。
+		fmt.Fprintf(&b, `<property fmtid="%s" pid="%d" name="%s"><vt:lpwstr>%s</vt:lpwstr></property>`, customPropsFmtID, i+2, p[0], escapeXML(p[1]))
+	}
+	b.WriteString(`</Properties>`)
+	return []byte(b.String())
+}
+
+func escapeXML(s string) string {
+	var b strings.Builder
+	_ = xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
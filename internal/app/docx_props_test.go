@@ -0,0 +1,85 @@
+package app
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeTestDocx(t *testing.T, path string, parts map[string]string) {
+	t.Helper()
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	zw := zip.NewWriter(f)
+	for name, content := range parts {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func readTestDocx(t *testing.T, path string) map[string]string {
+	t.Helper()
+	zr, err := zip.OpenReader(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer zr.Close()
+	out := map[string]string{}
+	for _, f := range zr.File {
+		b, err := readZipFile(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		out[f.Name] = string(b)
+	}
+	return out
+}
+
+func TestSetDocxProperties(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "a.docx")
+	writeTestDocx(t, path, map[string]string{
+		"[Content_Types].xml": `<?xml version="1.0"?><Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types"><Default Extension="xml" ContentType="application/xml"/></Types>`,
+		"_rels/.rels":         `<?xml version="1.0"?><Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="x" Target="word/document.xml"/></Relationships>`,
+		"docProps/core.xml":   `<cp:coreProperties xmlns:cp="c" xmlns:dc="d"><dc:title>T</dc:title></cp:coreProperties>`,
+		"word/document.xml":   `<w:document/>`,
+	})
+	meta := docxMetadata{JobID: "job_<1>", RunID: "run1", RulesVersion: "v3", TenantID: "demo", GeneratedAt: "2026-10-16T10:00:00+08:00", CLIVersion: "1.2.3"}
+	if err := setDocxProperties(path, meta); err != nil {
+		t.Fatalf("setDocxProperties error: %v", err)
+	}
+	// 再写一次应替换而非重复追加。
+	if err := setDocxProperties(path, meta); err != nil {
+		t.Fatalf("second setDocxProperties error: %v", err)
+	}
+	parts := readTestDocx(t, path)
+	if parts["word/document.xml"] != `<w:document/>` {
+		t.Fatalf("document part changed: %q", parts["word/document.xml"])
+	}
+	if core := parts["docProps/core.xml"]; strings.Count(core, "<dc:identifier>job_&lt;1&gt;</dc:identifier>") != 1 {
+		t.Fatalf("core=%s", core)
+	}
+	custom := parts["docProps/custom.xml"]
+	for _, want := range []string{`name="SylJobID"><vt:lpwstr>job_&lt;1&gt;</vt:lpwstr>`, `name="SylRulesVersion"><vt:lpwstr>v3<`, `name="SylTenant"><vt:lpwstr>demo<`, `name="SylCLIVersion"><vt:lpwstr>1.2.3<`, `pid="7"`} {
+		if !strings.Contains(custom, want) {
+			t.Fatalf("custom missing %q: %s", want, custom)
+		}
+	}
+	if strings.Count(parts["_rels/.rels"], customPropsRelType) != 1 || strings.Count(parts["[Content_Types].xml"], customPropsContentType) != 1 {
+		t.Fatalf("rels/content types not registered once: %s %s", parts["_rels/.rels"], parts["[Content_Types].xml"])
+	}
+}
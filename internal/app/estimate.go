@@ -0,0 +1,125 @@
+package app
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"syl-listing-pro/internal/client"
+	"syl-listing-pro/internal/i18n"
+	"syl-listing-pro/internal/manifest"
+)
+
+const (
+	// largeBatchTasks 为大批量的任务数下限：达到时开跑前查询剩余额度，开启 --confirm 时需确认。
+	largeBatchTasks = 20
+	// estimateHistory 为估算所依据的运行记录时间范围。
+	estimateHistory = 30 * 24 * time.Hour
+	// estimateSamples 为估算最多参考的最近成功任务数。
+	estimateSamples = 200
+)
+
+// runEstimate 为开跑前依据历史运行记录估算的耗时与用量。
+type runEstimate struct {
+	tasks int
+	// submits 为需要新提交的任务数（续跑时已提交的任务不再计用量）。
+	submits  int
+	samples  int
+	duration time.Duration
+	credits  float64
+}
+
+// estimateRun 取最近成功任务的平均耗时与 credits，按并发上限与提交间隔估算整批耗时与用量。
+func estimateRun(tasks []generateTask, history []manifest.Manifest, concurrency int, submitInterval time.Duration) runEstimate {
+	est := runEstimate{tasks: len(tasks)}
+	for _, t := range tasks {
+		if t.jobID == "" {
+			est.submits++
+		}
+	}
+	var totalMs int64
+	var totalCredits float64
+	for _, m := range history {
+		for _, t := range m.Tasks {
+			if est.samples >= estimateSamples {
+				break
+			}
+			if t.Status != manifest.StatusSucceeded || t.DurationMs <= 0 {
+				continue
+			}
+			est.samples++
+			totalMs += t.DurationMs
+			totalCredits += t.Credits
+		}
+	}
+	if est.samples == 0 {
+		return est
+	}
+	avg := time.Duration(totalMs/int64(est.samples)) * time.Millisecond
+	waves := (len(tasks) + concurrency - 1) / max(concurrency, 1)
+	est.duration = time.Duration(waves) * avg
+	if submitInterval > 0 {
+		est.duration = max(est.duration, time.Duration(est.submits-1)*submitInterval+avg)
+	}
+	est.credits = totalCredits / float64(est.samples) * float64(est.submits)
+	return est
+}
+
+func formatEstimate(est runEstimate) string {
+	if est.samples == 0 {
+		return i18n.T("预计：%d 个任务（暂无历史运行记录，无法估算耗时与用量）", est.tasks)
+	}
+	d := est.duration
+	if d > time.Minute {
+		d = d.Round(time.Minute)
+	}
+	if est.credits > 0 {
+		return i18n.T("预计：%d 个任务，约 %s，约 %.0f credits（依据最近 %d 个成功任务）", est.tasks, humanDurationShort(d), est.credits, est.samples)
+	}
+	return i18n.T("预计：%d 个任务，约 %s（依据最近 %d 个成功任务）", est.tasks, humanDurationShort(d), est.samples)
+}
+
+// preflightEstimate 在提交前输出耗时与用量估算；大批量时查询剩余额度，开启 --confirm 时等待确认。
+func preflightEstimate(ctx context.Context, log *Logger, api *client.API, token string, opts GenOptions, tasks []generateTask) error {
+	if len(tasks) < 2 {
+		return nil
+	}
+	history, _ := loadRunHistory(estimateHistory, time.Now())
+	est := estimateRun(tasks, history, maxConcurrentTasks, opts.pacer.submitInterval())
+	log.Info(formatEstimate(est))
+	if len(tasks) < largeBatchTasks {
+		return nil
+	}
+	if q, err := api.Quota(ctx, token); err == nil {
+		log.Info(i18n.T("剩余额度：%.2f credits", q.CreditsRemaining))
+		if est.credits > q.CreditsRemaining {
+			log.Info(i18n.T("预计用量超过剩余额度，部分任务可能因额度不足失败"))
+		}
+	} else if !errors.Is(err, client.ErrQuotaUnsupported) && !client.IsCanceled(err) {
+		log.Info(i18n.T("额度查询失败：%v", err))
+	}
+	if !opts.Confirm {
+		return nil
+	}
+	if !stdinIsTerminal() {
+		return i18n.Errorf("--confirm 需要在终端中确认（标准输入不是终端）")
+	}
+	fmt.Fprint(pickOutput, i18n.T("确认提交 %d 个任务？[y/N]：", len(tasks)))
+	line, _ := bufio.NewReader(pickInput).ReadString('\n')
+	switch strings.ToLower(strings.TrimSpace(line)) {
+	case "y", "yes":
+		return nil
+	}
+	return i18n.Errorf("未确认，已取消运行")
+}
+
+// submitInterval 返回 --submit-rate 对应的提交间隔；未限制时为 0。
+func (p *submitPacer) submitInterval() time.Duration {
+	if p == nil {
+		return 0
+	}
+	return p.interval
+}
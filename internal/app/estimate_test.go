@@ -0,0 +1,128 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"syl-listing-pro/internal/client"
+	"syl-listing-pro/internal/manifest"
+)
+
+func TestEstimateRun_FromHistory(t *testing.T) {
+	history := []manifest.Manifest{{Tasks: []manifest.Task{
+		{Status: manifest.StatusSucceeded, DurationMs: 60_000, Credits: 1},
+		{Status: manifest.StatusSucceeded, DurationMs: 180_000, Credits: 3},
+		{Status: manifest.StatusFailed, DurationMs: 999_000, Credits: 9},
+	}}}
+	tasks := make([]generateTask, 10)
+	tasks[0].jobID = "job_resumed"
+
+	est := estimateRun(tasks, history, 4, 0)
+	if est.samples != 2 || est.submits != 9 {
+		t.Fatalf("unexpected estimate: %+v", est)
+	}
+	// 10 个任务、并发 4 为 3 轮，每轮平均 2 分钟。
+	if est.duration != 6*time.Minute || est.credits != 18 {
+		t.Fatalf("duration=%s credits=%v", est.duration, est.credits)
+	}
+	if got := formatEstimate(est); !strings.Contains(got, "10 个任务") || !strings.Contains(got, "18 credits") {
+		t.Fatalf("unexpected text: %s", got)
+	}
+
+	paced := estimateRun(tasks, history, 4, time.Minute)
+	if paced.duration != 10*time.Minute {
+		t.Fatalf("paced duration=%s want 10m", paced.duration)
+	}
+	if empty := estimateRun(tasks, nil, 4, 0); !strings.Contains(formatEstimate(empty), "暂无历史运行记录") {
+		t.Fatalf("unexpected text: %s", formatEstimate(empty))
+	}
+}
+
+func TestPreflightEstimate_ConfirmLargeBatch(t *testing.T) {
+	prepareRunGenHome(t)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/quota" {
+			t.Errorf("unexpected request: %s", r.URL.Path)
+		}
+		_, _ = io.WriteString(w, `{"tenant_id":"demo","credits_remaining":12.5}`)
+	}))
+	defer ts.Close()
+	oldTerm, oldIn, oldOut := stdinIsTerminal, pickInput, pickOutput
+	defer func() { stdinIsTerminal, pickInput, pickOutput = oldTerm, oldIn, oldOut }()
+	stdinIsTerminal = func() bool { return true }
+	var prompt bytes.Buffer
+	pickOutput = &prompt
+
+	lg, _ := NewLogger(false, "")
+	api := client.New(ts.URL)
+	tasks := make([]generateTask, largeBatchTasks)
+	for _, answer := range []string{"y\n", "n\n"} {
+		pickInput = strings.NewReader(answer)
+		var err error
+		out := captureStdout(t, func() {
+			err = preflightEstimate(context.Background(), lg, api, "at", GenOptions{Confirm: true}, tasks)
+		})
+		if !strings.Contains(out, "剩余额度：12.50 credits") {
+			t.Fatalf("missing quota line: %s", out)
+		}
+		if (answer == "y\n") != (err == nil) {
+			t.Fatalf("answer %q: err=%v", answer, err)
+		}
+	}
+	if !strings.Contains(prompt.String(), "确认提交 20 个任务") {
+		t.Fatalf("unexpected prompt: %s", prompt.String())
+	}
+
+	stdinIsTerminal = func() bool { return false }
+	_ = captureStdout(t, func() {
+		if err := preflightEstimate(context.Background(), lg, api, "at", GenOptions{Confirm: true}, tasks); err == nil {
+			t.Fatal("--confirm without a terminal should fail")
+		}
+	})
+}
+
+func TestRunGen_DeclinedConfirmLeavesNoRunRecord(t *testing.T) {
+	prepareRunGenHome(t)
+	ts := newRunGenFastSuccessServer(t)
+	defer ts.Close()
+	oldBase := workerBaseURL
+	oldTerm, oldIn, oldOut := stdinIsTerminal, pickInput, pickOutput
+	defer func() {
+		workerBaseURL = oldBase
+		stdinIsTerminal, pickInput, pickOutput = oldTerm, oldIn, oldOut
+	}()
+	workerBaseURL = ts.URL
+	stdinIsTerminal = func() bool { return true }
+	pickInput = strings.NewReader("n\n")
+	pickOutput = io.Discard
+
+	inputPath := filepath.Join(t.TempDir(), "req.md")
+	if err := os.WriteFile(inputPath, []byte("# 输入"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	_, err := captureStdoutRun(t, func() error {
+		return RunGen(context.Background(), GenOptions{OutputDir: t.TempDir(), Inputs: []string{inputPath}, Num: largeBatchTasks, Confirm: true})
+	})
+	if err == nil || !strings.Contains(err.Error(), "未确认") {
+		t.Fatalf("expected declined confirmation, got %v", err)
+	}
+	if _, _, err := manifest.LoadLatest(filepath.Join(os.Getenv("HOME"), ".syl-listing-pro", "runs")); !errors.Is(err, manifest.ErrNoRuns) {
+		t.Fatalf("declined run must not leave a run record, got %v", err)
+	}
+}
+
+func TestRunRouted_RejectsConfirm(t *testing.T) {
+	err := runRouted(context.Background(), GenOptions{RouteFile: "routes.txt", Confirm: true})
+	if err == nil || !strings.Contains(err.Error(), "--confirm") {
+		t.Fatalf("expected --confirm to be rejected, got %v", err)
+	}
+}
@@ -0,0 +1,159 @@
+package app
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"syl-listing-pro/internal/client"
+	"syl-listing-pro/internal/i18n"
+)
+
+// eventSchemaVersion 为 --events-fd / --events-file 事件格式的版本号；字段只增不改，
+// 删除或改变字段含义时递增。
+const eventSchemaVersion = 1
+
+// eventStream 向 GUI、包装脚本输出稳定的 NDJSON 事件，与 --verbose 调试日志相互独立。
+// nil 表示未开启，全部方法均可直接调用。
+type eventStream struct {
+	mu    sync.Mutex
+	w     io.WriteCloser
+	runID string
+	now   func() time.Time
+}
+
+// openEventStream 按 --events-fd 或 --events-file 打开事件输出；两者都未指定时返回 nil。
+func openEventStream(fd int, path string) (*eventStream, error) {
+	path = strings.TrimSpace(path)
+	switch {
+	case fd > 0 && path != "":
+		return nil, i18n.Errorf("--events-fd 与 --events-file 不能同时使用")
+	case fd > 0:
+		if fd <= 2 {
+			return nil, i18n.Errorf("--events-fd 须大于 2（0、1、2 为标准输入输出）：%d", fd)
+		}
+		return &eventStream{w: os.NewFile(uintptr(fd), "events"), now: time.Now}, nil
+	case path != "":
+		if dir := filepath.Dir(path); dir != "." && dir != "" {
+			if err := os.MkdirAll(dir, 0o755); err != nil {
+				return nil, err
+			}
+		}
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+		if err != nil {
+			return nil, i18n.Errorf("打开事件文件失败: %w", err)
+		}
+		return &eventStream{w: f, now: time.Now}, nil
+	}
+	return nil, nil
+}
+
+func (s *eventStream) Close() error {
+	if s == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.w.Close()
+}
+
+// emit 写出一行事件；公共字段为 v（格式版本）、type、ts 与 run_id。写失败时忽略，不影响运行。
+func (s *eventStream) emit(typ string, fields map[string]any) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	m := map[string]any{"v": eventSchemaVersion, "type": typ, "ts": s.now().Format(time.RFC3339Nano)}
+	if s.runID != "" {
+		m["run_id"] = s.runID
+	}
+	for k, v := range fields {
+		m[k] = v
+	}
+	b, err := json.Marshal(m)
+	if err != nil {
+		return
+	}
+	_, _ = s.w.Write(append(b, '\n'))
+}
+
+func (s *eventStream) runStarted(runID, outputDir string, tasks int) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	s.runID = runID
+	s.mu.Unlock()
+	s.emit("run_started", map[string]any{"output_dir": mustAbsPath(outputDir), "tasks": tasks})
+}
+
+func (s *eventStream) taskStarted(task generateTask, attempt int) {
+	s.emit("task_started", map[string]any{
+		"task":       task.label,
+		"input_path": mustAbsPath(task.file.Path),
+		"index":      task.index,
+		"attempt":    attempt + 1,
+	})
+}
+
+func (s *eventStream) trace(task generateTask, item client.JobTraceItem) {
+	s.emit("trace", map[string]any{
+		"task":       task.label,
+		"job_id":     item.JobID,
+		"source":     item.Source,
+		"event":      item.Event,
+		"level":      item.Level,
+		"elapsed_ms": item.ElapsedMS,
+		"payload":    item.Payload,
+	})
+}
+
+// taskFinished 写出 task_succeeded、task_skipped 或 task_failed；retrying 表示失败后还会在下一轮重试。
+func (s *eventStream) taskFinished(task generateTask, result taskResult, retrying bool) {
+	if s == nil {
+		return
+	}
+	fields := map[string]any{
+		"task":        task.label,
+		"input_path":  mustAbsPath(task.file.Path),
+		"index":       task.index,
+		"job_id":      result.jobID,
+		"duration_ms": result.duration.Milliseconds(),
+	}
+	if result.ok || result.skipped {
+		outputs := make([]string, 0, len(result.outputs))
+		for _, p := range result.outputs {
+			outputs = append(outputs, mustAbsPath(p))
+		}
+		fields["outputs"] = outputs
+		if result.skipped {
+			// --on-conflict skip 下产物已存在，outputs 为之前运行写出的文件。
+			s.emit("task_skipped", fields)
+			return
+		}
+		s.emit("task_succeeded", fields)
+		return
+	}
+	errText := "canceled"
+	if result.err != nil {
+		errText = result.err.Error()
+	}
+	fields["error"] = errText
+	fields["retrying"] = retrying
+	s.emit("task_failed", fields)
+}
+
+// runFinished 写出 run_finished；status 为 succeeded、failed、cancelled 或 timeout。
+func (s *eventStream) runFinished(status string, succeeded, failed int, elapsed time.Duration) {
+	s.emit("run_finished", map[string]any{
+		"status":      status,
+		"succeeded":   succeeded,
+		"failed":      failed,
+		"duration_ms": elapsed.Milliseconds(),
+	})
+}
@@ -0,0 +1,82 @@
+package app
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestOpenEventStream_Validation(t *testing.T) {
+	if s, err := openEventStream(0, ""); s != nil || err != nil {
+		t.Fatalf("disabled stream: s=%v err=%v", s, err)
+	}
+	if _, err := openEventStream(3, "events.ndjson"); err == nil {
+		t.Fatal("expected conflict error")
+	}
+	if _, err := openEventStream(1, ""); err == nil {
+		t.Fatal("expected stdout fd rejected")
+	}
+	var s *eventStream
+	s.runFinished("succeeded", 0, 0, 0)
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRunGen_EventsFile(t *testing.T) {
+	prepareRunGenHome(t)
+	ts := newRunGenFastSuccessServer(t)
+	defer ts.Close()
+	oldBase := workerBaseURL
+	oldTimeout := streamTimeoutSecond
+	workerBaseURL = ts.URL
+	streamTimeoutSecond = 5
+	defer func() {
+		workerBaseURL = oldBase
+		streamTimeoutSecond = oldTimeout
+	}()
+
+	inputPath := filepath.Join(t.TempDir(), "req.md")
+	if err := os.WriteFile(inputPath, []byte("# 输入"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	eventsPath := filepath.Join(t.TempDir(), "events", "run.ndjson")
+	if _, err := captureStdoutRun(t, func() error {
+		return RunGen(context.Background(), GenOptions{OutputDir: t.TempDir(), Inputs: []string{inputPath}, SkipDocx: true, EventsFile: eventsPath})
+	}); err != nil {
+		t.Fatalf("RunGen error: %v", err)
+	}
+	f, err := os.Open(eventsPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	var types []string
+	var succeeded map[string]any
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		var ev map[string]any
+		if err := json.Unmarshal(sc.Bytes(), &ev); err != nil {
+			t.Fatalf("invalid line %q: %v", sc.Text(), err)
+		}
+		if ev["v"] != float64(eventSchemaVersion) || ev["run_id"] == "" {
+			t.Fatalf("missing common fields: %v", ev)
+		}
+		types = append(types, ev["type"].(string))
+		if ev["type"] == "task_succeeded" {
+			succeeded = ev
+		}
+	}
+	if len(types) < 4 || types[0] != "run_started" || types[1] != "task_started" || types[len(types)-1] != "run_finished" {
+		t.Fatalf("unexpected event order: %v", types)
+	}
+	if !containsString(types, "trace") || succeeded == nil {
+		t.Fatalf("missing trace or task_succeeded: %v", types)
+	}
+	if outputs, _ := succeeded["outputs"].([]any); len(outputs) != 3 || succeeded["job_id"] != "job_1" {
+		t.Fatalf("task_succeeded=%v", succeeded)
+	}
+}
@@ -0,0 +1,117 @@
+package app
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"syl-listing-pro/internal/i18n"
+	"syl-listing-pro/internal/listing"
+	"syl-listing-pro/internal/manifest"
+	"syl-listing-pro/internal/output"
+)
+
+// writeExports 运行结束后把全部成功的主稿按 --format 汇总为其他平台的批量导入文件
+// <输出目录>/<格式>_<run_id>.csv；选定过最终稿的需求文件只导出选定的候选。
+func writeExports(log *Logger, opts GenOptions, cp *manifest.Checkpoint) {
+	if len(opts.formats) == 0 {
+		return
+	}
+	if cp == nil {
+		log.Info(i18n.T("运行记录不可用，跳过导出"))
+		return
+	}
+	items, err := exportItems(cp.Snapshot())
+	if err != nil {
+		log.Info(i18n.T("导出失败：%v", err))
+		return
+	}
+	if len(items) == 0 {
+		log.Info(i18n.T("没有成功的 listing，跳过导出"))
+		return
+	}
+	for _, f := range opts.formats {
+		var buf bytes.Buffer
+		switch f {
+		case output.ExportShopify:
+			err = output.WriteShopifyCSV(&buf, items)
+		case output.ExportEbay:
+			var truncated []string
+			truncated, err = output.WriteEbayCSV(&buf, items, opts.Marketplace)
+			if len(truncated) > 0 {
+				log.Info(i18n.T("eBay 标题超过 80 个字符已截断：%s", strings.Join(truncated, ", ")))
+			}
+		}
+		path := filepath.Join(opts.OutputDir, fmt.Sprintf("%s_%s.csv", f, opts.runID))
+		if err == nil {
+			err = os.WriteFile(path, buf.Bytes(), 0o644)
+		}
+		if err != nil {
+			log.Info(i18n.T("导出 %s 失败：%v", f, err))
+			continue
+		}
+		log.Info(i18n.T("已导出 %s（%d 个商品）：%s", f, len(items), mustAbsPath(path)))
+	}
+}
+
+// exportItems 按任务顺序读取成功任务的主稿，拆出标题、五点与描述；handle 取需求文件名，重复时加序号。
+func exportItems(m manifest.Manifest) ([]output.ExportItem, error) {
+	selected := map[string]bool{}
+	for _, t := range m.Tasks {
+		if t.Selected {
+			selected[t.InputPath] = true
+		}
+	}
+	used := map[string]int{}
+	var items []output.ExportItem
+	for _, t := range m.Tasks {
+		if !t.Done() || (selected[t.InputPath] && !t.Selected) {
+			continue
+		}
+		en, _ := markdownOutputs(t.Outputs)
+		if en == "" {
+			continue
+		}
+		md, err := readMarkdownOutput(en)
+		if err != nil {
+			return nil, err
+		}
+		base := strings.TrimSuffix(filepath.Base(t.InputPath), filepath.Ext(t.InputPath))
+		if m.Num > 1 && !t.Selected {
+			base = fmt.Sprintf("%s-%d", base, t.Index)
+		}
+		handle := output.Handle(base)
+		if handle == "" {
+			handle = "listing"
+		}
+		if n := used[handle]; n > 0 {
+			used[handle] = n + 1
+			handle = fmt.Sprintf("%s-%d", handle, n+1)
+		} else {
+			used[handle] = 1
+		}
+		l := listing.Parse(md)
+		item := output.ExportItem{Handle: handle}
+		if s, ok := l.Section(listing.KindTitle); ok {
+			item.Title = plainMarkdown(strings.Join(strings.Fields(s.Body), " "))
+		}
+		if item.Title == "" {
+			item.Title = base
+		}
+		if s, ok := l.Section(listing.KindBullets); ok {
+			for _, b := range s.Items {
+				item.Bullets = append(item.Bullets, plainMarkdown(b))
+			}
+		}
+		if s, ok := l.Section(listing.KindDescription); ok {
+			item.Description = plainMarkdown(s.Body)
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// plainMarkdown 去掉加粗、斜体、行内代码等标记，导入目标平台时不显示 Markdown 符号。
+var plainMarkdown = strings.NewReplacer("**", "", "__", "", "`", "").Replace
@@ -0,0 +1,131 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"syl-listing-pro/internal/client"
+	"syl-listing-pro/internal/i18n"
+)
+
+const (
+	// failuresFileName 为输出目录中的失败报告文件名。
+	failuresFileName = "failures.json"
+	// failureTraceLines 为失败报告中每个任务保留的最后几行过程日志。
+	failureTraceLines = 20
+)
+
+// failureEntry 为 failures.json 中的一个失败任务。
+type failureEntry struct {
+	InputPath string   `json:"input_path"`
+	Index     int      `json:"index"`
+	Label     string   `json:"label,omitempty"`
+	JobID     string   `json:"job_id,omitempty"`
+	Class     string   `json:"class"`
+	Retryable bool     `json:"retryable"`
+	Error     string   `json:"error"`
+	Trace     []string `json:"trace,omitempty"`
+}
+
+type failuresFile struct {
+	RunID       string         `json:"run_id,omitempty"`
+	GeneratedAt string         `json:"generated_at"`
+	Failures    []failureEntry `json:"failures"`
+}
+
+// failureReport 收集最终失败（不再重试、非取消）的任务，运行结束时写入 failures.json，
+// 供 requeue 与问题反馈使用。
+type failureReport struct {
+	mu      sync.Mutex
+	entries map[string]failureEntry
+}
+
+func newFailureReport() *failureReport {
+	return &failureReport{entries: map[string]failureEntry{}}
+}
+
+// record 记下失败的任务；成功与 --on-conflict skip 跳过的任务不计入。取消的任务由调用方排除。
+func (r *failureReport) record(task generateTask, result taskResult) {
+	if result.ok || result.skipped {
+		return
+	}
+	errText := i18n.T("任务失败，未返回错误信息")
+	if result.err != nil {
+		errText = client.RedactText(result.err.Error())
+	}
+	entry := failureEntry{
+		InputPath: mustAbsPath(task.file.Path),
+		Index:     task.index,
+		Label:     task.label,
+		JobID:     result.jobID,
+		Class:     classifyFailure(result.err),
+		Retryable: result.retryable,
+		Error:     errText,
+		Trace:     result.traceTail,
+	}
+	r.mu.Lock()
+	r.entries[task.key()] = entry
+	r.mu.Unlock()
+}
+
+// write 按任务顺序写出 failures.json；没有失败任务时删除之前运行留下的 failures.json，避免被误当作本次结果。
+func (r *failureReport) write(log *Logger, outDir, runID string, tasks []generateTask) {
+	r.mu.Lock()
+	out := failuresFile{RunID: runID, GeneratedAt: time.Now().Format(time.RFC3339), Failures: []failureEntry{}}
+	for _, task := range tasks {
+		if e, ok := r.entries[task.key()]; ok {
+			out.Failures = append(out.Failures, e)
+		}
+	}
+	r.mu.Unlock()
+	path := filepath.Join(outDir, failuresFileName)
+	if len(out.Failures) == 0 {
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			log.Info(i18n.T("旧的失败报告删除失败：%v", err))
+		}
+		return
+	}
+	b, err := json.MarshalIndent(out, "", "  ")
+	if err == nil {
+		err = os.MkdirAll(outDir, 0o755)
+	}
+	if err == nil {
+		err = os.WriteFile(path, append(b, '\n'), 0o644)
+	}
+	if err != nil {
+		log.Info(i18n.T("失败报告写入失败：%v", err))
+		return
+	}
+	log.Info(i18n.T("失败报告已写入（%d 个任务）：%s", len(out.Failures), mustAbsPath(path)))
+}
+
+// classifyFailure 把错误归入固定分类，便于脚本按类处理。
+func classifyFailure(err error) string {
+	switch {
+	case errors.Is(err, client.ErrUnauthorized):
+		return "unauthorized"
+	case errors.Is(err, client.ErrQuotaExceeded):
+		return "quota_exceeded"
+	case errors.Is(err, client.ErrJobNotFound):
+		return "job_not_found"
+	case errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	case client.IsRetryable(err):
+		return "retryable"
+	}
+	return "failed"
+}
+
+// appendTraceTail 追加一行过程日志，只保留最后 failureTraceLines 行。
+func appendTraceTail(tail []string, line string) []string {
+	tail = append(tail, line)
+	if len(tail) > failureTraceLines {
+		tail = append(tail[:0], tail[len(tail)-failureTraceLines:]...)
+	}
+	return tail
+}
@@ -0,0 +1,127 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"syl-listing-pro/internal/client"
+	"syl-listing-pro/internal/input"
+)
+
+func TestClassifyFailure(t *testing.T) {
+	cases := []struct {
+		err  error
+		want string
+	}{
+		{fmt.Errorf("x: %w", client.ErrUnauthorized), "unauthorized"},
+		{fmt.Errorf("x: %w", client.ErrQuotaExceeded), "quota_exceeded"},
+		{client.ErrJobNotFound, "job_not_found"},
+		{fmt.Errorf("SSE 超时: %w", context.DeadlineExceeded), "timeout"},
+		{errors.New("rules missing"), "failed"},
+	}
+	for _, c := range cases {
+		if got := classifyFailure(c.err); got != c.want {
+			t.Fatalf("classifyFailure(%v)=%q, want %q", c.err, got, c.want)
+		}
+	}
+}
+
+func TestAppendTraceTail_KeepsLastLines(t *testing.T) {
+	var tail []string
+	for i := 0; i < failureTraceLines+5; i++ {
+		tail = appendTraceTail(tail, fmt.Sprint(i))
+	}
+	if len(tail) != failureTraceLines || tail[0] != "5" || tail[len(tail)-1] != fmt.Sprint(failureTraceLines+4) {
+		t.Fatalf("unexpected tail: %v", tail)
+	}
+}
+
+func TestRunGen_WritesFailuresJSON(t *testing.T) {
+	stubDocxConverter(t)
+	prepareRunGenHome(t)
+
+	inputPath := filepath.Join(t.TempDir(), "req.md")
+	if err := os.WriteFile(inputPath, []byte("# 输入\n\ncontent"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/auth/exchange":
+			_, _ = io.WriteString(w, `{"access_token":"at","tenant_id":"demo","expires_in":3600}`)
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/generate":
+			_, _ = io.WriteString(w, `{"job_id":"job_bad","status":"queued"}`)
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/jobs/job_bad/events":
+			writeSSETrace(t, w, 1, `{"job_id":"job_bad","tenant_id":"demo","offset":1,"item":{"source":"generation","event":"rules_loaded","tenant_id":"demo","job_id":"job_bad","elapsed_ms":1,"payload":{"rules_version":"v1"}}}`)
+			writeSSEEvent(t, w, "status", `{"job_id":"job_bad","tenant_id":"demo","status":"failed","error":"rules missing","updated_at":"2026-03-13T00:00:02Z"}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	oldBase := workerBaseURL
+	oldTimeout := streamTimeoutSecond
+	workerBaseURL = ts.URL
+	streamTimeoutSecond = 5
+	defer func() {
+		workerBaseURL = oldBase
+		streamTimeoutSecond = oldTimeout
+	}()
+
+	outDir := t.TempDir()
+	_, _ = captureStdoutRun(t, func() error {
+		return RunGen(context.Background(), GenOptions{Inputs: []string{inputPath}, OutputDir: outDir})
+	})
+
+	b, err := os.ReadFile(filepath.Join(outDir, failuresFileName))
+	if err != nil {
+		t.Fatalf("failures.json not written: %v", err)
+	}
+	var got failuresFile
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.RunID == "" || len(got.Failures) != 1 {
+		t.Fatalf("unexpected report: %s", b)
+	}
+	f := got.Failures[0]
+	if f.JobID != "job_bad" || f.Class != "failed" || f.Error != "rules missing" || f.InputPath != mustAbsPath(inputPath) || len(f.Trace) == 0 {
+		t.Fatalf("unexpected entry: %+v", f)
+	}
+}
+
+func TestFailureReport_RecordsFailuresWithoutErrorAndRemovesStaleFile(t *testing.T) {
+	lg, err := NewLogger(false, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	outDir := t.TempDir()
+	task := generateTask{file: input.RequirementFile{Path: "a.md"}, index: 1, label: "a.md"}
+
+	r := newFailureReport()
+	r.record(task, taskResult{jobID: "job_1"})
+	_ = captureStdout(t, func() { r.write(lg, outDir, "run1", []generateTask{task}) })
+	b, err := os.ReadFile(filepath.Join(outDir, failuresFileName))
+	if err != nil {
+		t.Fatalf("failure without error value should still be reported: %v", err)
+	}
+	var got failuresFile
+	if err := json.Unmarshal(b, &got); err != nil || len(got.Failures) != 1 || got.Failures[0].Error == "" || got.Failures[0].Class != "failed" {
+		t.Fatalf("unexpected report: %s (%v)", b, err)
+	}
+
+	clean := newFailureReport()
+	clean.record(task, taskResult{ok: true})
+	_ = captureStdout(t, func() { clean.write(lg, outDir, "run2", []generateTask{task}) })
+	if _, err := os.Stat(filepath.Join(outDir, failuresFileName)); !os.IsNotExist(err) {
+		t.Fatalf("clean run should remove the stale failures.json: %v", err)
+	}
+}
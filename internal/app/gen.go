@@ -2,6 +2,9 @@ package app
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"os"
@@ -15,8 +18,13 @@ import (
 
 	"golang.org/x/sync/semaphore"
 	"syl-listing-pro/internal/client"
+	"syl-listing-pro/internal/config"
+	"syl-listing-pro/internal/i18n"
 	"syl-listing-pro/internal/input"
+	"syl-listing-pro/internal/listing"
+	"syl-listing-pro/internal/manifest"
 	"syl-listing-pro/internal/output"
+	"syl-listing-pro/internal/processor"
 )
 
 var (
@@ -27,17 +35,174 @@ var (
 )
 
 type GenOptions struct {
-	Verbose   bool
-	LogFile   string
-	OutputDir string
-	Num       int
-	Inputs    []string
+	Verbose      bool
+	LogFile      string
+	OutputDir    string
+	Num          int
+	Inputs       []string
+	DedupContent bool
+	Incremental  bool
+	ResumeLast   bool
+	RetryFailed  int
+	// RequeueRunID 非空时只重新生成该运行中失败的任务。
+	RequeueRunID string
+	SkipDocx     bool
+	// OnConflict 非空时使用固定文件名（不带随机串），已存在的文件按该策略处理。
+	OnConflict string
+	// OutLayout 为输出目录组织方式：flat（默认）、per-input、per-date。
+	OutLayout string
+	// OutEncoding 为 Markdown 产物编码：utf8（默认）、utf8bom。
+	OutEncoding string
+	// OutNewlines 为 Markdown 产物换行符：lf（默认）、crlf。
+	OutNewlines string
+	// CLIVersion 为当前 CLI 版本，写入 Word 文档属性便于追溯。
+	CLIVersion string
+	// Stdout 为 en|cn|both 时把结果 Markdown 输出到标准输出而不写文件，只用于单个需求文件；日志改写到标准错误。
+	Stdout string
+	// EventsFD 大于 2 时向该文件描述符输出带版本的 NDJSON 事件流，供 GUI 与包装脚本使用。
+	EventsFD int
+	// EventsFile 非空时把同样的事件流写入该文件；与 EventsFD 二选一。
+	EventsFile string
+	// Combined 非空时运行结束后把全部成功的 listing 合并写入该 .md 或 .docx 文件。
+	Combined string
+	// Append 非空时每个任务成功后把主稿追加到该 .md 总目录（加文件锁）。
+	Append string
+	// Publish 为 true 时每个任务成功后把产物上传到 .env 中配置的对象存储。
+	Publish bool
+	// Processors 在每个任务成功写出产物后依次执行，失败只记录日志。
+	Processors []processor.Processor
+	// ConsistencyReport 为 true 时逐节比较 EN/CN，结果写入同名 .json 附带文件。
+	ConsistencyReport bool
+	// HTMLReport 为 true 时运行结束后在输出目录写 report_<run_id>.html。
+	HTMLReport bool
+	// Color 为彩色输出模式：auto（默认）、always、never。
+	Color string
+	// Timestamps 为 true 时普通日志每行前加墙钟时间。
+	Timestamps bool
+	// KeyProfile 指定使用的 KEY 配置；为空时取 use 选中的配置。
+	KeyProfile string
+	// Sections 非空时只重新生成这些分节（title|bullets|description）。
+	Sections []string
+	// Keywords 为每个任务都必须融入输出的关键词，与需求文件 frontmatter 的 keywords 合并。
+	Keywords []string
+	// Marketplace 为目标站点（us|de|fr|jp），决定输出语言对；为空时由服务端默认。
+	Marketplace string
+	// SearchTerms 为 true 时额外请求后台搜索词，写为 <文件名>_search_terms.txt。
+	SearchTerms bool
+	// Attributes 为随每个任务提交的商品属性；需求文件 frontmatter 中的同名属性优先，卖点合并。
+	Attributes client.ProductAttributes
+	// Label 为运行标签，记录在运行记录与附带文件中，便于按营销活动整理批次。
+	Label string
+	// Pick 为 true 且候选数大于 1 时，运行结束后在终端交互选定最终稿。
+	Pick bool
+	// AutoPick 为 true 且候选数大于 1 时，运行结束后本地给候选评分，把最高分复制为 _best 文件。
+	AutoPick bool
+	// Copy 为 en|cn 时，单个任务成功后把对应 Markdown 复制到系统剪贴板。
+	Copy string
+	// Open 为 true 时，单个任务成功后用系统默认程序打开主稿 Word。
+	Open bool
+	// BannedWordsFile 为禁用词表路径；为空时读取 ~/.syl-listing-pro/banned_words.txt（不存在则不检查）。
+	BannedWordsFile string
+	// Spellcheck 为 true 时对主稿英文做拼写检查，结果写入汇总与同名 .json 报告。
+	Spellcheck bool
+	// SpellDictFile 为英文词典（hunspell .dic 或单词表）；为空时查找系统词典。
+	SpellDictFile string
+	// StrictCompliance 为 true 时命中禁用词的任务记为失败（产物仍会写出）。
+	StrictCompliance bool
+
+	// Timeout 大于 0 时为整次运行的期限，到期后取消已提交任务并输出已完成部分的汇总。
+	Timeout time.Duration
+	// LogFormat 为标准输出格式：text（默认）或 json（NDJSON，不依赖 Verbose）。
+	LogFormat string
+	// LogDir 非空时每个任务的普通日志与 NDJSON 事件另写到 <LogDir>/<run_id>/<文件名>_<序号>.log|.ndjson。
+	LogDir string
+	// TraceBodyLimit 为 --verbose 日志中 HTTP 请求/响应体保留的最大字节数，0 表示不限制。
+	TraceBodyLimit int
+	// StageTimeouts 为命令行给出的各请求阶段单次超时，优先于 .env 中的 SYL_TIMEOUT_*。
+	StageTimeouts map[client.Stage]time.Duration
+	// RouteFile 非空时按该路由文件把输入分给多个 KEY 配置并发生成，产物写到 <OutputDir>/<KEY 配置名>。
+	RouteFile string
+	// Record 非空时把本次运行的全部 API 请求与响应（已脱敏）录制到该文件。
+	Record string
+	// Replay 非空时用该录制文件应答 API 请求，不访问服务端；未配置 KEY 也可运行。
+	Replay string
+	// TraceSample 大于 1 时，--verbose 下同类高频追踪事件每 N 条只记录 1 条（错误与警告除外）。
+	TraceSample int
+	// Normalize 为写出 EN/CN 前对 Markdown 应用的规范化规则（blank-lines|bullets|straight-quotes|curly-quotes|strip-emoji）。
+	Normalize []string
+	// Formats 为运行结束后额外导出的批量导入格式（shopify|ebay），写为 <输出目录>/<格式>_<run_id>.csv。
+	Formats []string
+	// ProvenanceHeader 为 true 时在 EN/CN Markdown 第一行写入 <!-- syl: job=... rules=... generated=... --> 来源注释。
+	ProvenanceHeader bool
+	// Confirm 为 true 时，任务数达到大批量门槛后先输出估算并在终端确认再提交。
+	Confirm bool
+	// SubmitRate 为新任务的提交速率上限，格式 N/sec|min|hour（如 10/min）；为空不限制。
+	SubmitRate string
+	// SubmitWindow 为每天允许提交新任务的时段，格式 HH:MM-HH:MM（本地时间，可跨午夜）；为空不限制。
+	SubmitWindow string
+
+	// bannedWords 为 RunGen 载入的禁用词表。
+	bannedWords []string
+	// formats 为 Formats 解析后的导出格式。
+	formats []output.ExportFormat
+	// normalize 为 Normalize 解析后的选项。
+	normalize listing.NormalizeOptions
+	// spellDict 为 RunGen 载入的拼写词典；为空表示不检查。
+	spellDict map[string]struct{}
+	// traceSampler 为本次运行共享的追踪采样器。
+	traceSampler *traceSampler
+	// events 为 EventsFD / EventsFile 打开的事件流；nil 表示未开启。
+	events *eventStream
+	// pacer 为 SubmitRate / SubmitWindow 对应的提交节奏控制；nil 表示不限制。
+	pacer *submitPacer
+	// limiter 为本次运行的自适应并发上限，runGenerateTask 据排队时长向其报告服务端压力。
+	limiter *adaptiveLimiter
+	// pause 为本次运行的暂停开关（--route 时各租户共用一个）；暂停期间不提交新任务。
+	pause *pauseGate
+	// runID 为产物所属的运行编号（续跑时为原运行），写入 Word 文档属性。
+	runID string
 }
 
 type generateTask struct {
-	file  input.RequirementFile
-	index int
-	label string
+	file           input.RequirementFile
+	index          int
+	label          string
+	idempotencyKey string
+	// jobID 非空表示续跑或重试时重新接入已提交的任务，不再重复提交。
+	jobID string
+	// mirrors 为内容与 file 完全相同的其他输入，复用同一个任务结果分别写出产物。
+	mirrors []input.RequirementFile
+}
+
+type taskResult struct {
+	ok      bool
+	jobID   string
+	outputs []string
+	err     error
+	// retryable 表示失败原因是超时、5xx、网络抖动等，重新提交有望成功。
+	retryable bool
+	// jobFailed 表示服务端已把任务置为终态 failed，重试需要换新的幂等键重新提交。
+	jobFailed bool
+	// skipped 表示 --on-conflict skip 下全部产物均已存在，本次没有写入任何文件；outputs 为已存在的文件。
+	skipped bool
+	// remoteURLs 为开启上传时产物的远端地址。
+	remoteURLs []string
+	listing    client.ResultResp
+	// inconsistent 表示开启一致性检查时 EN/CN 存在不一致。
+	inconsistent bool
+	// bannedHits 为结果中命中的禁用词。
+	bannedHits []string
+	// misspellings 为主稿英文中疑似拼错的单词。
+	misspellings []string
+	// keywordCoverage 为关键词在主稿标题、五点、描述中的出现次数；missingKeywords 为其中未出现的关键词。
+	keywordCoverage []listing.KeywordCoverage
+	missingKeywords []string
+	rulesVersion    string
+	duration        time.Duration
+	// sectionDurations 为各分节生成耗时（毫秒）。
+	sectionDurations map[string]int64
+	// traceTail 为最后几行过程日志（不带颜色，已隐去 KEY），写入失败报告。
+	traceTail []string
 }
 
 type submittedJob struct {
@@ -75,27 +240,157 @@ func (r *submittedJobRegistry) snapshot() []submittedJob {
 }
 
 func RunGen(ctx context.Context, opts GenOptions) error {
+	if opts.RouteFile != "" {
+		return runRouted(ctx, opts)
+	}
 	if opts.Num <= 0 {
 		opts.Num = 1
 	}
-	sylKey, err := loadSYLKeyForRun()
+	if _, err := output.ParseConflictPolicy(opts.OnConflict); err != nil {
+		return err
+	}
+	if _, err := output.ParseLayout(opts.OutLayout); err != nil {
+		return err
+	}
+	if _, err := output.ParseEncoding(opts.OutEncoding); err != nil {
+		return err
+	}
+	if _, err := output.ParseNewlines(opts.OutNewlines); err != nil {
+		return err
+	}
+	sections, err := normalizeSections(opts.Sections)
+	if err != nil {
+		return err
+	}
+	opts.Sections = sections
+	opts.Keywords = mergeKeywords(opts.Keywords)
+	opts.Attributes = productAttributes(client.ProductAttributes{
+		Brand:          strings.TrimSpace(opts.Attributes.Brand),
+		Category:       strings.TrimSpace(opts.Attributes.Category),
+		TargetAudience: strings.TrimSpace(opts.Attributes.TargetAudience),
+		KeyFeatures:    opts.Attributes.KeyFeatures,
+	}, nil)
+	opts.Label = strings.TrimSpace(opts.Label)
+	if opts.Marketplace, err = parseMarketplace(opts.Marketplace); err != nil {
+		return err
+	}
+	if opts.Copy, err = parseCopyTarget(opts.Copy); err != nil {
+		return err
+	}
+	if opts.Stdout, err = parseStdoutMode(opts.Stdout); err != nil {
+		return err
+	}
+	if opts.Pick && opts.AutoPick {
+		return i18n.Errorf("--pick 不能与 --auto-pick 同时使用")
+	}
+	if err := checkStdoutOptions(opts); err != nil {
+		return err
+	}
+	if opts.normalize, err = parseNormalizeRules(opts.Normalize); err != nil {
+		return err
+	}
+	if opts.Combined, err = parseCombinedPath(opts.Combined); err != nil {
+		return err
+	}
+	if opts.Append, err = parseAppendPath(opts.Append); err != nil {
+		return err
+	}
+	if opts.formats, err = output.ParseExportFormats(opts.Formats); err != nil {
+		return err
+	}
+	if opts.pacer, err = newSubmitPacer(opts.SubmitRate, opts.SubmitWindow); err != nil {
+		return err
+	}
+	if opts.Timeout < 0 {
+		return i18n.Errorf("--timeout 不能为负数：%s", opts.Timeout)
+	}
+	if opts.Timeout > 0 {
+		var cancelRun context.CancelFunc
+		ctx, cancelRun = context.WithTimeout(ctx, opts.Timeout)
+		defer cancelRun()
+	}
+	if opts.bannedWords, err = config.LoadBannedWords(opts.BannedWordsFile); err != nil {
+		return err
+	}
+	profile, err := resolveKeyProfile(opts.KeyProfile)
 	if err != nil {
 		return err
 	}
+	opts.KeyProfile = profile
+	if opts.Record != "" && opts.Replay != "" {
+		return i18n.Errorf("--record 与 --replay 不能同时使用")
+	}
+	sylKey, err := loadSYLKeyForRun(opts.KeyProfile)
+	if err != nil {
+		if opts.Replay == "" {
+			return err
+		}
+		sylKey = replayKey
+	}
 	log, err := NewLogger(opts.Verbose, opts.LogFile)
 	if err != nil {
 		return err
 	}
 	defer func() { _ = log.Close() }()
+	if err := log.SetColorMode(opts.Color); err != nil {
+		return err
+	}
+	if err := log.SetLogFormat(opts.LogFormat); err != nil {
+		return err
+	}
+	log.SetTimestamps(opts.Timestamps)
+	log.SetStderr(opts.Stdout != "")
+	if opts.events, err = openEventStream(opts.EventsFD, opts.EventsFile); err != nil {
+		return err
+	}
+	defer func() { _ = opts.events.Close() }()
+	if opts.Spellcheck {
+		if opts.spellDict, err = config.LoadSpellDictionary(opts.SpellDictFile); err != nil {
+			return err
+		}
+		if len(opts.spellDict) == 0 {
+			log.Info(i18n.T("未找到英文词典，跳过拼写检查（可用 --spell-dict 指定 hunspell .dic 或单词表）"))
+		}
+	}
+	log.AddSecret(sylKey)
 	runDone := make(chan struct{})
 	defer close(runDone)
 	startAll := time.Now()
+	runID := newRunID(startAll)
 
+	opts.traceSampler = newTraceSampler(opts.TraceSample)
 	api := client.New(resolveWorkerBaseURL())
+	api.SetTraceBodyLimit(opts.TraceBodyLimit)
+	if err := applyStageTimeouts(api, opts.StageTimeouts); err != nil {
+		return err
+	}
+	if opts.Replay != "" {
+		if err := api.Replay(opts.Replay); err != nil {
+			return err
+		}
+		log.Info(i18n.T("回放模式：API 请求由录制文件应答：%s", mustAbsPath(opts.Replay)))
+	}
+	if opts.Record != "" {
+		api.StartRecording()
+		defer func() {
+			if err := api.SaveCassette(opts.Record); err != nil {
+				log.Info(i18n.T("写录制文件失败：%v", err))
+				return
+			}
+			log.Info(i18n.T("API 交互已录制到：%s", mustAbsPath(opts.Record)))
+		}()
+	}
 	api.SetTrace(func(ev client.TraceEvent) {
 		if shouldSkipVerboseHTTPTrace(opts.Verbose, ev) {
 			return
 		}
+		level := ""
+		if ev.Stage == "error" || ev.StatusCode >= 400 {
+			level = "error"
+		}
+		if !opts.traceSampler.keep(ev.Stage+" "+ev.Method+" "+traceURLPath(ev.URL), level) {
+			return
+		}
 		log.Event("worker_http_"+ev.Stage, map[string]any{
 			"method":      ev.Method,
 			"url":         ev.URL,
@@ -108,15 +403,60 @@ func RunGen(ctx context.Context, opts GenOptions) error {
 	})
 	ex, err := api.Exchange(ctx, sylKey)
 	if err != nil {
-		return err
+		return withRemediation(err)
 	}
+	log.AddSecret(ex.AccessToken)
 
-	files, err := input.Discover(opts.Inputs)
+	plan, err := planRun(log, opts, runID, startAll)
 	if err != nil {
 		return err
 	}
-
-	tasks := buildGenerateTasks(files, opts.Num)
+	if len(plan.tasks) == 0 {
+		opts.events.runStarted(runID, plan.outputDir, 0)
+		opts.events.runFinished("succeeded", 0, 0, time.Since(startAll))
+		return nil
+	}
+	opts.OutputDir = plan.outputDir
+	opts.Num = plan.num
+	opts.Sections = plan.sections
+	opts.Keywords = plan.keywords
+	opts.Marketplace = plan.marketplace
+	opts.SearchTerms = plan.searchTerms
+	opts.Attributes = plan.attributes
+	opts.Label = plan.label
+	opts.runID = runID
+	if plan.checkpoint != nil {
+		opts.runID = plan.checkpoint.Snapshot().RunID
+	}
+	opts.events.runStarted(opts.runID, opts.OutputDir, len(plan.tasks))
+	if err := preflightRun(opts); err != nil {
+		return err
+	}
+	if err := preflightEstimate(ctx, log, api, ex.AccessToken, opts, plan.tasks); err != nil {
+		opts.events.runFinished("cancelled", 0, 0, time.Since(startAll))
+		return err
+	}
+	if plan.pending != nil {
+		plan.checkpoint = createRunCheckpoint(log, *plan.pending)
+	}
+	if opts.LogDir != "" {
+		log.Info(i18n.T("各任务日志写入：%s", mustAbsPath(filepath.Join(opts.LogDir, runID))))
+	}
+	var publisher *outputPublisher
+	if opts.Publish {
+		publisher, err = newOutputPublisher(runID)
+		if err != nil {
+			return err
+		}
+	}
+	tasks := plan.tasks
+	cp := plan.checkpoint
+	summary := newTaskSummary()
+	failures := newFailureReport()
+	var tracker *incrementalTracker
+	if plan.ledger != nil {
+		tracker = newIncrementalTracker(log, plan.ledger, tasks)
+	}
 	submitted := newSubmittedJobRegistry()
 	var cancelOnce sync.Once
 	cancelDone := make(chan struct{})
@@ -127,7 +467,11 @@ func RunGen(ctx context.Context, opts GenOptions) error {
 			if len(jobs) == 0 {
 				return
 			}
-			log.Info(fmt.Sprintf("检测到中断，开始取消已提交任务（%d）", len(jobs)))
+			if runTimedOut(ctx, opts) {
+				log.Info(i18n.T("运行已达 --timeout %s，开始取消已提交任务（%d）", opts.Timeout, len(jobs)))
+			} else {
+				log.Info(i18n.T("检测到中断，开始取消已提交任务（%d），再次中断可立即退出", len(jobs)))
+			}
 			cancelCtx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
 			defer cancel()
 			var okCount atomic.Int64
@@ -147,26 +491,26 @@ func RunGen(ctx context.Context, opts GenOptions) error {
 					resp, err := api.CancelJob(cancelCtx, ex.AccessToken, item.jobID)
 					if err != nil {
 						failCount.Add(1)
-						log.Info(fmt.Sprintf("%s 取消失败：%v", taskPrefix(ex.TenantID, 0, item.label), err))
+						log.Info(i18n.T("%s 取消失败：%v", taskPrefix(ex.TenantID, 0, item.label), err))
 						return
 					}
 					okCount.Add(1)
 					if resp.Cancelled || strings.EqualFold(resp.Status, "cancelled") {
-						log.Info(fmt.Sprintf("%s 已取消（job_id=%s）", taskPrefix(ex.TenantID, 0, item.label), item.jobID))
+						log.Info(i18n.T("%s 已取消（job_id=%s）", taskPrefix(ex.TenantID, 0, item.label), item.jobID))
 						return
 					}
-					log.Info(fmt.Sprintf("%s 已提交取消请求（job_id=%s）", taskPrefix(ex.TenantID, 0, item.label), item.jobID))
+					log.Info(i18n.T("%s 已提交取消请求（job_id=%s）", taskPrefix(ex.TenantID, 0, item.label), item.jobID))
 				}()
 			}
 			cwg.Wait()
-			log.Info(fmt.Sprintf("取消完成：成功 %d，失败 %d", okCount.Load(), failCount.Load()))
+			log.Info(i18n.T("取消完成：成功 %d，失败 %d", okCount.Load(), failCount.Load()))
 		})
 	}
 
 	go func() {
 		select {
 		case <-ctx.Done():
-			if isContextCanceledErr(ctx.Err()) {
+			if client.IsCanceled(ctx.Err()) {
 				cancelSubmittedTasks()
 			}
 		case <-runDone:
@@ -176,57 +520,219 @@ func RunGen(ctx context.Context, opts GenOptions) error {
 
 	var successCount atomic.Int64
 	var failedCount atomic.Int64
-	var wg sync.WaitGroup
-	sem := semaphore.NewWeighted(int64(maxConcurrentTasks))
-
-	for _, task := range tasks {
-		task := task
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
-			if err := sem.Acquire(ctx, 1); err != nil {
-				if isContextCanceledErr(err) {
-					log.Info(fmt.Sprintf("%s 已取消", taskPrefix(ex.TenantID, 0, task.label)))
+	var skippedCount atomic.Int64
+	var inconsistentCount atomic.Int64
+	var bannedCount atomic.Int64
+	var misspelledCount atomic.Int64
+	var keywordMissingCount atomic.Int64
+	var usageMu sync.Mutex
+	var runUsage client.Usage
+	usageSeen := false
+	limiter := newAdaptiveLimiter(maxConcurrentTasks, log)
+	opts.limiter = limiter
+	if opts.pause == nil {
+		opts.pause = &pauseGate{}
+		stopPause := watchPause(ctx, log, opts.pause, pauseKeys(opts))
+		defer stopPause()
+	}
+
+	// runBatch 并发执行一轮任务，返回本轮失败但可重试、且还有重试机会的任务。
+	runBatch := func(batch []generateTask, pass int) []generateTask {
+		var wg sync.WaitGroup
+		var retryMu sync.Mutex
+		var retry []generateTask
+		for _, task := range batch {
+			task := task
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if err := limiter.Acquire(ctx); err != nil {
+					if client.IsCanceled(err) {
+						log.Info(i18n.T("%s 已取消", taskPrefix(ex.TenantID, 0, task.label)))
+						return
+					}
+					failedCount.Add(1)
+					log.Info(i18n.T("%s 生成失败：%v", taskPrefix(ex.TenantID, 0, task.label), err))
 					return
 				}
-				failedCount.Add(1)
-				log.Info(fmt.Sprintf("%s 生成失败：%v", taskPrefix(ex.TenantID, 0, task.label), err))
-				return
-			}
-			defer sem.Release(1)
+				defer limiter.Release()
+
+				log := openTaskLog(log, opts.LogDir, runID, task)
+				defer closeTaskLog(log)
+				opts.events.taskStarted(task, pass)
+				taskStart := time.Now()
+				result := runTaskRecovered(log, runID, task, func() taskResult {
+					result := runGenerateTask(ctx, api, ex, log, opts, task, func(jobID string) {
+						submitted.add(jobID, task.label)
+						log.Event("job_submitted", map[string]any{"job_id": jobID, "task": task.label})
+						recordTaskCheckpoint(cp, log, task, func(item *manifest.Task) {
+							item.Status = manifest.StatusSubmitted
+							item.JobID = jobID
+						})
+					})
+					result.duration = time.Since(taskStart)
+					if result.ok {
+						prefix := taskPrefix(ex.TenantID, 0, task.label)
+						result.remoteURLs = publisher.publish(ctx, log, prefix, result.outputs)
+						appendCatalog(log, opts, prefix, task, result.listing)
+						runProcessors(ctx, log, opts.Processors, prefix, processor.Result{
+							RunID:     runID,
+							InputPath: mustAbsPath(task.file.Path),
+							Index:     task.index,
+							JobID:     result.jobID,
+							Listing:   result.listing,
+							Outputs:   result.outputs,
+						})
+					}
+					return result
+				})
+				recordTaskResult(ctx, cp, log, task, result)
+				limiter.observe(result)
+				retrying := !result.ok && result.retryable && pass < opts.RetryFailed && !client.IsCanceled(ctx.Err())
+				opts.events.taskFinished(task, result, retrying)
+				if !retrying {
+					summary.record(task, result, client.IsCanceled(ctx.Err()))
+					if !client.IsCanceled(ctx.Err()) {
+						failures.record(task, result)
+					}
+				}
+				if len(result.bannedHits) > 0 {
+					bannedCount.Add(1)
+				}
+				if len(result.misspellings) > 0 {
+					misspelledCount.Add(1)
+				}
+				if len(result.missingKeywords) > 0 {
+					keywordMissingCount.Add(1)
+				}
+				switch {
+				case result.ok:
+					successCount.Add(1)
+					if result.listing.Usage != nil {
+						usageMu.Lock()
+						runUsage = runUsage.Add(*result.listing.Usage)
+						usageSeen = true
+						usageMu.Unlock()
+					}
+					if result.inconsistent {
+						inconsistentCount.Add(1)
+					}
+				case result.skipped:
+					skippedCount.Add(1)
+				case client.IsCanceled(ctx.Err()):
+					return
+				case result.retryable && pass < opts.RetryFailed:
+					// 已提交的任务（SSE 超时、流中断、读取结果失败）在服务端可能仍在运行，
+					// 重试时重新接入原 job_id，避免重复提交、重复计费；提交本身失败时沿用原幂等键重新提交，
+					// 服务端若已受理会返回同一个任务。只有服务端返回终态 failed 时才换新的幂等键。
+					task.jobID = result.jobID
+					if result.jobFailed {
+						task.jobID = ""
+						task.idempotencyKey = idempotencyKey(fmt.Sprintf("%s:retry%d", runID, pass+1), task)
+					}
+					retryMu.Lock()
+					retry = append(retry, task)
+					retryMu.Unlock()
+					return
+				default:
+					failedCount.Add(1)
+				}
+				if tracker != nil {
+					tracker.done(task, result)
+				}
+			}()
+		}
+		wg.Wait()
+		return retry
+	}
 
-			if runGenerateTask(ctx, api, ex, log, opts, task, func(jobID string) {
-				submitted.add(jobID, task.label)
-			}) {
-				successCount.Add(1)
-				return
-			}
-			if isContextCanceledErr(ctx.Err()) {
-				return
-			}
-			failedCount.Add(1)
-		}()
+	pending := runBatch(tasks, 0)
+	for pass := 1; len(pending) > 0 && !client.IsCanceled(ctx.Err()); pass++ {
+		log.Info(i18n.T("第 %d/%d 轮重试：%d 个任务因可重试错误失败，重新处理", pass, opts.RetryFailed, len(pending)))
+		pending = runBatch(pending, pass)
+	}
+	if err := cp.Finish(time.Now()); err != nil {
+		log.Info(i18n.T("运行记录写入失败：%v", err))
+	}
+	if opts.HTMLReport {
+		writeHTMLReport(log, opts.OutputDir, cp)
+	}
+	if len(tasks) > 1 {
+		summary.write(log, tasks)
+	}
+	if opts.Stdout == "" {
+		failures.write(log, opts.OutputDir, opts.runID, tasks)
 	}
-	wg.Wait()
-	if isContextCanceledErr(ctx.Err()) {
+	if client.IsCanceled(ctx.Err()) {
 		cancelSubmittedTasks()
 		select {
 		case <-cancelDone:
 		case <-time.After(25 * time.Second):
-			log.Info("取消等待超时，已退出")
+			log.Info(i18n.T("取消等待超时，已退出"))
 		}
+		if runTimedOut(ctx, opts) {
+			success, failed := int(successCount.Load()), int(failedCount.Load())
+			opts.events.runFinished("timeout", success, failed, time.Since(startAll))
+			log.Info(i18n.T("运行超时（--timeout %s）：成功 %d，失败 %d，未完成 %d", opts.Timeout, success, failed, len(tasks)-success-failed))
+			if cp != nil {
+				log.Info(i18n.T("可执行 syl-listing-pro requeue %s 重新生成失败任务", cp.Snapshot().RunID))
+			}
+			return i18n.Errorf("运行超过 --timeout %s，未完成的任务已取消", opts.Timeout)
+		}
+		opts.events.runFinished("cancelled", int(successCount.Load()), int(failedCount.Load()), time.Since(startAll))
 		return context.Canceled
 	}
 
 	success := int(successCount.Load())
 	failed := int(failedCount.Load())
-	log.Info(fmt.Sprintf("任务完成：成功 %d，失败 %d，总耗时 %s", success, failed, humanDurationShort(time.Since(startAll))))
+	log.Info(i18n.T("任务完成：成功 %d，失败 %d，总耗时 %s", success, failed, humanDurationShort(time.Since(startAll))))
+	if n := skippedCount.Load(); n > 0 {
+		log.Info(i18n.T("跳过 %d 个任务：输出已存在（--on-conflict skip）", n))
+	}
+	if usageSeen {
+		log.Info(i18n.T("本次用量：%s", formatUsage(runUsage)))
+	}
+	if opts.ConsistencyReport {
+		log.Info(i18n.T("EN/CN 一致性：%d 个任务存在不一致，详见同名 .json 报告", inconsistentCount.Load()))
+	}
+	if n := bannedCount.Load(); n > 0 {
+		log.Info(i18n.T("合规检查：%d 个任务命中禁用词，详见同名 .json 报告", n))
+	}
+	if n := misspelledCount.Load(); n > 0 {
+		log.Info(i18n.T("拼写检查：%d 个任务有疑似拼写错误，详见同名 .json 报告", n))
+	}
+	if n := keywordMissingCount.Load(); n > 0 {
+		log.Info(i18n.T("关键词覆盖：%d 个任务缺失关键词，可考虑重新生成，详见同名 .json 报告", n))
+	}
+	if opts.Pick && opts.Num > 1 {
+		runCandidatePicker(log, cp)
+	}
+	if opts.AutoPick && opts.Num > 1 {
+		runAutoPick(log, opts, cp)
+	}
+	writeCombined(ctx, log, opts, ex.TenantID, cp)
+	writeExports(log, opts, cp)
+	runDesktopActions(log, opts, cp)
+	status := "succeeded"
+	if failed > 0 {
+		status = "failed"
+	}
+	opts.events.runFinished(status, success, failed, time.Since(startAll))
+	if failed > 0 && cp != nil {
+		log.Info(i18n.T("可执行 syl-listing-pro requeue %s 重新生成失败任务", cp.Snapshot().RunID))
+	}
 	if failed > 0 {
-		return fmt.Errorf("存在失败任务")
+		return i18n.Errorf("存在失败任务")
 	}
 	return nil
 }
 
+// RunRequeue 重新生成指定运行中失败的任务。
+func RunRequeue(ctx context.Context, runID string, opts GenOptions) error {
+	opts.RequeueRunID = runID
+	return RunGen(ctx, opts)
+}
+
 func buildGenerateTasks(files []input.RequirementFile, num int) []generateTask {
 	tasks := make([]generateTask, 0, len(files)*num)
 	fileCount := len(files)
@@ -242,6 +748,38 @@ func buildGenerateTasks(files []input.RequirementFile, num int) []generateTask {
 	return tasks
 }
 
+// dedupGenerateTasks 合并内容相同且序号相同的任务，返回合并后的任务列表与被合并的任务数。
+func dedupGenerateTasks(tasks []generateTask) ([]generateTask, int) {
+	out := make([]generateTask, 0, len(tasks))
+	primary := make(map[string]int, len(tasks))
+	merged := 0
+	for _, task := range tasks {
+		key := fmt.Sprintf("%s#%d", task.file.ContentHash(), task.index)
+		if idx, ok := primary[key]; ok {
+			out[idx].mirrors = append(out[idx].mirrors, task.file)
+			merged++
+			continue
+		}
+		primary[key] = len(out)
+		out = append(out, task)
+	}
+	return out, merged
+}
+
+// newRunID 生成本次运行的标识：时间前缀便于排序，随机后缀避免同秒冲突。
+func newRunID(now time.Time) string {
+	b := make([]byte, 3)
+	_, _ = rand.Read(b)
+	return now.Format("20060102-150405") + "-" + hex.EncodeToString(b)
+}
+
+// idempotencyKey 由运行标识、输入内容哈希和任务键（绝对路径与候选序号）派生，同一任务的网络重试共用同一个 key；
+// 内容相同的不同输入文件各自得到不同的 key，不会被服务端合并为一个任务。
+func idempotencyKey(runID string, task generateTask) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s:%s:%s", runID, task.file.ContentHash(), task.key())))
+	return hex.EncodeToString(sum[:16])
+}
+
 func taskDisplayLabel(fileCount, num int, path string, index int) string {
 	base := filepath.Base(path)
 	switch {
@@ -272,57 +810,114 @@ func runGenerateTask(
 	opts GenOptions,
 	task generateTask,
 	onJobSubmitted func(jobID string),
-) bool {
+) taskResult {
 	tenantForLog := ex.TenantID
 	var elapsedForLog int64
 
-	resp, err := api.Generate(ctx, ex.AccessToken, client.GenerateReq{
-		InputMarkdown:  task.file.Content,
-		InputFilename:  filepath.Base(task.file.Path),
-		CandidateCount: 1,
-	})
-	if err != nil {
-		if isContextCanceledErr(err) {
-			log.Info(fmt.Sprintf("%s 已取消", taskPrefix(tenantForLog, elapsedForLog, task.label)))
-			return false
+	jobID := task.jobID
+	if jobID == "" {
+		if err := opts.pause.wait(ctx); err != nil {
+			log.Info(i18n.T("%s 已取消", taskPrefix(tenantForLog, elapsedForLog, task.label)))
+			return taskResult{}
 		}
-		log.Info(fmt.Sprintf("%s 生成失败：%v", taskPrefix(tenantForLog, elapsedForLog, task.label), err))
-		return false
+		if err := opts.pacer.wait(ctx, log, taskPrefix(tenantForLog, elapsedForLog, task.label)); err != nil {
+			log.Info(i18n.T("%s 已取消", taskPrefix(tenantForLog, elapsedForLog, task.label)))
+			return taskResult{}
+		}
+		fm, body := task.file.Frontmatter()
+		resp, err := api.Generate(ctx, ex.AccessToken, client.GenerateReq{
+			InputMarkdown:     body,
+			InputFilename:     filepath.Base(task.file.Path),
+			CandidateCount:    1,
+			Sections:          opts.Sections,
+			Keywords:          mergeKeywords(opts.Keywords, input.SplitList(fm["keywords"])...),
+			Marketplace:       opts.Marketplace,
+			SearchTerms:       opts.SearchTerms,
+			ProductAttributes: productAttributes(opts.Attributes, fm),
+			IdempotencyKey:    task.idempotencyKey,
+		})
+		if err != nil {
+			// 只有整个运行被取消时才算取消；--submit-timeout 或客户端超时同样包裹 DeadlineExceeded，按失败处理。
+			if ctx.Err() != nil {
+				log.Info(i18n.T("%s 已取消", taskPrefix(tenantForLog, elapsedForLog, task.label)))
+				return taskResult{}
+			}
+			log.Info(i18n.T("%s 生成失败：%v", taskPrefix(tenantForLog, elapsedForLog, task.label), err))
+			logRemediation(log, taskPrefix(tenantForLog, elapsedForLog, task.label), err)
+			return taskResult{err: err, retryable: client.IsRetryable(err)}
+		}
+		jobID = resp.JobID
+	} else {
+		log.Info(i18n.T("%s 继续跟踪已提交任务（job_id=%s）", taskPrefix(tenantForLog, elapsedForLog, task.label), jobID))
 	}
 	if onJobSubmitted != nil {
-		onJobSubmitted(resp.JobID)
+		onJobSubmitted(jobID)
 	}
+	result := taskResult{jobID: jobID}
 
 	traceWarned := false
 	lastTraceLine := ""
+	// queuedAt 为 generate_queued 事件的 elapsed_ms，用于计算任务在服务端的排队时长。
+	queuedAt := int64(-1)
 	streamCtx, cancelStream := context.WithTimeout(ctx, time.Duration(streamTimeoutSecond)*time.Second)
 	defer cancelStream()
 
 	handleTraceItem := func(item client.JobTraceItem) {
+		opts.events.trace(task, item)
+		if line := renderWorkerTraceLine(item, false); strings.TrimSpace(line) != "" {
+			result.traceTail = appendTraceTail(result.traceTail, client.RedactText(line))
+		}
 		if strings.TrimSpace(item.TenantID) != "" {
 			tenantForLog = item.TenantID
 		}
 		if item.ElapsedMS >= 0 {
 			elapsedForLog = item.ElapsedMS
 		}
-		if opts.Verbose {
-			if shouldSkipVerboseWorkerTrace(item) {
+		if item.Event == "generate_queued" {
+			queuedAt = item.ElapsedMS
+		} else if queuedAt >= 0 && item.ElapsedMS >= 0 {
+			if wait := time.Duration(item.ElapsedMS-queuedAt) * time.Millisecond; wait >= queueBackpressureWait {
+				opts.limiter.backoff(i18n.T("排队 %s", humanDurationShort(wait)))
+			}
+			queuedAt = -1
+		}
+		switch item.Event {
+		case "rules_loaded":
+			result.rulesVersion = stringPayload(item.Payload, "rules_version")
+		case "section_generate_ok":
+			section := stringPayload(item.Payload, "section")
+			if section == "" {
+				section = stringPayload(item.Payload, "step")
+			}
+			if ms := intPayload(item.Payload, "duration_ms"); section != "" && ms > 0 {
+				if result.sectionDurations == nil {
+					result.sectionDurations = map[string]int64{}
+				}
+				result.sectionDurations[section] += int64(ms)
+			}
+		}
+		// 单任务日志（--log-dir）不论是否 --verbose 都记录 worker 事件。
+		if opts.Verbose || opts.LogDir != "" {
+			traced := !shouldSkipVerboseWorkerTrace(item) && opts.traceSampler.keep(task.label+" "+item.Event, item.Level)
+			if !traced && opts.Verbose {
 				return
 			}
-			log.Event("worker_trace", map[string]any{
-				"job_id":     item.JobID,
-				"tenant_id":  item.TenantID,
-				"ts":         item.TS,
-				"elapsed_ms": item.ElapsedMS,
-				"source":     item.Source,
-				"event_name": item.Event,
-				"level":      item.Level,
-				"req_id":     item.ReqID,
-				"payload":    item.Payload,
-				"task":       task.label,
-			})
+			if traced {
+				log.Event("worker_trace", map[string]any{
+					"job_id":     item.JobID,
+					"tenant_id":  item.TenantID,
+					"ts":         item.TS,
+					"elapsed_ms": item.ElapsedMS,
+					"source":     item.Source,
+					"event_name": item.Event,
+					"level":      item.Level,
+					"req_id":     item.ReqID,
+					"payload":    item.Payload,
+					"task":       task.label,
+				})
+			}
 		}
-		msg := renderWorkerTraceLine(item, !opts.Verbose)
+		msg := renderWorkerTraceLine(item, log.Colorize())
 		if strings.TrimSpace(msg) == "" {
 			return
 		}
@@ -335,7 +930,7 @@ func runGenerateTask(
 		log.Info(fmt.Sprintf("%s %s", taskPrefix(tenantForLog, elapsedForLog, task.label), msg))
 	}
 
-	stResp, err := api.JobEvents(streamCtx, ex.AccessToken, resp.JobID, func(ev client.JobEvent) {
+	stResp, err := api.JobEvents(streamCtx, ex.AccessToken, jobID, func(ev client.JobEvent) {
 		switch ev.Type {
 		case "trace":
 			if ev.Trace == nil {
@@ -353,93 +948,280 @@ func runGenerateTask(
 		}
 	})
 	if err != nil {
-		if isContextCanceledErr(err) {
-			log.Info(fmt.Sprintf("%s 已取消", taskPrefix(tenantForLog, elapsedForLog, task.label)))
-			return false
+		if ctx.Err() != nil && client.IsCanceled(err) {
+			log.Info(i18n.T("%s 已取消", taskPrefix(tenantForLog, elapsedForLog, task.label)))
+			return result
 		}
 		if errors.Is(err, context.DeadlineExceeded) {
-			log.Info(fmt.Sprintf("%s 生成失败：SSE 超时", taskPrefix(tenantForLog, elapsedForLog, task.label)))
-			return false
+			log.Info(i18n.T("%s 生成失败：SSE 超时", taskPrefix(tenantForLog, elapsedForLog, task.label)))
+			result.err = i18n.Errorf("SSE 超时: %w", err)
+			result.retryable = true
+			return result
 		}
 		if opts.Verbose {
 			log.Event("worker_trace_error", map[string]any{
-				"job_id": resp.JobID,
+				"job_id": jobID,
 				"error":  err.Error(),
 				"task":   task.label,
 			})
 		} else if !traceWarned {
 			traceWarned = true
-			log.Info(fmt.Sprintf("%s 过程流式接收失败：%v", taskPrefix(tenantForLog, elapsedForLog, task.label), err))
+			log.Info(i18n.T("%s 过程流式接收失败：%v", taskPrefix(tenantForLog, elapsedForLog, task.label), err))
 		}
-		log.Info(fmt.Sprintf("%s 生成失败：%v", taskPrefix(tenantForLog, elapsedForLog, task.label), err))
-		return false
+		log.Info(i18n.T("%s 生成失败：%v", taskPrefix(tenantForLog, elapsedForLog, task.label), err))
+		logRemediation(log, taskPrefix(tenantForLog, elapsedForLog, task.label), err)
+		result.err = err
+		result.retryable = client.IsRetryable(err)
+		return result
 	}
 
 	if stResp.Status == "succeeded" {
-		resData, err := api.Result(ctx, ex.AccessToken, resp.JobID)
+		resData, err := api.Result(ctx, ex.AccessToken, jobID)
 		if err != nil {
-			log.Info(fmt.Sprintf("%s 生成失败：读取结果失败: %v", taskPrefix(tenantForLog, elapsedForLog, task.label), err))
-			return false
+			log.Info(i18n.T("%s 生成失败：读取结果失败: %v", taskPrefix(tenantForLog, elapsedForLog, task.label), err))
+			logRemediation(log, taskPrefix(tenantForLog, elapsedForLog, task.label), err)
+			result.err = i18n.Errorf("读取结果失败: %w", err)
+			result.retryable = client.IsRetryable(err)
+			return result
 		}
-		_, enPath, cnPath, err := output.UniquePair(opts.OutputDir, task.file.Path)
-		if err != nil {
-			log.Info(fmt.Sprintf("%s 生成失败：输出文件名失败: %v", taskPrefix(tenantForLog, elapsedForLog, task.label), err))
-			return false
+		prefix := taskPrefix(tenantForLog, elapsedForLog, task.label)
+		if len(opts.Sections) > 0 {
+			var partial bool
+			resData, partial = applySectionResults(resData, opts.Sections)
+			if partial {
+				log.Info(i18n.T("%s 已按节重新生成：%s", prefix, strings.Join(opts.Sections, ",")))
+			} else {
+				log.Info(i18n.T("%s 服务端不支持按节生成，返回的是完整 listing", prefix))
+			}
 		}
-		if err := os.WriteFile(enPath, []byte(resData.ENMarkdown), 0o644); err != nil {
-			log.Info(fmt.Sprintf("%s 生成失败：写 EN 失败: %v", taskPrefix(tenantForLog, elapsedForLog, task.label), err))
-			return false
+		if opts.normalize.Enabled() {
+			resData.ENMarkdown = listing.Normalize(resData.ENMarkdown, opts.normalize)
+			resData.CNMarkdown = listing.Normalize(resData.CNMarkdown, opts.normalize)
 		}
-		if err := os.WriteFile(cnPath, []byte(resData.CNMarkdown), 0o644); err != nil {
-			log.Info(fmt.Sprintf("%s 生成失败：写 CN 失败: %v", taskPrefix(tenantForLog, elapsedForLog, task.label), err))
-			return false
+		var consistency *listing.ConsistencyReport
+		if opts.ConsistencyReport {
+			report := listing.CheckConsistency(listing.Parse(resData.ENMarkdown), listing.Parse(resData.CNMarkdown))
+			consistency = &report
+			result.inconsistent = !report.Consistent
+			for _, is := range report.Issues {
+				log.Info(i18n.T("%s EN/CN 不一致：%s：%s", prefix, is.Section, is.Message))
+			}
 		}
-		log.Info(fmt.Sprintf("%s EN 已写入：%s", taskPrefix(tenantForLog, elapsedForLog, task.label), mustAbsPath(enPath)))
-		log.Info(fmt.Sprintf("%s CN 已写入：%s", taskPrefix(tenantForLog, elapsedForLog, task.label), mustAbsPath(cnPath)))
-
-		enDocxTargetPath := strings.TrimSuffix(enPath, filepath.Ext(enPath)) + ".docx"
-		enDocxPath, err := convertMarkdownToDocxFunc(ctx, enPath, enDocxTargetPath)
-		if err != nil {
-			log.Info(fmt.Sprintf("%s 生成失败：EN Word 转换失败: %v", taskPrefix(tenantForLog, elapsedForLog, task.label), err))
-			return false
+		if len(opts.bannedWords) > 0 {
+			result.bannedHits = listing.FindBannedWords(resData.ENMarkdown+"\n"+resData.CNMarkdown, opts.bannedWords)
+			if len(result.bannedHits) > 0 {
+				log.Info(i18n.T("%s 命中禁用词：%s", prefix, strings.Join(result.bannedHits, ", ")))
+			}
 		}
-		cnDocxTargetPath := strings.TrimSuffix(cnPath, filepath.Ext(cnPath)) + ".docx"
-		cnDocxPath, err := convertMarkdownToDocxFunc(ctx, cnPath, cnDocxTargetPath)
-		if err != nil {
-			log.Info(fmt.Sprintf("%s 生成失败：CN Word 转换失败: %v", taskPrefix(tenantForLog, elapsedForLog, task.label), err))
-			return false
+		if len(resData.ValidationReport) > 0 {
+			log.Info(i18n.T("%s 校验报告：%s", prefix, validationReportMultiline(resData.ValidationReport)))
 		}
-		log.Info(fmt.Sprintf("%s EN Word 已写入：%s", taskPrefix(tenantForLog, elapsedForLog, task.label), mustAbsPath(enDocxPath)))
-		log.Info(fmt.Sprintf("%s CN Word 已写入：%s", taskPrefix(tenantForLog, elapsedForLog, task.label), mustAbsPath(cnDocxPath)))
-		return true
+		langs := output.NewLangs(resData.Languages)
+		if langs.Primary == "en" {
+			result.misspellings = listing.FindMisspellings(resData.ENMarkdown, opts.spellDict)
+			if len(result.misspellings) > 0 {
+				log.Info(i18n.T("%s 疑似拼写错误：%s", prefix, strings.Join(result.misspellings, ", ")))
+			}
+		}
+		fm, _ := task.file.Frontmatter()
+		if keywords := mergeKeywords(opts.Keywords, input.SplitList(fm["keywords"])...); len(keywords) > 0 {
+			result.keywordCoverage = listing.CheckKeywordCoverage(resData.ENMarkdown, keywords)
+			result.missingKeywords = listing.MissingKeywords(result.keywordCoverage)
+			log.Info(i18n.T("%s 关键词覆盖：%d/%d（%s）", prefix, len(result.keywordCoverage)-len(result.missingKeywords), len(result.keywordCoverage), formatKeywordCoverage(result.keywordCoverage)))
+			if len(result.missingKeywords) > 0 {
+				log.Info(i18n.T("%s 缺失关键词：%s", prefix, strings.Join(result.missingKeywords, ", ")))
+			}
+		}
+		charCounts := map[string][]sectionCount{
+			langs.Primary:   countSections(resData.ENMarkdown, lineLengthRules(resData.ValidationReport)),
+			langs.Secondary: countSections(resData.CNMarkdown, nil),
+		}
+		logSectionCounts(log, prefix, langs.Primary, charCounts[langs.Primary])
+		logSectionCounts(log, prefix, langs.Secondary, charCounts[langs.Secondary])
+		if resData.Usage != nil {
+			log.Info(i18n.T("%s 用量：%s", prefix, formatUsage(*resData.Usage)))
+		}
+		targets := append([]input.RequirementFile{task.file}, task.mirrors...)
+		if opts.Stdout != "" {
+			// --stdout 只把结果写到标准输出，不写任何产物文件。
+			targets = nil
+		}
+		var existing []string
+		for _, f := range targets {
+			paths, err := writeListingOutputs(ctx, log, opts, prefix, f.Path, task.index, resData, docxMetadata{
+				JobID:        jobID,
+				RunID:        opts.runID,
+				RulesVersion: result.rulesVersion,
+				TenantID:     tenantForLog,
+				GeneratedAt:  time.Now().Format(time.RFC3339),
+				CLIVersion:   opts.CLIVersion,
+			})
+			if errors.Is(err, output.ErrExists) {
+				// 已存在的产物属于之前的运行，不改写其附带文件。
+				existing = append(existing, paths...)
+				continue
+			}
+			if err != nil {
+				log.Info(i18n.T("%s 生成失败：%v", prefix, err))
+				result.err = err
+				return result
+			}
+			result.outputs = append(result.outputs, paths...)
+			if consistency != nil || len(resData.ValidationReport) > 0 || len(result.bannedHits) > 0 || len(result.misspellings) > 0 || len(result.keywordCoverage) > 0 || opts.Label != "" || hasSectionCounts(charCounts) {
+				sidecar, err := writeSidecar(paths[0], listingSidecar{
+					InputPath:        mustAbsPath(f.Path),
+					JobID:            jobID,
+					Label:            opts.Label,
+					ValidationReport: resData.ValidationReport,
+					Consistency:      consistency,
+					BannedWords:      result.bannedHits,
+					Misspellings:     result.misspellings,
+					KeywordCoverage:  result.keywordCoverage,
+					CharCounts:       charCounts,
+				})
+				if err != nil {
+					log.Info(fmt.Sprintf("%s %v", prefix, err))
+					continue
+				}
+				result.outputs = append(result.outputs, sidecar)
+			}
+		}
+		result.listing = resData
+		if len(targets) > 0 && len(result.outputs) == 0 && len(existing) > 0 {
+			// 全部产物都已存在：不算新的成功，也不做上传、追加、合并与后处理。
+			result.skipped = true
+			result.outputs = existing
+			return result
+		}
+		if opts.StrictCompliance && len(result.bannedHits) > 0 {
+			log.Info(i18n.T("%s 生成失败：命中禁用词（--strict-compliance）", prefix))
+			result.err = i18n.Errorf("命中禁用词：%s", strings.Join(result.bannedHits, ", "))
+			return result
+		}
+		if opts.Stdout != "" {
+			if err := writeStdoutListing(os.Stdout, opts.Stdout, resData); err != nil {
+				log.Info(i18n.T("%s 生成失败：%v", prefix, err))
+				result.err = err
+				return result
+			}
+		}
+		result.ok = true
+		return result
 	}
 	if stResp.Status == "failed" {
-		log.Info(fmt.Sprintf("%s 生成失败：%s", taskPrefix(tenantForLog, elapsedForLog, task.label), stResp.Error))
-		return false
+		log.Info(i18n.T("%s 生成失败：%s", taskPrefix(tenantForLog, elapsedForLog, task.label), stResp.Error))
+		result.err = errors.New(stResp.Error)
+		result.jobFailed = true
+		result.retryable = client.IsRetryable(result.err)
+		return result
 	}
 	if stResp.Status == "cancelled" {
-		log.Info(fmt.Sprintf("%s 生成已取消", taskPrefix(tenantForLog, elapsedForLog, task.label)))
-		return false
-	}
-	log.Info(fmt.Sprintf("%s 生成失败：SSE 未返回终态", taskPrefix(tenantForLog, elapsedForLog, task.label)))
-	return false
+		log.Info(i18n.T("%s 生成已取消", taskPrefix(tenantForLog, elapsedForLog, task.label)))
+		result.err = i18n.New("任务已被取消")
+		return result
+	}
+	log.Info(i18n.T("%s 生成失败：SSE 未返回终态", taskPrefix(tenantForLog, elapsedForLog, task.label)))
+	result.err = i18n.New("SSE 未返回终态")
+	result.retryable = true
+	return result
 }
 
-func isContextCanceledErr(err error) bool {
-	if err == nil {
-		return false
+func writeListingOutputs(ctx context.Context, log *Logger, opts GenOptions, prefix string, inputPath string, index int, resData client.ResultResp, meta docxMetadata) ([]string, error) {
+	policy, _ := output.ParseConflictPolicy(opts.OnConflict)
+	candidate := 0
+	if opts.Num > 1 || index > 1 {
+		candidate = index
+	}
+	layout, _ := output.ParseLayout(opts.OutLayout)
+	outDir := output.LayoutDir(opts.OutputDir, inputPath, layout, time.Now())
+	langs := output.NewLangs(resData.Languages)
+	enLabel, cnLabel := strings.ToUpper(langs.Primary), strings.ToUpper(langs.Secondary)
+	enPath, cnPath, err := output.Pair(outDir, inputPath, candidate, policy, langs)
+	if errors.Is(err, output.ErrExists) {
+		log.Info(i18n.T("%s 输出已存在，跳过写入：%s", prefix, mustAbsPath(enPath)))
+		return []string{enPath, cnPath}, err
 	}
-	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
-		return true
+	if err != nil {
+		return nil, i18n.Errorf("输出文件名失败: %w", err)
+	}
+	enc, _ := output.ParseEncoding(opts.OutEncoding)
+	nl, _ := output.ParseNewlines(opts.OutNewlines)
+	enMD, cnMD := resData.ENMarkdown, resData.CNMarkdown
+	if opts.ProvenanceHeader {
+		if h := listing.ProvenanceHeader(meta.JobID, meta.RulesVersion, meta.GeneratedAt); h != "" {
+			enMD, cnMD = h+"\n"+enMD, h+"\n"+cnMD
+		}
 	}
-	msg := strings.ToLower(err.Error())
-	if strings.Contains(msg, "interrupt signal received") {
-		return true
+	if err := os.WriteFile(enPath, output.EncodeText(enMD, enc, nl), 0o644); err != nil {
+		return nil, i18n.Errorf("写 %s 失败: %w", enLabel, err)
 	}
-	if strings.Contains(msg, "operation was canceled") || strings.Contains(msg, "operation was cancelled") {
-		return true
+	if err := os.WriteFile(cnPath, output.EncodeText(cnMD, enc, nl), 0o644); err != nil {
+		return nil, i18n.Errorf("写 %s 失败: %w", cnLabel, err)
 	}
-	return false
+	log.Info(i18n.T("%s %s 已写入：%s", prefix, enLabel, mustAbsPath(enPath)))
+	log.Info(i18n.T("%s %s 已写入：%s", prefix, cnLabel, mustAbsPath(cnPath)))
+	paths := []string{enPath, cnPath}
+	if opts.SearchTerms {
+		termsPath, err := writeSearchTerms(log, prefix, enPath, resData.SearchTerms)
+		if err != nil {
+			return nil, err
+		}
+		if termsPath != "" {
+			paths = append(paths, termsPath)
+		}
+	}
+	if opts.SkipDocx {
+		return paths, nil
+	}
+
+	enDocxTargetPath := strings.TrimSuffix(enPath, filepath.Ext(enPath)) + ".docx"
+	enDocxPath, err := convertMarkdownToDocxFunc(ctx, enPath, enDocxTargetPath)
+	if err != nil {
+		return nil, i18n.Errorf("%s Word 转换失败: %w", enLabel, err)
+	}
+	cnDocxTargetPath := strings.TrimSuffix(cnPath, filepath.Ext(cnPath)) + ".docx"
+	cnDocxPath, err := convertMarkdownToDocxFunc(ctx, cnPath, cnDocxTargetPath)
+	if err != nil {
+		return nil, i18n.Errorf("%s Word 转换失败: %w", cnLabel, err)
+	}
+	for _, p := range []string{enDocxPath, cnDocxPath} {
+		if err := setDocxProperties(p, meta); err != nil {
+			log.Info(i18n.T("%s Word 文档属性写入失败：%v", prefix, err))
+		}
+	}
+	log.Info(i18n.T("%s %s Word 已写入：%s", prefix, enLabel, mustAbsPath(enDocxPath)))
+	log.Info(i18n.T("%s %s Word 已写入：%s", prefix, cnLabel, mustAbsPath(cnDocxPath)))
+	return append(paths, enDocxPath, cnDocxPath), nil
+}
+
+// writeSearchTerms 把后台搜索词按 Amazon 字节上限整理后写为 <文件名>_search_terms.txt；
+// 服务端未返回搜索词时只提示，返回空路径。
+func writeSearchTerms(log *Logger, prefix, enPath, terms string) (string, error) {
+	fitted, dropped := listing.FitSearchTerms(terms, listing.SearchTermsByteBudget)
+	if fitted == "" {
+		log.Info(i18n.T("%s 服务端未返回后台搜索词（可能不支持）", prefix))
+		return "", nil
+	}
+	if len(dropped) > 0 {
+		log.Info(i18n.T("%s 后台搜索词超过 %d 字节，已舍弃：%s", prefix, listing.SearchTermsByteBudget, strings.Join(dropped, " ")))
+	}
+	p := strings.TrimSuffix(sidecarPath(enPath), ".json") + "_search_terms.txt"
+	if err := os.WriteFile(p, []byte(fitted+"\n"), 0o644); err != nil {
+		return "", i18n.Errorf("写后台搜索词失败: %w", err)
+	}
+	log.Info(i18n.T("%s 后台搜索词已写入（%d 字节）：%s", prefix, len(fitted), mustAbsPath(p)))
+	return p, nil
+}
+
+// runTimedOut 判断运行是否因 --timeout 到期而中止（而非用户中断）。
+func runTimedOut(ctx context.Context, opts GenOptions) bool {
+	return opts.Timeout > 0 && errors.Is(ctx.Err(), context.DeadlineExceeded)
+}
+
+// traceURLPath 去掉查询参数，使同一接口的追踪事件归为一类采样。
+func traceURLPath(raw string) string {
+	path, _, _ := strings.Cut(raw, "?")
+	return path
 }
 
 func shouldSkipVerboseHTTPTrace(verbose bool, ev client.TraceEvent) bool {
@@ -481,39 +1263,39 @@ func renderWorkerTraceLine(item client.JobTraceItem, colorizeLabel bool) string
 	switch item.Event {
 	case "generate_queued":
 		if strings.TrimSpace(item.JobID) != "" {
-			return fmt.Sprintf("任务已加入队列 %s", item.JobID)
+			return i18n.T("任务已加入队列 %s", item.JobID)
 		}
-		return "任务已加入队列"
+		return i18n.T("任务已加入队列")
 	case "rules_loaded":
 		rulesVersion := stringPayload(item.Payload, "rules_version")
 		workerVersion := stringPayload(item.Payload, "worker_version")
 		if strings.TrimSpace(workerVersion) != "" {
-			return fmt.Sprintf("规则已加载 %s | worker %s", rulesVersion, workerVersion)
+			return i18n.T("规则已加载 %s | worker %s", rulesVersion, workerVersion)
 		}
-		return fmt.Sprintf("规则已加载 %s", rulesVersion)
+		return i18n.T("规则已加载 %s", rulesVersion)
 	case "section_generate_ok":
 		step := stringPayload(item.Payload, "step")
 		if _, ok := judgeRoundOfStep(step); ok {
-			return fmt.Sprintf("%s完成%s", sectionLabel(item.Payload, colorizeLabel), tailDuration(item.Payload, "duration_ms", colorizeLabel))
+			return i18n.T("%s完成%s", sectionLabel(item.Payload, colorizeLabel), tailDuration(item.Payload, "duration_ms", colorizeLabel))
 		}
-		return fmt.Sprintf("%s已生成%s", sectionLabel(item.Payload, colorizeLabel), tailDuration(item.Payload, "duration_ms", colorizeLabel))
+		return i18n.T("%s已生成%s", sectionLabel(item.Payload, colorizeLabel), tailDuration(item.Payload, "duration_ms", colorizeLabel))
 	case "section_sentence_step_ok":
 		label := sectionLabel(item.Payload, colorizeLabel)
 		idx := intPayload(item.Payload, "sentence_index")
 		total := intPayload(item.Payload, "sentence_total")
 		if idx > 0 && total > 0 {
-			return fmt.Sprintf("%s逐句生成（第%d/%d句）完成%s", label, idx, total, tailDuration(item.Payload, "duration_ms", colorizeLabel))
+			return i18n.T("%s逐句生成（第%d/%d句）完成%s", label, idx, total, tailDuration(item.Payload, "duration_ms", colorizeLabel))
 		}
-		return fmt.Sprintf("%s逐句生成完成%s", label, tailDuration(item.Payload, "duration_ms", colorizeLabel))
+		return i18n.T("%s逐句生成完成%s", label, tailDuration(item.Payload, "duration_ms", colorizeLabel))
 	case "section_sentence_step_validate_fail":
 		label := sectionLabel(item.Payload, colorizeLabel)
 		idx := intPayload(item.Payload, "sentence_index")
 		total := intPayload(item.Payload, "sentence_total")
 		errText := shortText(stringPayload(item.Payload, "error"), 140)
 		if idx > 0 && total > 0 {
-			return fmt.Sprintf("%s逐句校验失败（第%d/%d句）：%s", label, idx, total, errText)
+			return i18n.T("%s逐句校验失败（第%d/%d句）：%s", label, idx, total, errText)
 		}
-		return fmt.Sprintf("%s逐句校验失败：%s", label, errText)
+		return i18n.T("%s逐句校验失败：%s", label, errText)
 	case "api_request":
 		// 底层 LLM 调用事件不在普通输出展示；可通过 --verbose 查看 NDJSON 细节。
 		return ""
@@ -526,7 +1308,7 @@ func renderWorkerTraceLine(item client.JobTraceItem, colorizeLabel bool) string
 	case "agent_team_candidate_failed":
 		return ""
 	case "agent_team_ok":
-		return fmt.Sprintf("%s%s生成完成%s", runtimeSectionLabel(item.Payload, colorizeLabel), runtimeCandidateLabel(item.Payload), tailDuration(item.Payload, "latency_ms", colorizeLabel))
+		return i18n.T("%s%s生成完成%s", runtimeSectionLabel(item.Payload, colorizeLabel), runtimeCandidateLabel(item.Payload), tailDuration(item.Payload, "latency_ms", colorizeLabel))
 	case "runtime_candidate_selection":
 		label := runtimeSectionLabel(item.Payload, colorizeLabel)
 		scores := runtimeCandidateScores(item.Payload)
@@ -535,25 +1317,25 @@ func renderWorkerTraceLine(item client.JobTraceItem, colorizeLabel bool) string
 		}
 		selected := intPayload(item.Payload, "selected_candidate_index")
 		if selected > 0 {
-			return fmt.Sprintf("%s 候选评分：%s，已选 #%d%s", label, strings.Join(scores, "，"), selected, tailDuration(item.Payload, "duration_ms", colorizeLabel))
+			return i18n.T("%s 候选评分：%s，已选 #%d%s", label, strings.Join(scores, "，"), selected, tailDuration(item.Payload, "duration_ms", colorizeLabel))
 		}
-		return fmt.Sprintf("%s 候选评分：%s%s", label, strings.Join(scores, "，"), tailDuration(item.Payload, "duration_ms", colorizeLabel))
+		return i18n.T("%s 候选评分：%s%s", label, strings.Join(scores, "，"), tailDuration(item.Payload, "duration_ms", colorizeLabel))
 	case "job_retry_scheduled":
-		return fmt.Sprintf("任务重试计划：第 %d/%d 次失败，准备第 %d 次（等待由队列退避控制）：%s",
+		return i18n.T("任务重试计划：第 %d/%d 次失败，准备第 %d 次（等待由队列退避控制）：%s",
 			intPayload(item.Payload, "attempt"),
 			intPayload(item.Payload, "max_attempts"),
 			intPayload(item.Payload, "next_attempt"),
 			summarizeRetryError(stringPayload(item.Payload, "error")))
 	case "job_succeeded":
-		return fmt.Sprintf("执行完成%s", tailDuration(item.Payload, "duration_ms", colorizeLabel))
+		return i18n.T("执行完成%s", tailDuration(item.Payload, "duration_ms", colorizeLabel))
 	case "job_failed":
-		return fmt.Sprintf("执行失败：%s", shortText(stringPayload(item.Payload, "error"), 120))
+		return i18n.T("执行失败：%s", shortText(stringPayload(item.Payload, "error"), 120))
 	case "job_cancel_requested":
-		return "取消请求已提交"
+		return i18n.T("取消请求已提交")
 	case "job_cancelled":
-		return "任务已取消"
+		return i18n.T("任务已取消")
 	case "generation_ok":
-		return fmt.Sprintf("生成阶段完成%s", tailDuration(item.Payload, "timing_ms", colorizeLabel))
+		return i18n.T("生成阶段完成%s", tailDuration(item.Payload, "timing_ms", colorizeLabel))
 	}
 	return genericWorkerTraceLine(item, colorizeLabel)
 }
@@ -566,20 +1348,20 @@ func genericWorkerTraceLine(item client.JobTraceItem, colorizeLabel bool) string
 	case strings.HasSuffix(item.Event, "_start") && step != "":
 		return ""
 	case strings.Contains(item.Event, "repair_needed"):
-		return fmt.Sprintf("%s规则校验失败：%s", label, errorPreviewMultiline(item.Payload))
+		return i18n.T("%s规则校验失败：%s", label, errorPreviewMultiline(item.Payload))
 	case strings.Contains(item.Event, "validate_fail"):
-		return fmt.Sprintf("%s规则校验失败：%s", label, errorPreviewMultiline(item.Payload))
+		return i18n.T("%s规则校验失败：%s", label, errorPreviewMultiline(item.Payload))
 	case strings.HasSuffix(item.Event, "_repair_ok"):
-		return fmt.Sprintf("%s修复完成", label)
+		return i18n.T("%s修复完成", label)
 	case strings.HasSuffix(item.Event, "_ok") && step != "":
-		return fmt.Sprintf("%s完成%s", label, tailDuration(item.Payload, "duration_ms", colorizeLabel))
+		return i18n.T("%s完成%s", label, tailDuration(item.Payload, "duration_ms", colorizeLabel))
 	case strings.HasSuffix(item.Event, "_failed"):
 		if errText != "" {
-			return fmt.Sprintf("%s失败：%s", eventLabel(item.Event), shortText(errText, 120))
+			return i18n.T("%s失败：%s", eventLabel(item.Event), shortText(errText, 120))
 		}
-		return fmt.Sprintf("%s失败", eventLabel(item.Event))
+		return i18n.T("%s失败", eventLabel(item.Event))
 	case errText != "":
-		return fmt.Sprintf("%s：%s", eventLabel(item.Event), shortText(errText, 120))
+		return i18n.T("%s：%s", eventLabel(item.Event), shortText(errText, 120))
 	default:
 		return ""
 	}
@@ -605,20 +1387,20 @@ func tracePrefix(tenantID string, elapsedMs int64) string {
 
 func stepLabel(step string) string {
 	if step == "" {
-		return "任务步骤"
+		return i18n.T("任务步骤")
 	}
 	if label, ok := judgeRoundStepLabel(step); ok {
 		return label
 	}
 	if strings.HasPrefix(step, "translate_") {
-		return sectionDisplayName(strings.TrimPrefix(step, "translate_")) + "翻译"
+		return i18n.T("%s翻译", sectionDisplayName(strings.TrimPrefix(step, "translate_")))
 	}
 	if idx := strings.Index(step, "_attempt_"); idx > 0 {
 		return stepLabel(step[:idx])
 	}
 	if strings.HasSuffix(step, "_whole_repair") {
 		base := strings.TrimSuffix(step, "_whole_repair")
-		return stepLabel(base) + "整段修复"
+		return i18n.T("%s整段修复", stepLabel(base))
 	}
 	return sectionDisplayName(step)
 }
@@ -637,13 +1419,13 @@ func judgeRoundStepLabel(step string) (string, bool) {
 		return "", false
 	}
 	sectionName := sectionDisplayName(parts[0])
-	return fmt.Sprintf("%s一致性修复（第%d轮）", sectionName, round), true
+	return i18n.T("%s一致性修复（第%d轮）", sectionName, round), true
 }
 
 func sectionDisplayName(token string) string {
 	clean := strings.TrimSpace(strings.ReplaceAll(token, "_", " "))
 	if clean == "" {
-		return "步骤"
+		return i18n.T("步骤")
 	}
 	return clean
 }
@@ -651,7 +1433,7 @@ func sectionDisplayName(token string) string {
 func eventLabel(name string) string {
 	clean := strings.TrimSpace(strings.ReplaceAll(name, "_", " "))
 	if clean == "" {
-		return "事件"
+		return i18n.T("事件")
 	}
 	return clean
 }
@@ -670,7 +1452,7 @@ func sectionLabel(payload map[string]any, colorizeLabel bool) string {
 	}
 	section := stringPayload(payload, "section")
 	if section == "" {
-		return "步骤"
+		return i18n.T("步骤")
 	}
 	return stepLabel(section)
 }
@@ -678,11 +1460,11 @@ func sectionLabel(payload map[string]any, colorizeLabel bool) string {
 func runtimeSectionLabel(payload map[string]any, colorizeLabel bool) string {
 	switch strings.TrimSpace(stringPayload(payload, "section")) {
 	case "title":
-		return colorLabel("标题", colorizeLabel)
+		return colorLabel(i18n.T("标题"), colorizeLabel)
 	case "bullets":
-		return colorLabel("五点描述", colorizeLabel)
+		return colorLabel(i18n.T("五点描述"), colorizeLabel)
 	case "description":
-		return colorLabel("产品描述", colorizeLabel)
+		return colorLabel(i18n.T("产品描述"), colorizeLabel)
 	default:
 		return sectionLabel(payload, colorizeLabel)
 	}
@@ -690,7 +1472,7 @@ func runtimeSectionLabel(payload map[string]any, colorizeLabel bool) string {
 
 func runtimeCandidateLabel(payload map[string]any) string {
 	if candidateIndex := intPayload(payload, "candidate_index"); candidateIndex > 0 {
-		return fmt.Sprintf(" [候选#%d] ", candidateIndex)
+		return i18n.T(" [候选#%d] ", candidateIndex)
 	}
 	step := strings.TrimSpace(stringPayload(payload, "step"))
 	if step == "" {
@@ -704,7 +1486,7 @@ func runtimeCandidateLabel(payload map[string]any) string {
 	if err != nil || candidateIndex <= 0 {
 		return ""
 	}
-	return fmt.Sprintf(" [候选#%d] ", candidateIndex)
+	return i18n.T(" [候选#%d] ", candidateIndex)
 }
 
 func formatBaseLabelWithStep(baseLabel, step string, colorizeLabel bool) string {
@@ -713,13 +1495,13 @@ func formatBaseLabelWithStep(baseLabel, step string, colorizeLabel bool) string
 		return base
 	}
 	if strings.HasPrefix(step, "translate_") {
-		return base + "翻译"
+		return i18n.T("%s翻译", base)
 	}
 	if round, ok := judgeRoundOfStep(step); ok {
-		return fmt.Sprintf("%s一致性修复（第%d轮）", base, round)
+		return i18n.T("%s一致性修复（第%d轮）", base, round)
 	}
 	if strings.HasSuffix(step, "_whole_repair") {
-		return base + "整段修复"
+		return i18n.T("%s整段修复", base)
 	}
 	return base
 }
@@ -786,7 +1568,7 @@ func runtimeCandidateScores(payload map[string]any) []string {
 			continue
 		}
 		if reason := strings.TrimSpace(stringPayload(m, "failure_reason")); reason != "" {
-			scores = append(scores, fmt.Sprintf("#%d失败(%s)", candidateIndex, summarizeCandidateFailure(reason)))
+			scores = append(scores, i18n.T("#%d失败(%s)", candidateIndex, summarizeCandidateFailure(reason)))
 			continue
 		}
 		score := intPayload(m, "score")
@@ -798,13 +1580,13 @@ func runtimeCandidateScores(payload map[string]any) []string {
 func summarizeCandidateFailure(reason string) string {
 	reason = strings.TrimSpace(reason)
 	if reason == "" {
-		return "未知错误"
+		return i18n.T("未知错误")
 	}
 	reason = strings.TrimPrefix(reason, "section agent team validation failed: ")
 	reason = strings.TrimPrefix(reason, "validation failed: ")
 	reason = strings.TrimSpace(reason)
 	if reason == "" {
-		return "未知错误"
+		return i18n.T("未知错误")
 	}
 	parts := splitCandidateFailureReasons(reason)
 	if len(parts) > 1 {
@@ -825,7 +1607,7 @@ func summarizeCandidateFailure(reason string) string {
 func summarizeRetryError(reason string) string {
 	reason = strings.TrimSpace(reason)
 	if reason == "" {
-		return "未知错误"
+		return i18n.T("未知错误")
 	}
 	if strings.Contains(reason, "validation failed") || strings.Contains(reason, "长度不满足约束") || strings.Contains(reason, "关键词") {
 		return summarizeCandidateFailure(reason)
@@ -836,28 +1618,28 @@ func summarizeRetryError(reason string) string {
 func summarizeSingleCandidateFailure(reason string) string {
 	reason = strings.TrimSpace(reason)
 	if reason == "" {
-		return "未知错误"
+		return i18n.T("未知错误")
 	}
 	if matched := lineLengthConstraintPattern.FindStringSubmatch(reason); len(matched) == 7 {
 		actual, _ := strconv.Atoi(matched[2])
 		tolMin, _ := strconv.Atoi(matched[5])
 		tolMax, _ := strconv.Atoi(matched[6])
 		if actual < tolMin {
-			return fmt.Sprintf("第%s条长度不足: %d<%d", matched[1], actual, tolMin)
+			return i18n.T("第%s条长度不足: %d<%d", matched[1], actual, tolMin)
 		}
-		return fmt.Sprintf("第%s条长度超限: %d>%d", matched[1], actual, tolMax)
+		return i18n.T("第%s条长度超限: %d>%d", matched[1], actual, tolMax)
 	}
 	if matched := textLengthConstraintPattern.FindStringSubmatch(reason); len(matched) == 6 {
 		actual, _ := strconv.Atoi(matched[1])
 		tolMin, _ := strconv.Atoi(matched[4])
 		tolMax, _ := strconv.Atoi(matched[5])
 		if actual < tolMin {
-			return fmt.Sprintf("长度不足: %d<%d", actual, tolMin)
+			return i18n.T("长度不足: %d<%d", actual, tolMin)
 		}
-		return fmt.Sprintf("长度超限: %d>%d", actual, tolMax)
+		return i18n.T("长度超限: %d>%d", actual, tolMax)
 	}
 	if matched := keywordOrderPattern.FindStringSubmatch(reason); len(matched) == 3 {
-		return fmt.Sprintf("关键词顺序错误: 第%s个 %s", matched[1], strings.TrimSpace(matched[2]))
+		return i18n.T("关键词顺序错误: 第%s个 %s", matched[1], strings.TrimSpace(matched[2]))
 	}
 	return shortText(formatValidationError(reason), 48)
 }
@@ -890,7 +1672,7 @@ func stringPayload(payload map[string]any, key string) string {
 func firstError(payload map[string]any) string {
 	v, ok := payload["errors"]
 	if !ok || v == nil {
-		return "未知错误"
+		return i18n.T("未知错误")
 	}
 	if arr, ok := v.([]any); ok && len(arr) > 0 {
 		return shortText(fmt.Sprintf("%v", arr[0]), 140)
@@ -936,9 +1718,9 @@ func allErrors(payload map[string]any) []string {
 func errorCountLabel(payload map[string]any) string {
 	errs := allErrors(payload)
 	if len(errs) == 0 {
-		return "1条"
+		return i18n.T("1条")
 	}
-	return fmt.Sprintf("%d条", len(errs))
+	return i18n.T("%d条", len(errs))
 }
 
 func errorPreview(payload map[string]any, max int) string {
@@ -951,7 +1733,7 @@ func errorPreview(payload map[string]any, max int) string {
 		return strings.Join(errs, "；")
 	}
 	head := strings.Join(errs[:max], "；")
-	return fmt.Sprintf("%s；...（其余%d条）", head, len(errs)-max)
+	return i18n.T("%s；...（其余%d条）", head, len(errs)-max)
 }
 
 func errorPreviewMultiline(payload map[string]any) string {
@@ -966,16 +1748,33 @@ func errorPreviewMultiline(payload map[string]any) string {
 	return "\n           " + strings.Join(formatted, "；\n           ")
 }
 
+func formatUsage(u client.Usage) string {
+	text := i18n.T("tokens %d（输入 %d / 输出 %d）", u.Tokens(), u.InputTokens, u.OutputTokens)
+	if u.Credits > 0 {
+		text += i18n.T("，credits %.2f", u.Credits)
+	}
+	return text
+}
+
+// validationReportMultiline 把结果中的 validation_report 按约束格式逐行展示。
+func validationReportMultiline(report []string) string {
+	formatted := make([]string, 0, len(report))
+	for _, item := range report {
+		formatted = append(formatted, formatValidationError(item))
+	}
+	return "\n           " + strings.Join(formatted, "；\n           ")
+}
+
 func formatValidationError(errText string) string {
 	errText = strings.TrimSpace(errText)
 	if errText == "" {
-		return "未知错误"
+		return i18n.T("未知错误")
 	}
 	if matched := lineLengthConstraintPattern.FindStringSubmatch(errText); len(matched) == 7 {
-		return fmt.Sprintf("第%s条长度不满足约束: %s", matched[1], formatLengthConstraintRange(matched[2], matched[3], matched[4], matched[5], matched[6]))
+		return i18n.T("第%s条长度不满足约束: %s", matched[1], formatLengthConstraintRange(matched[2], matched[3], matched[4], matched[5], matched[6]))
 	}
 	if matched := textLengthConstraintPattern.FindStringSubmatch(errText); len(matched) == 6 {
-		return fmt.Sprintf("长度不满足约束: %s", formatLengthConstraintRange(matched[1], matched[2], matched[3], matched[4], matched[5]))
+		return i18n.T("长度不满足约束: %s", formatLengthConstraintRange(matched[1], matched[2], matched[3], matched[4], matched[5]))
 	}
 	return errText
 }
@@ -990,9 +1789,9 @@ func formatLengthConstraintRange(actualStr, ruleMinStr, ruleMaxStr, tolMinStr, t
 		return fmt.Sprintf("%s ? [%s[%s,%s]%s]", actualStr, tolMinStr, ruleMinStr, ruleMaxStr, tolMaxStr)
 	}
 	if actual < tolMin {
-		return fmt.Sprintf("%d < [%d[%d,%d]%d] 低于下限", actual, tolMin, ruleMin, ruleMax, tolMax)
+		return i18n.T("%d < [%d[%d,%d]%d] 低于下限", actual, tolMin, ruleMin, ruleMax, tolMax)
 	}
-	return fmt.Sprintf("[%d[%d,%d]%d] < %d 高于上限", tolMin, ruleMin, ruleMax, tolMax, actual)
+	return i18n.T("[%d[%d,%d]%d] < %d 高于上限", tolMin, ruleMin, ruleMax, tolMax, actual)
 }
 
 func targetsLabel(payload map[string]any) string {
@@ -1060,12 +1859,21 @@ func tailDuration(payload map[string]any, key string, colorize bool) string {
 	return " " + d
 }
 
+// shortText 把 s 截到最多 n 个字符并加 "..."；按 rune 截断，且不会把组合符号、emoji 修饰符与前一个字符拆开。
 func shortText(s string, n int) string {
 	runes := []rune(s)
 	if n <= 0 || len(runes) <= n {
 		return s
 	}
-	return strings.TrimSpace(string(runes[:n])) + "..."
+	return strings.TrimSpace(string(runes[:clusterCut(runes, n)])) + "..."
+}
+
+// clusterCut 把截断位置 n 回退到字符簇起点，避免截断后留下孤立的组合符号或半个 emoji 序列。
+func clusterCut(runes []rune, n int) int {
+	for n > 0 && n < len(runes) && (isZeroWidth(runes[n]) || runes[n-1] == 0x200D) {
+		n--
+	}
+	return n
 }
 
 func humanDurationShort(d time.Duration) string {
@@ -1091,3 +1899,25 @@ func mustAbsPath(p string) string {
 	}
 	return p
 }
+
+// replayKey 为回放模式下本机未配置 KEY 时使用的占位值；录制文件中的 KEY 已隐去，不参与匹配。
+const replayKey = "syl-replay-placeholder-key"
+
+// applyStageTimeouts 先套用 .env 中的阶段超时，再由命令行覆盖；命令行值为 0 表示未指定。
+func applyStageTimeouts(api *client.API, flags map[client.Stage]time.Duration) error {
+	timeouts, err := config.LoadStageTimeouts()
+	if err != nil {
+		return err
+	}
+	for _, stage := range client.Stages {
+		d := timeouts[stage]
+		if v := flags[stage]; v != 0 {
+			if v < 0 {
+				return i18n.Errorf("--%s-timeout 不能为负数：%s", stage, v)
+			}
+			d = v
+		}
+		api.SetStageTimeout(stage, d)
+	}
+	return nil
+}
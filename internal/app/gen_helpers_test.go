@@ -6,8 +6,10 @@ import (
 	"strings"
 	"testing"
 	"time"
+	"unicode/utf8"
 
 	"syl-listing-pro/internal/client"
+	"syl-listing-pro/internal/input"
 )
 
 func TestSkipTraceHelpers(t *testing.T) {
@@ -374,6 +376,12 @@ func TestDurationAndTextHelpers(t *testing.T) {
 	if got := shortText("abc", 0); got != "abc" {
 		t.Fatalf("got=%q", got)
 	}
+	if got := shortText("cafe\u0301 au lait", 4); got != "caf..." {
+		t.Fatalf("combining mark split: got=%q", got)
+	}
+	if got := shortText("ok 👍🏽 done", 4); got != "ok..." || !utf8.ValidString(got) {
+		t.Fatalf("emoji modifier split: got=%q", got)
+	}
 
 	if got := humanDurationShort(1500 * time.Millisecond); got != "2s" {
 		t.Fatalf("got=%q", got)
@@ -398,3 +406,29 @@ func TestDurationAndTextHelpers(t *testing.T) {
 	// Keep current directory stable for the abs path assertion above.
 	_ = os.Chdir(".")
 }
+
+func TestRunIDAndIdempotencyKey(t *testing.T) {
+	now := time.Date(2026, 3, 13, 8, 30, 0, 0, time.UTC)
+	runID := newRunID(now)
+	if !strings.HasPrefix(runID, "20260313-083000-") || len(runID) != len("20060102-150405-")+6 {
+		t.Fatalf("unexpected run id: %q", runID)
+	}
+
+	task := generateTask{file: input.RequirementFile{Path: "a.md", Content: "x"}, index: 1}
+	k1 := idempotencyKey(runID, task)
+	if k1 != idempotencyKey(runID, task) {
+		t.Fatal("idempotency key should be stable for the same task")
+	}
+	task.index = 2
+	if k1 == idempotencyKey(runID, task) {
+		t.Fatal("different candidate index should change key")
+	}
+	task.index = 1
+	if k1 == idempotencyKey(runID+"x", task) {
+		t.Fatal("different run should change key")
+	}
+	other := generateTask{file: input.RequirementFile{Path: "b.md", Content: "x"}, index: 1}
+	if k1 == idempotencyKey(runID, other) {
+		t.Fatal("different input files with identical content should get different keys")
+	}
+}
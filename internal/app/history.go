@@ -0,0 +1,183 @@
+package app
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"syl-listing-pro/internal/i18n"
+	"syl-listing-pro/internal/manifest"
+	"syl-listing-pro/internal/util"
+)
+
+// parseSince 解析 --since：支持 7d 这类按天的写法以及 Go duration（如 12h）。
+func parseSince(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, nil
+	}
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n < 0 {
+			return 0, i18n.Errorf("--since 格式无效: %s", s)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 {
+		return 0, i18n.Errorf("--since 格式无效: %s", s)
+	}
+	return d, nil
+}
+
+// loadRunHistory 按时间倒序读取运行记录；since 大于 0 时只保留该时间段内开始的运行。
+func loadRunHistory(since time.Duration, now time.Time) ([]manifest.Manifest, error) {
+	dir, err := util.DefaultRunsDir()
+	if err != nil {
+		return nil, err
+	}
+	paths, err := manifest.List(dir)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]manifest.Manifest, 0, len(paths))
+	for _, p := range paths {
+		m, err := manifest.Load(p)
+		if err != nil {
+			continue
+		}
+		if since > 0 {
+			started, err := time.Parse(time.RFC3339, m.StartedAt)
+			if err != nil || now.Sub(started) > since {
+				continue
+			}
+		}
+		out = append(out, m)
+	}
+	return out, nil
+}
+
+// filterRunsByLabel 只保留带有指定运行标签的记录；label 为空时不筛选。
+func filterRunsByLabel(runs []manifest.Manifest, label string) []manifest.Manifest {
+	label = strings.TrimSpace(label)
+	if label == "" {
+		return runs
+	}
+	out := runs[:0]
+	for _, m := range runs {
+		if m.Label == label {
+			out = append(out, m)
+		}
+	}
+	return out
+}
+
+// RunHistory 列出本地运行记录；label 非空时只列出该标签的运行。
+func RunHistory(w io.Writer, since string, label string) error {
+	d, err := parseSince(since)
+	if err != nil {
+		return err
+	}
+	runs, err := loadRunHistory(d, time.Now())
+	if err != nil {
+		return err
+	}
+	runs = filterRunsByLabel(runs, label)
+	if len(runs) == 0 {
+		fmt.Fprintln(w, i18n.T("没有运行记录"))
+		return nil
+	}
+	for _, m := range runs {
+		counts := map[string]int{}
+		for _, t := range m.Tasks {
+			counts[t.Status]++
+		}
+		fmt.Fprint(w, i18n.T("%s  开始 %s  耗时 %s  任务 %d：成功 %d，失败 %d，取消 %d，未完成 %d",
+			m.RunID, formatRunTime(m.StartedAt), runDuration(m), len(m.Tasks),
+			counts[manifest.StatusSucceeded], counts[manifest.StatusFailed], counts[manifest.StatusCancelled],
+			counts[manifest.StatusPending]+counts[manifest.StatusSubmitted]))
+		if m.Label != "" {
+			fmt.Fprint(w, i18n.T("  标签 %s", m.Label))
+		}
+		fmt.Fprintln(w)
+	}
+	return nil
+}
+
+// RunHistoryShow 显示一次运行的任务明细。
+func RunHistoryShow(w io.Writer, runID string) error {
+	m, err := loadRunManifest(runID)
+	if err != nil {
+		return err
+	}
+	fmt.Fprint(w, i18n.T("运行编号：%s\n开始：%s\n结束：%s\n输出目录：%s\n", m.RunID, formatRunTime(m.StartedAt), formatRunTime(m.FinishedAt), m.OutputDir))
+	if m.Label != "" {
+		fmt.Fprint(w, i18n.T("标签：%s\n", m.Label))
+	}
+	for _, t := range m.Tasks {
+		label := t.Label
+		if label == "" {
+			label = t.InputPath
+		}
+		if t.Selected {
+			label += i18n.T("（已选定）")
+		}
+		fmt.Fprint(w, i18n.T("\n[%s] %s\n  输入：%s\n", t.Status, label, t.InputPath))
+		if t.JobID != "" {
+			fmt.Fprint(w, i18n.T("  job_id：%s\n", t.JobID))
+		}
+		if t.DurationMs > 0 {
+			fmt.Fprint(w, i18n.T("  耗时：%s\n", humanDurationShort(time.Duration(t.DurationMs)*time.Millisecond)))
+		}
+		if t.Tokens > 0 || t.Credits > 0 {
+			fmt.Fprint(w, i18n.T("  用量：tokens %d，credits %.2f\n", t.Tokens, t.Credits))
+		}
+		for _, out := range t.Outputs {
+			fmt.Fprint(w, i18n.T("  产物：%s\n", out))
+		}
+		for _, out := range t.FinalOutputs {
+			fmt.Fprint(w, i18n.T("  最终稿：%s\n", out))
+		}
+		if t.Error != "" {
+			fmt.Fprint(w, i18n.T("  错误：%s\n", t.Error))
+		}
+	}
+	return nil
+}
+
+func formatRunTime(ts string) string {
+	t, err := time.Parse(time.RFC3339, ts)
+	if err != nil {
+		return "-"
+	}
+	return t.Local().Format("2006-01-02 15:04:05")
+}
+
+func runDuration(m manifest.Manifest) string {
+	start, err1 := time.Parse(time.RFC3339, m.StartedAt)
+	end, err2 := time.Parse(time.RFC3339, m.FinishedAt)
+	if err1 != nil || err2 != nil {
+		return "-"
+	}
+	return humanDurationShort(end.Sub(start))
+}
+
+// RecentRunIDs 按时间倒序返回本地运行编号，供命令行补全使用；出错时返回空。
+func RecentRunIDs() []string {
+	dir, err := util.DefaultRunsDir()
+	if err != nil {
+		return nil
+	}
+	paths, err := manifest.List(dir)
+	if err != nil {
+		return nil
+	}
+	ids := make([]string, 0, len(paths))
+	for _, p := range paths {
+		ids = append(ids, strings.TrimSuffix(filepath.Base(p), ".json"))
+	}
+	return ids
+}
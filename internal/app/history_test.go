@@ -0,0 +1,121 @@
+package app
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"syl-listing-pro/internal/manifest"
+	"syl-listing-pro/internal/util"
+)
+
+func TestParseSince(t *testing.T) {
+	cases := map[string]time.Duration{"": 0, "7d": 7 * 24 * time.Hour, "12h": 12 * time.Hour}
+	for in, want := range cases {
+		got, err := parseSince(in)
+		if err != nil || got != want {
+			t.Fatalf("parseSince(%q)=%v,%v want %v", in, got, err, want)
+		}
+	}
+	for _, bad := range []string{"xd", "-1d", "soon"} {
+		if _, err := parseSince(bad); err == nil {
+			t.Fatalf("parseSince(%q) expected error", bad)
+		}
+	}
+}
+
+func writeHistoryManifest(t *testing.T, m manifest.Manifest) {
+	t.Helper()
+	dir, err := util.DefaultRunsDir()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cp, err := manifest.Create(dir, m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cp.Finish(mustParseRFC3339(t, m.StartedAt).Add(90 * time.Second)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func mustParseRFC3339(t *testing.T, s string) time.Time {
+	t.Helper()
+	v, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
+
+func TestRunHistory(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	now := time.Now().UTC()
+	writeHistoryManifest(t, manifest.Manifest{
+		RunID:     "20200101-000000-aaaaaa",
+		StartedAt: now.Add(-30 * 24 * time.Hour).Format(time.RFC3339),
+		Tasks:     []manifest.Task{{Key: "a#1", InputPath: "/in/a.md", Status: manifest.StatusSucceeded}},
+	})
+	writeHistoryManifest(t, manifest.Manifest{
+		RunID:     "20990101-000000-bbbbbb",
+		StartedAt: now.Add(-time.Hour).Format(time.RFC3339),
+		Label:     "spring-launch",
+		Tasks: []manifest.Task{
+			{Key: "b#1", InputPath: "/in/b.md", Label: "b.md", Status: manifest.StatusSucceeded, JobID: "job_b", DurationMs: 5000, Outputs: []string{"/out/b_en.md"}},
+			{Key: "c#1", InputPath: "/in/c.md", Status: manifest.StatusFailed, Error: "engine failed"},
+		},
+	})
+
+	var buf bytes.Buffer
+	if err := RunHistory(&buf, "", ""); err != nil {
+		t.Fatalf("RunHistory error: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 || !strings.HasPrefix(lines[0], "20990101-000000-bbbbbb") || !strings.Contains(lines[0], "任务 2：成功 1，失败 1") || !strings.Contains(lines[0], "耗时 1m30s") {
+		t.Fatalf("unexpected history:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	if err := RunHistory(&buf, "7d", ""); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(buf.String(), "20200101-000000-aaaaaa") || !strings.Contains(buf.String(), "20990101-000000-bbbbbb") {
+		t.Fatalf("--since not applied:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	if err := RunHistory(&buf, "", "spring-launch"); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(buf.String(), "20200101-000000-aaaaaa") || !strings.Contains(buf.String(), "标签 spring-launch") {
+		t.Fatalf("--label not applied:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	if err := RunHistoryShow(&buf, "20990101-000000-bbbbbb"); err != nil {
+		t.Fatalf("RunHistoryShow error: %v", err)
+	}
+	for _, want := range []string{"标签：spring-launch", "[succeeded] b.md", "job_id：job_b", "耗时：5s", "产物：/out/b_en.md", "[failed] /in/c.md", "错误：engine failed"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Fatalf("missing %q in:\n%s", want, buf.String())
+		}
+	}
+	if err := RunHistoryShow(&buf, "../etc"); err == nil {
+		t.Fatal("expected invalid run id error")
+	}
+}
+
+func TestRecentRunIDs(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	if ids := RecentRunIDs(); len(ids) != 0 {
+		t.Fatalf("unexpected ids: %v", ids)
+	}
+	now := time.Now().UTC().Format(time.RFC3339)
+	writeHistoryManifest(t, manifest.Manifest{RunID: "20260101-000000-aaaaaa", StartedAt: now})
+	writeHistoryManifest(t, manifest.Manifest{RunID: "20260102-000000-bbbbbb", StartedAt: now})
+	ids := RecentRunIDs()
+	if strings.Join(ids, ",") != "20260102-000000-bbbbbb,20260101-000000-aaaaaa" {
+		t.Fatalf("unexpected ids: %v", ids)
+	}
+}
@@ -0,0 +1,99 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"syl-listing-pro/internal/amazon"
+	"syl-listing-pro/internal/config"
+	"syl-listing-pro/internal/i18n"
+)
+
+// ImportASINOptions 为 import-asin 的参数。
+type ImportASINOptions struct {
+	ASIN string
+	// From 非空时从该商品详情页 HTML 文件读取（- 表示标准输入），不访问 SP-API。
+	From string
+	// OutputDir 为需求文件写入目录，文件名为 <ASIN>.md。
+	OutputDir string
+	// Force 为 true 时覆盖已存在的需求文件。
+	Force bool
+}
+
+// RunImportASIN 读取亚马逊上已有的 listing，写为需求 Markdown 骨架 <ASIN>.md，用于改写现有 listing。
+func RunImportASIN(ctx context.Context, w io.Writer, stdin io.Reader, opts ImportASINOptions) error {
+	asin, err := amazon.NormalizeASIN(opts.ASIN)
+	if err != nil {
+		return err
+	}
+	var l amazon.Listing
+	if strings.TrimSpace(opts.From) != "" {
+		l, err = importFromHTML(opts.From, stdin)
+		if err != nil {
+			return err
+		}
+		if l.ASIN != "" && l.ASIN != asin {
+			fmt.Fprintln(w, i18n.T("注意：页面中的 ASIN 为 %s，与指定的 %s 不一致", l.ASIN, asin))
+		}
+		l.ASIN = asin
+	} else {
+		cfg, err := config.LoadSPAPIConfig()
+		if errors.Is(err, amazon.ErrSPAPINotConfigured) {
+			return i18n.Errorf("未配置 SP-API 凭据（.env 中 SYL_SPAPI_CLIENT_ID、SYL_SPAPI_CLIENT_SECRET、SYL_SPAPI_REFRESH_TOKEN），可改用 --from <商品页.html>")
+		}
+		if err != nil {
+			return err
+		}
+		api, err := amazon.NewSPAPI(cfg)
+		if err != nil {
+			return err
+		}
+		if l, err = api.FetchListing(ctx, asin); err != nil {
+			return err
+		}
+	}
+
+	outDir := opts.OutputDir
+	if outDir == "" {
+		outDir = "."
+	}
+	if err := os.MkdirAll(outDir, 0o755); err != nil {
+		return err
+	}
+	path := filepath.Join(outDir, asin+".md")
+	if _, err := os.Stat(path); err == nil && !opts.Force {
+		return i18n.Errorf("需求文件已存在：%s（可加 --force 覆盖）", mustAbsPath(path))
+	}
+	if err := os.WriteFile(path, []byte(l.RequirementMarkdown()), 0o644); err != nil {
+		return i18n.Errorf("写需求文件失败: %w", err)
+	}
+	fmt.Fprintln(w, i18n.T("已导入 %s：标题%s，五点 %d 条，描述%s", asin, presence(l.Title), len(l.Bullets), presence(l.Description)))
+	fmt.Fprintln(w, i18n.T("需求文件：%s（填写“改写要求”后即可生成）", mustAbsPath(path)))
+	return nil
+}
+
+func importFromHTML(from string, stdin io.Reader) (amazon.Listing, error) {
+	var b []byte
+	var err error
+	if from == "-" {
+		b, err = io.ReadAll(stdin)
+	} else {
+		b, err = os.ReadFile(from)
+	}
+	if err != nil {
+		return amazon.Listing{}, i18n.Errorf("读取商品页失败: %w", err)
+	}
+	return amazon.ParseProductHTML(string(b))
+}
+
+func presence(s string) string {
+	if strings.TrimSpace(s) == "" {
+		return i18n.T("无")
+	}
+	return i18n.T("有")
+}
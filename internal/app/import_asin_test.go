@@ -0,0 +1,46 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunImportASIN_FromHTML(t *testing.T) {
+	prepareRunGenHome(t)
+	page := `<span id="productTitle">Yoga Mat</span><div id="feature-bullets"><ul><li>Non-slip</li></ul></div>`
+	outDir := t.TempDir()
+	var buf bytes.Buffer
+	opts := ImportASINOptions{ASIN: "b0test1234", From: "-", OutputDir: outDir}
+	if err := RunImportASIN(context.Background(), &buf, strings.NewReader(page), opts); err != nil {
+		t.Fatalf("RunImportASIN error: %v", err)
+	}
+	b, err := os.ReadFile(filepath.Join(outDir, "B0TEST1234.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(b), "asin: B0TEST1234") || !strings.Contains(string(b), "- Non-slip") {
+		t.Fatalf("unexpected skeleton:\n%s", b)
+	}
+	if !strings.Contains(buf.String(), "五点 1 条") {
+		t.Fatalf("unexpected output: %s", buf.String())
+	}
+	if err := RunImportASIN(context.Background(), &buf, strings.NewReader(page), opts); err == nil || !strings.Contains(err.Error(), "--force") {
+		t.Fatalf("expected existing-file error, got %v", err)
+	}
+	opts.Force = true
+	if err := RunImportASIN(context.Background(), &buf, strings.NewReader(page), opts); err != nil {
+		t.Fatalf("--force should overwrite: %v", err)
+	}
+}
+
+func TestRunImportASIN_RequiresSPAPIConfig(t *testing.T) {
+	prepareRunGenHome(t)
+	err := RunImportASIN(context.Background(), &bytes.Buffer{}, nil, ImportASINOptions{ASIN: "B0TEST1234", OutputDir: t.TempDir()})
+	if err == nil || !strings.Contains(err.Error(), "SYL_SPAPI_CLIENT_ID") {
+		t.Fatalf("unexpected err: %v", err)
+	}
+}
@@ -0,0 +1,83 @@
+package app
+
+import (
+	"sync"
+	"time"
+
+	"syl-listing-pro/internal/i18n"
+	"syl-listing-pro/internal/input"
+	"syl-listing-pro/internal/ledger"
+)
+
+// filterProcessedFiles 去掉处理记录中已存在（同一路径且内容未变化）的需求文件。
+func filterProcessedFiles(files []input.RequirementFile, led *ledger.Ledger) ([]input.RequirementFile, []input.RequirementFile) {
+	fresh := make([]input.RequirementFile, 0, len(files))
+	var skipped []input.RequirementFile
+	for _, f := range files {
+		if _, ok := led.Lookup(ledger.Key(f.Path, f.ContentHash())); ok {
+			skipped = append(skipped, f)
+			continue
+		}
+		fresh = append(fresh, f)
+	}
+	return fresh, skipped
+}
+
+// incrementalTracker 在同一需求文件的全部任务都成功后写入处理记录；内容相同的其他输入（mirrors）一并记录。
+type incrementalTracker struct {
+	mu        sync.Mutex
+	log       *Logger
+	ledger    *ledger.Ledger
+	remaining map[string]int
+	failed    map[string]bool
+	outputs   map[string][]string
+	inputs    map[string][]string
+}
+
+func newIncrementalTracker(log *Logger, led *ledger.Ledger, tasks []generateTask) *incrementalTracker {
+	t := &incrementalTracker{
+		log:       log,
+		ledger:    led,
+		remaining: make(map[string]int),
+		failed:    make(map[string]bool),
+		outputs:   make(map[string][]string),
+		inputs:    make(map[string][]string),
+	}
+	for _, task := range tasks {
+		key := ledger.Key(task.file.Path, task.file.ContentHash())
+		t.remaining[key]++
+		if _, ok := t.inputs[key]; !ok {
+			t.inputs[key] = []string{task.file.Path}
+			for _, m := range task.mirrors {
+				t.inputs[key] = append(t.inputs[key], m.Path)
+			}
+		}
+	}
+	return t
+}
+
+func (t *incrementalTracker) done(task generateTask, result taskResult) {
+	hash := task.file.ContentHash()
+	key := ledger.Key(task.file.Path, hash)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.remaining[key]--
+	if !result.ok {
+		t.failed[key] = true
+	}
+	t.outputs[key] = append(t.outputs[key], result.outputs...)
+	if t.remaining[key] > 0 || t.failed[key] {
+		return
+	}
+	now := time.Now().Format(time.RFC3339)
+	for _, p := range t.inputs[key] {
+		t.ledger.Record(ledger.Key(p, hash), ledger.Entry{
+			InputPath:   p,
+			Outputs:     t.outputs[key],
+			GeneratedAt: now,
+		})
+	}
+	if err := t.ledger.Save(); err != nil {
+		t.log.Info(i18n.T("处理记录写入失败：%v", err))
+	}
+}
@@ -0,0 +1,32 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"syl-listing-pro/internal/listing"
+)
+
+// mergeKeywords 合并关键词，去空白并按首次出现的顺序去重（不区分大小写）。
+func mergeKeywords(base []string, extra ...string) []string {
+	var out []string
+	seen := map[string]bool{}
+	for _, k := range append(append([]string{}, base...), extra...) {
+		k = strings.TrimSpace(k)
+		if k == "" || seen[strings.ToLower(k)] {
+			continue
+		}
+		seen[strings.ToLower(k)] = true
+		out = append(out, k)
+	}
+	return out
+}
+
+// formatKeywordCoverage 把覆盖结果格式化为 "yoga mat 3, eco 0" 这样的单行摘要。
+func formatKeywordCoverage(coverage []listing.KeywordCoverage) string {
+	parts := make([]string, 0, len(coverage))
+	for _, c := range coverage {
+		parts = append(parts, fmt.Sprintf("%s %d", c.Keyword, c.Total()))
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,86 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"syl-listing-pro/internal/client"
+)
+
+func TestMergeKeywords(t *testing.T) {
+	got := mergeKeywords([]string{"Yoga Mat", " ", "non-slip"}, "yoga mat", "eco")
+	if strings.Join(got, "|") != "Yoga Mat|non-slip|eco" {
+		t.Fatalf("got=%v", got)
+	}
+}
+
+func TestRunGen_KeywordsFromFlagAndFrontmatter(t *testing.T) {
+	prepareRunGenHome(t)
+	inner := newRunGenFastSuccessServer(t)
+	defer inner.Close()
+	var mu sync.Mutex
+	var reqs []client.GenerateReq
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost && r.URL.Path == "/v1/generate" {
+			b, _ := io.ReadAll(r.Body)
+			var req client.GenerateReq
+			_ = json.Unmarshal(b, &req)
+			mu.Lock()
+			reqs = append(reqs, req)
+			mu.Unlock()
+			r.Body = io.NopCloser(bytes.NewReader(b))
+		}
+		inner.Config.Handler.ServeHTTP(w, r)
+	}))
+	defer ts.Close()
+	oldBase := workerBaseURL
+	oldTimeout := streamTimeoutSecond
+	workerBaseURL = ts.URL
+	streamTimeoutSecond = 5
+	defer func() {
+		workerBaseURL = oldBase
+		streamTimeoutSecond = oldTimeout
+	}()
+
+	inputPath := filepath.Join(t.TempDir(), "req.md")
+	if err := os.WriteFile(inputPath, []byte("---\nkeywords: eco, yoga mat\n---\n#MARK\ncontent\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	outDir := t.TempDir()
+	out, err := captureStdoutRun(t, func() error {
+		return RunGen(context.Background(), GenOptions{OutputDir: outDir, Inputs: []string{inputPath}, SkipDocx: true, Keywords: []string{"Yoga Mat", "non-slip"}})
+	})
+	if err != nil {
+		t.Fatalf("RunGen error: %v", err)
+	}
+	if len(reqs) != 1 {
+		t.Fatalf("expected one generate request, got %d", len(reqs))
+	}
+	if got := strings.Join(reqs[0].Keywords, "|"); got != "Yoga Mat|non-slip|eco" {
+		t.Fatalf("keywords=%s", got)
+	}
+	if reqs[0].InputMarkdown != "#MARK\ncontent\n" {
+		t.Fatalf("frontmatter not stripped: %q", reqs[0].InputMarkdown)
+	}
+	if !strings.Contains(out, "缺失关键词：Yoga Mat, non-slip, eco") || !strings.Contains(out, "关键词覆盖：1 个任务缺失关键词") {
+		t.Fatalf("missing keyword report not logged: %s", out)
+	}
+	sidecars, _ := filepath.Glob(filepath.Join(outDir, "*.json"))
+	if len(sidecars) != 1 {
+		t.Fatalf("expected one sidecar, got %v", sidecars)
+	}
+	var sc listingSidecar
+	b, _ := os.ReadFile(sidecars[0])
+	if err := json.Unmarshal(b, &sc); err != nil || len(sc.KeywordCoverage) != 3 || sc.KeywordCoverage[0].Keyword != "Yoga Mat" {
+		t.Fatalf("sidecar keyword coverage=%+v err=%v", sc.KeywordCoverage, err)
+	}
+}
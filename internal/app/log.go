@@ -1,6 +1,8 @@
 package app
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -9,18 +11,36 @@ import (
 	"strings"
 	"sync"
 	"time"
+
+	"syl-listing-pro/internal/client"
+	"syl-listing-pro/internal/i18n"
 )
 
 type Logger struct {
 	verbose bool
-	file    *os.File
-	mu      sync.Mutex
+	// color 为 false 时写到终端的内容也去掉 ANSI 颜色码。
+	color bool
+	// timestamps 为 true 时普通日志每行前加本地时间。
+	timestamps bool
+	// jsonOut 为 true 时（--log-format json）普通日志也以 NDJSON 输出，且不依赖 --verbose。
+	jsonOut bool
+	// stderr 为 true 时终端输出写到标准错误（--stdout 模式下标准输出只留给结果）。
+	stderr bool
+	now    func() time.Time
+	file   *os.File
+	mu     sync.Mutex
+	// secrets 为需要从日志中隐去的 KEY、访问令牌原文。
+	secrets []string
+	// parent 非空表示这是 ForTask 创建的单任务日志：file 写普通日志，trace 写 NDJSON，
+	// 同时把每条日志转交给 parent。
+	parent *Logger
+	trace  *os.File
 }
 
 var ansiEscape = regexp.MustCompile(`\x1b\[[0-9;]*m`)
 
 func NewLogger(verbose bool, logFile string) (*Logger, error) {
-	l := &Logger{verbose: verbose}
+	l := &Logger{verbose: verbose, color: autoColor(), now: time.Now}
 	if strings.TrimSpace(logFile) == "" {
 		return l, nil
 	}
@@ -38,42 +58,200 @@ func NewLogger(verbose bool, logFile string) (*Logger, error) {
 	return l, nil
 }
 
+// SetColorMode 设置彩色输出：auto 在设置了 NO_COLOR 或标准输出不是终端时关闭颜色，
+// always 强制开启，never 始终关闭。
+func (l *Logger) SetColorMode(mode string) error {
+	switch strings.ToLower(strings.TrimSpace(mode)) {
+	case "", "auto":
+		l.color = autoColor()
+	case "always":
+		l.color = true
+	case "never":
+		l.color = false
+	default:
+		return i18n.Errorf("--color 仅支持 auto、always、never：%s", mode)
+	}
+	return nil
+}
+
+// SetLogFormat 设置标准输出格式：text（默认）为人类可读进度，json 把每条日志与事件都输出为 NDJSON。
+func (l *Logger) SetLogFormat(format string) error {
+	switch strings.ToLower(strings.TrimSpace(format)) {
+	case "", "text":
+		l.jsonOut = false
+	case "json":
+		l.jsonOut = true
+	default:
+		return i18n.Errorf("--log-format 仅支持 text、json：%s", format)
+	}
+	return nil
+}
+
+// SetTimestamps 控制普通日志是否带墙钟时间前缀（HH:MM:SS）。
+func (l *Logger) SetTimestamps(enabled bool) {
+	l.timestamps = enabled
+}
+
+// SetStderr 控制终端输出是否改写到标准错误。
+func (l *Logger) SetStderr(enabled bool) {
+	l.stderr = enabled
+}
+
+// Colorize 表示普通日志是否应当带颜色。
+func (l *Logger) Colorize() bool {
+	if l.parent != nil {
+		return l.parent.Colorize()
+	}
+	return l.color && !l.verbose && !l.jsonOut
+}
+
+func autoColor() bool {
+	if _, ok := os.LookupEnv("NO_COLOR"); ok {
+		return false
+	}
+	info, err := os.Stdout.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
 func (l *Logger) Close() error {
 	l.mu.Lock()
 	defer l.mu.Unlock()
-	if l.file == nil {
-		return nil
+	var err error
+	if l.file != nil {
+		err = l.file.Close()
+		l.file = nil
+	}
+	if l.trace != nil {
+		if traceErr := l.trace.Close(); err == nil {
+			err = traceErr
+		}
+		l.trace = nil
 	}
-	err := l.file.Close()
-	l.file = nil
 	return err
 }
 
+// AddSecret 登记一个不得出现在终端与日志文件中的凭据；Bearer 令牌与 JSON 令牌字段始终会被隐去。
+func (l *Logger) AddSecret(secret string) {
+	secret = strings.TrimSpace(secret)
+	if secret == "" {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.secrets = append(l.secrets, secret)
+}
+
+// ForTask 在 dir 下创建单任务日志：<name>.log 为带时间的普通日志，<name>.ndjson 为该任务的全部事件
+// （不论是否 --verbose），写入的内容同时照常输出到 l。
+func (l *Logger) ForTask(dir, name string) (*Logger, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	base := filepath.Join(dir, name)
+	f, err := os.OpenFile(base+".log", os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	trace, err := os.OpenFile(base+".ndjson", os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	return &Logger{verbose: l.verbose, now: l.now, file: f, trace: trace, parent: l}, nil
+}
+
+func (l *Logger) writeTaskFile(f *os.File, line string) {
+	root := l.parent
+	root.mu.Lock()
+	line = client.RedactText(ansiEscape.ReplaceAllString(line, ""), root.secrets...)
+	root.mu.Unlock()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if f != nil {
+		_, _ = f.WriteString(line + "\n")
+	}
+}
+
 func (l *Logger) writeLine(line string) {
 	l.mu.Lock()
 	defer l.mu.Unlock()
-	fmt.Println(line)
+	line = client.RedactText(line, l.secrets...)
+	if !l.color {
+		line = ansiEscape.ReplaceAllString(line, "")
+	}
+	if l.stderr {
+		fmt.Fprintln(os.Stderr, line)
+	} else {
+		fmt.Println(line)
+	}
 	if l.file != nil {
 		_, _ = l.file.WriteString(ansiEscape.ReplaceAllString(line, "") + "\n")
 	}
 }
 
 func (l *Logger) Info(msg string) {
-	if l.verbose {
+	if l.parent != nil {
+		l.writeTaskFile(l.file, l.now().Format("15:04:05")+" "+msg)
+		l.parent.Info(msg)
+		return
+	}
+	if l.verbose || l.jsonOut {
 		l.Event("info", map[string]any{"message": msg})
 		return
 	}
+	if l.timestamps {
+		msg = l.now().Format("15:04:05") + " " + msg
+	}
 	l.writeLine(msg)
 }
 
 func (l *Logger) Event(event string, fields map[string]any) {
-	if !l.verbose {
+	if l.parent != nil {
+		l.writeTaskFile(l.trace, eventLine(event, fields))
+		l.parent.Event(event, fields)
+		return
+	}
+	if !l.verbose && !l.jsonOut {
 		return
 	}
+	l.writeLine(eventLine(event, fields))
+}
+
+func eventLine(event string, fields map[string]any) string {
 	m := map[string]any{"ts": time.Now().Format(time.RFC3339Nano), "event": event}
 	for k, v := range fields {
 		m[k] = v
 	}
 	b, _ := json.Marshal(m)
-	l.writeLine(string(b))
+	return string(b)
+}
+
+// openTaskLog 在设置了 --log-dir 时为任务创建单任务日志，否则（或创建失败时）返回 log 本身。
+func openTaskLog(log *Logger, logDir, runID string, task generateTask) *Logger {
+	if strings.TrimSpace(logDir) == "" {
+		return log
+	}
+	tl, err := log.ForTask(filepath.Join(logDir, runID), taskLogName(task))
+	if err != nil {
+		log.Info(i18n.T("%s 任务日志创建失败：%v", taskPrefix("", 0, task.label), err))
+		return log
+	}
+	return tl
+}
+
+// taskLogName 返回单任务日志的文件名（不含扩展名）：<文件名>_<序号>_<目录哈希>。
+// 目录哈希取输入所在目录绝对路径的 SHA-256 前 6 位，不同目录下的同名输入不会写进同一份日志。
+func taskLogName(task generateTask) string {
+	name := strings.TrimSuffix(filepath.Base(task.file.Path), filepath.Ext(task.file.Path))
+	sum := sha256.Sum256([]byte(filepath.Dir(mustAbsPath(task.file.Path))))
+	return fmt.Sprintf("%s_%d_%s", name, task.index, hex.EncodeToString(sum[:])[:6])
+}
+
+func closeTaskLog(log *Logger) {
+	if log.parent != nil {
+		_ = log.Close()
+	}
 }
@@ -1,12 +1,16 @@
 package app
 
 import (
+	"context"
 	"encoding/json"
 	"io"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
+
+	"syl-listing-pro/internal/input"
 )
 
 func captureStdout(t *testing.T, fn func()) string {
@@ -96,3 +100,177 @@ func TestLogger_InfoVerboseUsesEvent(t *testing.T) {
 		t.Fatalf("unexpected verbose info output: %q", out)
 	}
 }
+
+func TestLogger_ColorMode(t *testing.T) {
+	lg, err := NewLogger(false, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := lg.SetColorMode("never"); err != nil {
+		t.Fatal(err)
+	}
+	if out := captureStdout(t, func() { lg.Info("\x1b[92m标题\x1b[0m完成") }); out != "标题完成\n" {
+		t.Fatalf("never should strip ansi: %q", out)
+	}
+	if err := lg.SetColorMode("always"); err != nil {
+		t.Fatal(err)
+	}
+	if !lg.Colorize() {
+		t.Fatal("always should colorize")
+	}
+	if out := captureStdout(t, func() { lg.Info("\x1b[92m标题\x1b[0m") }); !strings.Contains(out, "\x1b[92m") {
+		t.Fatalf("always should keep ansi: %q", out)
+	}
+	t.Setenv("NO_COLOR", "1")
+	if err := lg.SetColorMode("auto"); err != nil || lg.Colorize() {
+		t.Fatalf("NO_COLOR should disable color: err=%v", err)
+	}
+	if err := lg.SetColorMode("rainbow"); err == nil {
+		t.Fatal("expected invalid mode error")
+	}
+}
+
+func TestLogger_Timestamps(t *testing.T) {
+	lg, err := NewLogger(false, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = lg.SetColorMode("never")
+	lg.now = func() time.Time { return time.Date(2026, 1, 2, 9, 8, 7, 0, time.Local) }
+	lg.SetTimestamps(true)
+	if out := captureStdout(t, func() { lg.Info("任务完成") }); out != "09:08:07 任务完成\n" {
+		t.Fatalf("unexpected output: %q", out)
+	}
+}
+
+func TestLogger_RedactsSecrets(t *testing.T) {
+	logPath := filepath.Join(t.TempDir(), "run.log")
+	lg, err := NewLogger(true, logPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer lg.Close()
+	lg.AddSecret("syl_live_secret_key")
+	out := captureStdout(t, func() {
+		lg.Event("worker_http_response", map[string]any{
+			"request":  "Authorization: Bearer at_123.abc",
+			"response": `{"access_token":"at_123.abc","tenant_id":"demo"}`,
+			"error":    "bad key syl_live_secret_key",
+		})
+	})
+	b, _ := os.ReadFile(logPath)
+	for _, text := range []string{out, string(b)} {
+		if strings.Contains(text, "at_123") || strings.Contains(text, "syl_live_secret_key") {
+			t.Fatalf("secret leaked: %s", text)
+		}
+		if !strings.Contains(text, "[REDACTED]") || !strings.Contains(text, "demo") {
+			t.Fatalf("unexpected redaction: %s", text)
+		}
+	}
+}
+
+func TestTraceSampler(t *testing.T) {
+	s := newTraceSampler(3)
+	var kept int
+	for i := 0; i < 7; i++ {
+		if s.keep("task section_generate_ok", "info") {
+			kept++
+		}
+	}
+	if kept != 3 {
+		t.Fatalf("kept=%d want 3", kept)
+	}
+	if !s.keep("task section_generate_ok", "error") {
+		t.Fatal("errors must always be kept")
+	}
+	if !newTraceSampler(0).keep("x", "") {
+		t.Fatal("sampling disabled should keep everything")
+	}
+}
+
+func TestRunGen_LogDirWritesPerTaskLogs(t *testing.T) {
+	prepareRunGenHome(t)
+
+	ts := newRunGenFastSuccessServer(t)
+	defer ts.Close()
+	oldBase, oldTimeout := workerBaseURL, streamTimeoutSecond
+	workerBaseURL = ts.URL
+	streamTimeoutSecond = 5
+	defer func() { workerBaseURL, streamTimeoutSecond = oldBase, oldTimeout }()
+
+	dir := t.TempDir()
+	var inputs []string
+	for _, name := range []string{"a.md", "b.md"} {
+		p := filepath.Join(dir, name)
+		if err := os.WriteFile(p, []byte("# "+name), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		inputs = append(inputs, p)
+	}
+	logDir := t.TempDir()
+	if _, err := captureStdoutRun(t, func() error {
+		return RunGen(context.Background(), GenOptions{OutputDir: t.TempDir(), Inputs: inputs, SkipDocx: true, LogDir: logDir})
+	}); err != nil {
+		t.Fatalf("RunGen error: %v", err)
+	}
+	logs, _ := filepath.Glob(filepath.Join(logDir, "*", "a_1_*.log"))
+	traces, _ := filepath.Glob(filepath.Join(logDir, "*", "a_1_*.ndjson"))
+	if len(logs) != 1 || len(traces) != 1 {
+		t.Fatalf("missing per-task logs: %v %v", logs, traces)
+	}
+	human, _ := os.ReadFile(logs[0])
+	if !strings.Contains(string(human), "[a.md]") || strings.Contains(string(human), "[b.md]") {
+		t.Fatalf("unexpected task log:\n%s", human)
+	}
+	events, _ := os.ReadFile(traces[0])
+	for _, want := range []string{`"event":"job_submitted"`, `"event_name":"rules_loaded"`} {
+		if !strings.Contains(string(events), want) {
+			t.Fatalf("missing %s in:\n%s", want, events)
+		}
+	}
+}
+
+func TestTaskLogName_SeparatesSameNameInDifferentDirs(t *testing.T) {
+	dir := t.TempDir()
+	a := generateTask{file: input.RequirementFile{Path: filepath.Join(dir, "a", "req.md")}, index: 1}
+	b := generateTask{file: input.RequirementFile{Path: filepath.Join(dir, "b", "req.md")}, index: 1}
+	a2 := generateTask{file: input.RequirementFile{Path: filepath.Join(dir, "a", "req.md")}, index: 2}
+	na, nb := taskLogName(a), taskLogName(b)
+	if na == nb || !strings.HasPrefix(na, "req_1_") || !strings.HasPrefix(nb, "req_1_") {
+		t.Fatalf("same-name inputs in different dirs should get different logs: %q %q", na, nb)
+	}
+	if n := taskLogName(a2); n != "req_2"+strings.TrimPrefix(na, "req_1") {
+		t.Fatalf("candidate index should only change the index part: %q vs %q", n, na)
+	}
+}
+
+func TestLogger_JSONFormat(t *testing.T) {
+	lg, err := NewLogger(false, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := lg.SetLogFormat("xml"); err == nil {
+		t.Fatal("expected invalid --log-format error")
+	}
+	if err := lg.SetLogFormat("json"); err != nil {
+		t.Fatal(err)
+	}
+	out := captureStdout(t, func() {
+		lg.Info("任务完成")
+		lg.Event("job_submitted", map[string]any{"job_id": "j1"})
+	})
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("unexpected output: %q", out)
+	}
+	var first map[string]any
+	if err := json.Unmarshal([]byte(lines[0]), &first); err != nil || first["event"] != "info" || first["message"] != "任务完成" {
+		t.Fatalf("unexpected info line: %s", lines[0])
+	}
+	if !strings.Contains(lines[1], `"job_id":"j1"`) {
+		t.Fatalf("unexpected event line: %s", lines[1])
+	}
+	if lg.Colorize() {
+		t.Fatal("json output must not be colorized")
+	}
+}
@@ -0,0 +1,18 @@
+package app
+
+import (
+	"strings"
+
+	"syl-listing-pro/internal/i18n"
+)
+
+var marketplaces = []string{"us", "de", "fr", "jp"}
+
+// parseMarketplace 校验 --marketplace，空值表示使用服务端默认站点。
+func parseMarketplace(s string) (string, error) {
+	m := strings.ToLower(strings.TrimSpace(s))
+	if m == "" || containsString(marketplaces, m) {
+		return m, nil
+	}
+	return "", i18n.Errorf("--marketplace 仅支持 %s：%s", strings.Join(marketplaces, "|"), s)
+}
@@ -0,0 +1,81 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"syl-listing-pro/internal/client"
+)
+
+func TestParseMarketplace(t *testing.T) {
+	if got, err := parseMarketplace(" DE "); err != nil || got != "de" {
+		t.Fatalf("got=%q err=%v", got, err)
+	}
+	if _, err := parseMarketplace("uk"); err == nil || !strings.Contains(err.Error(), "--marketplace") {
+		t.Fatalf("unexpected err: %v", err)
+	}
+}
+
+func TestRunGen_MarketplaceLanguageSuffixes(t *testing.T) {
+	prepareRunGenHome(t)
+	inner := newRunGenFastSuccessServer(t)
+	defer inner.Close()
+	var marketplace string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/generate":
+			var req client.GenerateReq
+			b, _ := io.ReadAll(r.Body)
+			_ = json.Unmarshal(b, &req)
+			marketplace = req.Marketplace
+			r.Body = io.NopCloser(strings.NewReader(string(b)))
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/result"):
+			rec := httptest.NewRecorder()
+			inner.Config.Handler.ServeHTTP(rec, r)
+			var res map[string]any
+			_ = json.Unmarshal(rec.Body.Bytes(), &res)
+			res["languages"] = []string{"en", "de"}
+			_ = json.NewEncoder(w).Encode(res)
+			return
+		}
+		inner.Config.Handler.ServeHTTP(w, r)
+	}))
+	defer ts.Close()
+	oldBase := workerBaseURL
+	oldTimeout := streamTimeoutSecond
+	workerBaseURL = ts.URL
+	streamTimeoutSecond = 5
+	defer func() {
+		workerBaseURL = oldBase
+		streamTimeoutSecond = oldTimeout
+	}()
+
+	inputPath := filepath.Join(t.TempDir(), "req.md")
+	if err := os.WriteFile(inputPath, []byte("# 输入"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	outDir := t.TempDir()
+	if _, err := captureStdoutRun(t, func() error {
+		return RunGen(context.Background(), GenOptions{OutputDir: outDir, Inputs: []string{inputPath}, SkipDocx: true, OnConflict: "overwrite", Marketplace: "de"})
+	}); err != nil {
+		t.Fatalf("RunGen error: %v", err)
+	}
+	if marketplace != "de" {
+		t.Fatalf("marketplace not sent: %q", marketplace)
+	}
+	for _, name := range []string{"req_en.md", "req_de.md"} {
+		if _, err := os.Stat(filepath.Join(outDir, name)); err != nil {
+			t.Fatalf("missing %s: %v", name, err)
+		}
+	}
+	if sidecarPath(filepath.Join(outDir, "req_en.md")) != filepath.Join(outDir, "req.json") {
+		t.Fatal("unexpected sidecar path")
+	}
+}
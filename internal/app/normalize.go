@@ -0,0 +1,37 @@
+package app
+
+import (
+	"strings"
+
+	"syl-listing-pro/internal/i18n"
+	"syl-listing-pro/internal/listing"
+)
+
+// normalizeRules 为 --normalize 可选的规则。
+var normalizeRules = []string{"blank-lines", "bullets", "straight-quotes", "curly-quotes", "strip-emoji"}
+
+// parseNormalizeRules 把 --normalize 的规则列表转为规范化选项；直引号与弯引号不能同时指定。
+func parseNormalizeRules(rules []string) (listing.NormalizeOptions, error) {
+	var opts listing.NormalizeOptions
+	for _, raw := range rules {
+		rule := strings.ToLower(strings.TrimSpace(raw))
+		switch rule {
+		case "":
+		case "blank-lines":
+			opts.CollapseBlankLines = true
+		case "bullets":
+			opts.Bullets = true
+		case "straight-quotes", "curly-quotes":
+			quotes := strings.TrimSuffix(rule, "-quotes")
+			if opts.Quotes != "" && opts.Quotes != quotes {
+				return listing.NormalizeOptions{}, i18n.Errorf("--normalize 不能同时使用 straight-quotes 与 curly-quotes")
+			}
+			opts.Quotes = quotes
+		case "strip-emoji":
+			opts.StripEmoji = true
+		default:
+			return listing.NormalizeOptions{}, i18n.Errorf("--normalize 只支持 %s: %s", strings.Join(normalizeRules, "|"), raw)
+		}
+	}
+	return opts, nil
+}
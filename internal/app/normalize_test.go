@@ -0,0 +1,68 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"syl-listing-pro/internal/client"
+	"syl-listing-pro/internal/listing"
+)
+
+func TestParseNormalizeRules(t *testing.T) {
+	got, err := parseNormalizeRules([]string{" Blank-Lines", "bullets", "curly-quotes", "", "strip-emoji"})
+	want := listing.NormalizeOptions{CollapseBlankLines: true, Bullets: true, Quotes: listing.QuotesCurly, StripEmoji: true}
+	if err != nil || got != want {
+		t.Fatalf("got=%+v err=%v", got, err)
+	}
+	if _, err := parseNormalizeRules([]string{"straight-quotes", "curly-quotes"}); err == nil {
+		t.Fatal("expected conflicting quotes error")
+	}
+	if _, err := parseNormalizeRules([]string{"tabs"}); err == nil || !strings.Contains(err.Error(), "--normalize") {
+		t.Fatalf("unexpected err: %v", err)
+	}
+}
+
+func TestRunGen_NormalizeOutputs(t *testing.T) {
+	prepareRunGenHome(t)
+	inner := newRunGenFastSuccessServer(t)
+	defer inner.Close()
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/result") {
+			_ = json.NewEncoder(w).Encode(client.ResultResp{ENMarkdown: "# Title\n\n\n\n* ✅ Non-slip\n", CNMarkdown: "# 标题\n• 防滑\n"})
+			return
+		}
+		inner.Config.Handler.ServeHTTP(w, r)
+	}))
+	defer ts.Close()
+	oldBase := workerBaseURL
+	oldTimeout := streamTimeoutSecond
+	workerBaseURL = ts.URL
+	streamTimeoutSecond = 5
+	defer func() {
+		workerBaseURL = oldBase
+		streamTimeoutSecond = oldTimeout
+	}()
+
+	inputPath := filepath.Join(t.TempDir(), "req.md")
+	if err := os.WriteFile(inputPath, []byte("# 输入"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	outDir := t.TempDir()
+	if _, err := captureStdoutRun(t, func() error {
+		return RunGen(context.Background(), GenOptions{OutputDir: outDir, Inputs: []string{inputPath}, SkipDocx: true, OnConflict: "overwrite", Normalize: []string{"blank-lines", "bullets", "strip-emoji"}})
+	}); err != nil {
+		t.Fatalf("RunGen error: %v", err)
+	}
+	for name, want := range map[string]string{"req_en.md": "# Title\n\n- Non-slip\n", "req_cn.md": "# 标题\n- 防滑\n"} {
+		b, err := os.ReadFile(filepath.Join(outDir, name))
+		if err != nil || string(b) != want {
+			t.Fatalf("%s=%q err=%v", name, b, err)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package app
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+
+	"syl-listing-pro/internal/i18n"
+)
+
+// pauseGate 控制是否暂停提交新任务：暂停期间等待提交的任务阻塞，已提交任务照常跟踪到结束。
+// nil 表示不支持暂停。
+type pauseGate struct {
+	mu sync.Mutex
+	// resume 在暂停期间非 nil，恢复时关闭以唤醒等待的任务。
+	resume chan struct{}
+}
+
+// toggle 在暂停与恢复之间切换，返回切换后是否处于暂停状态。
+func (g *pauseGate) toggle() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.resume != nil {
+		close(g.resume)
+		g.resume = nil
+		return false
+	}
+	g.resume = make(chan struct{})
+	return true
+}
+
+func (g *pauseGate) paused() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.resume != nil
+}
+
+// wait 在暂停期间阻塞到恢复为止；ctx 取消时返回其错误。
+func (g *pauseGate) wait(ctx context.Context) error {
+	if g == nil {
+		return nil
+	}
+	for {
+		g.mu.Lock()
+		ch := g.resume
+		g.mu.Unlock()
+		if ch == nil {
+			return ctx.Err()
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ch:
+		}
+	}
+}
+
+// pauseKeys 返回读取暂停按键的输入：标准输入是终端且运行结束后不需要交互选择候选时为 pickInput，否则为 nil。
+// --route 时监听在各租户的 --confirm 之前开始，读取按键会抢走确认输入，因此同样不读取。
+func pauseKeys(opts GenOptions) io.Reader {
+	if opts.Pick || (opts.Confirm && opts.RouteFile != "") || !stdinIsTerminal() {
+		return nil
+	}
+	return pickInput
+}
+
+// watchPause 监听暂停切换：SIGUSR1（Windows 不支持），以及 keys 中单独一行的 p。
+// 返回的函数停止监听；keys 上阻塞中的读取无法中断，读到的按键在停止后忽略。
+func watchPause(ctx context.Context, log *Logger, g *pauseGate, keys io.Reader) func() {
+	sigCh := make(chan os.Signal, 1)
+	notifyPauseSignal(sigCh)
+	done := make(chan struct{})
+	keyCh := make(chan struct{})
+	if keys != nil {
+		log.Info(i18n.T("运行中输入 p 并回车可暂停/恢复提交新任务"))
+		go func() {
+			r := bufio.NewReader(keys)
+			for {
+				line, err := r.ReadString('\n')
+				if strings.EqualFold(strings.TrimSpace(line), "p") {
+					select {
+					case keyCh <- struct{}{}:
+					case <-done:
+						return
+					}
+				}
+				if err != nil {
+					return
+				}
+			}
+		}()
+	}
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-done:
+				return
+			case <-sigCh:
+			case <-keyCh:
+			}
+			if g.toggle() {
+				log.Info(i18n.T("已暂停提交新任务，已提交的任务继续跟踪；再次输入 p 回车或发送 SIGUSR1 恢复"))
+			} else {
+				log.Info(i18n.T("已恢复提交新任务"))
+			}
+		}
+	}()
+	return func() {
+		signal.Stop(sigCh)
+		close(done)
+	}
+}
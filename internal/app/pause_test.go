@@ -0,0 +1,103 @@
+package app
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPauseGate_WaitBlocksUntilResumed(t *testing.T) {
+	g := &pauseGate{}
+	if err := g.wait(context.Background()); err != nil {
+		t.Fatalf("unpaused wait: %v", err)
+	}
+	if !g.toggle() {
+		t.Fatal("expected paused after first toggle")
+	}
+	done := make(chan error, 1)
+	go func() { done <- g.wait(context.Background()) }()
+	select {
+	case err := <-done:
+		t.Fatalf("wait returned while paused: %v", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+	if g.toggle() {
+		t.Fatal("expected resumed after second toggle")
+	}
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("resumed wait: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("wait did not return after resume")
+	}
+
+	g.toggle()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := g.wait(ctx); err == nil {
+		t.Fatal("expected cancellation error while paused")
+	}
+	var nilGate *pauseGate
+	if err := nilGate.wait(context.Background()); err != nil {
+		t.Fatalf("nil gate wait: %v", err)
+	}
+}
+
+func TestWatchPause_KeyToggles(t *testing.T) {
+	log, err := NewLogger(false, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer log.Close()
+	g := &pauseGate{}
+	r, w := io.Pipe()
+	defer w.Close()
+	stop := watchPause(context.Background(), log, g, r)
+	defer stop()
+
+	waitPaused := func(want bool) {
+		t.Helper()
+		deadline := time.Now().Add(time.Second)
+		for g.paused() != want {
+			if time.Now().After(deadline) {
+				t.Fatalf("paused=%v, want %v", g.paused(), want)
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+	}
+	if _, err := io.WriteString(w, "x\nP\n"); err != nil {
+		t.Fatal(err)
+	}
+	waitPaused(true)
+	if _, err := io.WriteString(w, "p\n"); err != nil {
+		t.Fatal(err)
+	}
+	waitPaused(false)
+}
+
+func TestPauseKeys(t *testing.T) {
+	oldTerm, oldIn := stdinIsTerminal, pickInput
+	defer func() { stdinIsTerminal, pickInput = oldTerm, oldIn }()
+	stdinIsTerminal = func() bool { return true }
+	pickInput = strings.NewReader("")
+
+	if pauseKeys(GenOptions{}) == nil {
+		t.Fatal("terminal run should read pause keys")
+	}
+	if pauseKeys(GenOptions{Confirm: true}) == nil {
+		t.Fatal("non-routed --confirm finishes before the watcher starts")
+	}
+	for _, opts := range []GenOptions{{Pick: true}, {RouteFile: "routes.txt", Confirm: true}} {
+		if pauseKeys(opts) != nil {
+			t.Fatalf("pause keys must not compete for stdin: %+v", opts)
+		}
+	}
+	stdinIsTerminal = func() bool { return false }
+	if pauseKeys(GenOptions{}) != nil {
+		t.Fatal("non-terminal stdin should not be read")
+	}
+}
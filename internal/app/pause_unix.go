@@ -0,0 +1,14 @@
+//go:build !windows
+
+package app
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// notifyPauseSignal 把 SIGUSR1 转发到 ch，用于切换暂停提交。
+func notifyPauseSignal(ch chan<- os.Signal) {
+	signal.Notify(ch, syscall.SIGUSR1)
+}
@@ -0,0 +1,6 @@
+package app
+
+import "os"
+
+// notifyPauseSignal 在 Windows 上为空操作：没有 SIGUSR1，只能用按键暂停。
+func notifyPauseSignal(ch chan<- os.Signal) {}
@@ -0,0 +1,204 @@
+package app
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"syl-listing-pro/internal/i18n"
+	"syl-listing-pro/internal/listing"
+	"syl-listing-pro/internal/manifest"
+	"syl-listing-pro/internal/output"
+)
+
+// 候选选择器的输入输出，测试时替换。
+var (
+	pickInput       io.Reader = os.Stdin
+	pickOutput      io.Writer = os.Stdout
+	stdinIsTerminal           = func() bool {
+		fi, err := os.Stdin.Stat()
+		return err == nil && fi.Mode()&os.ModeCharDevice != 0
+	}
+)
+
+type pickCandidate struct {
+	key     string
+	index   int
+	en, cn  string
+	listing listing.Listing
+}
+
+type pickGroup struct {
+	inputPath  string
+	candidates []pickCandidate
+}
+
+// runCandidatePicker 按需求文件逐个展示成功的候选，由用户选定最终稿：
+// 复制为 <base>_final_<lang>.md（有 Word 时一并复制）并记入运行记录。
+func runCandidatePicker(log *Logger, cp *manifest.Checkpoint) {
+	if cp == nil {
+		return
+	}
+	if !stdinIsTerminal() {
+		log.Info(i18n.T("标准输入不是终端，跳过候选选择"))
+		return
+	}
+	reader := bufio.NewReader(pickInput)
+	for _, g := range candidateGroups(cp.Snapshot()) {
+		writeCandidateTable(pickOutput, g)
+		choice, ok := promptCandidate(reader, pickOutput, len(g.candidates))
+		if !ok {
+			return
+		}
+		if choice == 0 {
+			continue
+		}
+		c := g.candidates[choice-1]
+		finals, err := copyFinalOutputs(g.inputPath, c)
+		if err != nil {
+			log.Info(i18n.T("保存最终稿失败：%v", err))
+			continue
+		}
+		if err := cp.Update(c.key, func(t *manifest.Task) {
+			t.Selected = true
+			t.FinalOutputs = finals
+		}); err != nil {
+			log.Info(i18n.T("运行记录写入失败：%v", err))
+		}
+		log.Info(i18n.T("已选定候选 %d：%s", c.index, strings.Join(finals, "、")))
+	}
+}
+
+// candidateGroups 按输入顺序汇总有两个及以上成功候选的需求文件。
+func candidateGroups(m manifest.Manifest) []pickGroup {
+	var groups []pickGroup
+	pos := map[string]int{}
+	for _, t := range m.Tasks {
+		if !t.Done() {
+			continue
+		}
+		en, cn := markdownOutputs(t.Outputs)
+		if en == "" || cn == "" {
+			continue
+		}
+		b, err := os.ReadFile(en)
+		if err != nil {
+			continue
+		}
+		i, ok := pos[t.InputPath]
+		if !ok {
+			i = len(groups)
+			pos[t.InputPath] = i
+			groups = append(groups, pickGroup{inputPath: t.InputPath})
+		}
+		groups[i].candidates = append(groups[i].candidates, pickCandidate{
+			key: t.Key, index: t.Index, en: en, cn: cn, listing: listing.Parse(string(b)),
+		})
+	}
+	out := groups[:0]
+	for _, g := range groups {
+		if len(g.candidates) > 1 {
+			out = append(out, g)
+		}
+	}
+	return out
+}
+
+// markdownOutputs 返回主输入的主稿/对照稿 Markdown 产物（Outputs 中前两个 .md）。
+func markdownOutputs(outputs []string) (string, string) {
+	var md []string
+	for _, p := range outputs {
+		if strings.HasSuffix(p, ".md") {
+			md = append(md, p)
+		}
+		if len(md) == 2 {
+			return md[0], md[1]
+		}
+	}
+	return "", ""
+}
+
+func writeCandidateTable(w io.Writer, g pickGroup) {
+	fmt.Fprintf(w, "\n%s\n", i18n.T("需求文件：%s", g.inputPath))
+	for i, c := range g.candidates {
+		title, _ := c.listing.Section(listing.KindTitle)
+		bullets, _ := c.listing.Section(listing.KindBullets)
+		desc, _ := c.listing.Section(listing.KindDescription)
+		firstBullet := ""
+		if len(bullets.Items) > 0 {
+			firstBullet = bullets.Items[0]
+		}
+		fmt.Fprintf(w, "  [%d] %s\n", i+1, clipRunes(title.Body, 80))
+		fmt.Fprintf(w, "      %s\n", i18n.T("首条五点：%s", clipRunes(firstBullet, 80)))
+		fmt.Fprintf(w, "      %s\n", i18n.T("字符数：标题 %d / 五点 %d / 描述 %d", title.Chars(), bullets.Chars(), desc.Chars()))
+	}
+}
+
+// promptCandidate 读取用户选择；回车跳过返回 0，输入结束时第二个返回值为 false。
+func promptCandidate(r *bufio.Reader, w io.Writer, n int) (int, bool) {
+	for {
+		fmt.Fprint(w, i18n.T("选择最终稿 [1-%d，回车跳过]：", n))
+		line, err := r.ReadString('\n')
+		text := strings.TrimSpace(line)
+		if text == "" {
+			return 0, err == nil
+		}
+		if v, convErr := strconv.Atoi(text); convErr == nil && v >= 1 && v <= n {
+			return v, true
+		}
+		if err != nil {
+			return 0, false
+		}
+		fmt.Fprintln(w, i18n.T("无效选择：%s", text))
+	}
+}
+
+// copyFinalOutputs 把候选的 Markdown 与同名 Word 复制为 _final 文件，已存在时覆盖。
+func copyFinalOutputs(inputPath string, c pickCandidate) ([]string, error) {
+	enFinal, cnFinal := output.FinalPair(filepath.Dir(c.en), inputPath, candidateLangs(c))
+	return copyCandidateOutputs(c, enFinal, cnFinal)
+}
+
+func candidateLangs(c pickCandidate) output.Langs {
+	return output.Langs{Primary: output.LangOf(c.en), Secondary: output.LangOf(c.cn)}
+}
+
+// copyCandidateOutputs 把候选的 Markdown 与同名 Word 复制到 enFinal/cnFinal，已存在时覆盖。
+func copyCandidateOutputs(c pickCandidate, enFinal, cnFinal string) ([]string, error) {
+	pairs := [][2]string{{c.en, enFinal}, {c.cn, cnFinal}}
+	for _, src := range []string{c.en, c.cn} {
+		docx := strings.TrimSuffix(src, filepath.Ext(src)) + ".docx"
+		if _, err := os.Stat(docx); err == nil {
+			dst := enFinal
+			if src == c.cn {
+				dst = cnFinal
+			}
+			pairs = append(pairs, [2]string{docx, strings.TrimSuffix(dst, filepath.Ext(dst)) + ".docx"})
+		}
+	}
+	var out []string
+	for _, p := range pairs {
+		b, err := os.ReadFile(p[0])
+		if err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(p[1], b, 0o644); err != nil {
+			return nil, err
+		}
+		out = append(out, p[1])
+	}
+	return out, nil
+}
+
+func clipRunes(s string, n int) string {
+	s = strings.Join(strings.Fields(s), " ")
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return string([]rune(s)[:n]) + "…"
+}
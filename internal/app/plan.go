@@ -0,0 +1,339 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"time"
+
+	"syl-listing-pro/internal/client"
+	"syl-listing-pro/internal/config"
+	"syl-listing-pro/internal/i18n"
+	"syl-listing-pro/internal/input"
+	"syl-listing-pro/internal/ledger"
+	"syl-listing-pro/internal/manifest"
+	"syl-listing-pro/internal/util"
+)
+
+// runPlan 是一次运行待执行的任务集合及其检查点。
+type runPlan struct {
+	outputDir  string
+	tasks      []generateTask
+	checkpoint *manifest.Checkpoint
+	// pending 为新运行待创建的运行记录；预检与 --confirm 通过后才写入 runs/，
+	// 放弃的运行不会成为 --resume-last 的续跑对象。续跑时为 nil。
+	pending *manifest.Manifest
+	ledger  *ledger.Ledger
+	// num 为每个需求文件的候选数，决定产物是否带候选序号；续跑与重新生成沿用原运行的设置。
+	num int
+	// sections 为本次按节生成的分节；续跑与重新生成沿用原运行的设置。
+	sections    []string
+	keywords    []string
+	marketplace string
+	searchTerms bool
+	attributes  client.ProductAttributes
+	label       string
+}
+
+func planRun(log *Logger, opts GenOptions, runID string, startedAt time.Time) (runPlan, error) {
+	if opts.ResumeLast {
+		if len(opts.Inputs) > 0 {
+			return runPlan{}, i18n.Errorf("--resume-last 会沿用上次运行的输入，不能再指定输入文件")
+		}
+		return planResume(log, opts, runID)
+	}
+	if opts.RequeueRunID != "" {
+		if len(opts.Inputs) > 0 {
+			return runPlan{}, i18n.Errorf("requeue 会沿用原运行的输入，不能再指定输入文件")
+		}
+		return planRequeue(log, opts, runID, startedAt)
+	}
+
+	files, err := input.Discover(opts.Inputs)
+	if err != nil {
+		return runPlan{}, err
+	}
+	plan := runPlan{outputDir: opts.OutputDir, num: opts.Num, sections: opts.Sections, keywords: opts.Keywords, marketplace: opts.Marketplace, searchTerms: opts.SearchTerms, attributes: opts.Attributes, label: opts.Label}
+	if opts.Incremental {
+		plan.ledger, err = ledger.Load(opts.OutputDir)
+		if err != nil {
+			return runPlan{}, err
+		}
+		var skipped []input.RequirementFile
+		files, skipped = filterProcessedFiles(files, plan.ledger)
+		for _, f := range skipped {
+			log.Info(i18n.T("增量模式：%s 内容未变化，已跳过", f.Path))
+		}
+		if len(files) == 0 {
+			log.Info(i18n.T("增量模式：没有新增或变更的需求文件"))
+			return plan, nil
+		}
+	}
+
+	tasks := buildGenerateTasks(files, opts.Num)
+	if opts.DedupContent {
+		var merged int
+		tasks, merged = dedupGenerateTasks(tasks)
+		if merged > 0 {
+			log.Info(i18n.T("内容去重：%d 个任务与已有输入内容相同，将复用其结果", merged))
+		}
+	}
+	for i := range tasks {
+		tasks[i].idempotencyKey = idempotencyKey(runID, tasks[i])
+	}
+	plan.tasks = tasks
+	m := newRunManifest(runID, startedAt, opts, tasks)
+	plan.pending = &m
+	return plan, nil
+}
+
+func planResume(log *Logger, opts GenOptions, runID string) (runPlan, error) {
+	dir, err := util.DefaultRunsDir()
+	if err != nil {
+		return runPlan{}, err
+	}
+	m, path, err := manifest.LoadLatest(dir)
+	if err != nil {
+		if errors.Is(err, manifest.ErrNoRuns) {
+			return runPlan{}, i18n.Errorf("没有可续跑的运行记录")
+		}
+		return runPlan{}, err
+	}
+	if err := checkRunKeyProfile(m, opts.KeyProfile); err != nil {
+		return runPlan{}, err
+	}
+	tasks, err := tasksFromManifest(m, runID)
+	if err != nil {
+		return runPlan{}, err
+	}
+	plan := runPlan{outputDir: m.OutputDir, tasks: tasks, num: m.Num, sections: m.Sections, keywords: m.Keywords, marketplace: m.Marketplace, searchTerms: m.SearchTerms, attributes: manifestAttributes(m), label: m.Label}
+	if len(tasks) == 0 {
+		log.Info(i18n.T("最近一次运行 %s 已全部完成，无需续跑", m.RunID))
+		return plan, nil
+	}
+	log.Info(i18n.T("续跑 %s：已完成 %d，待处理 %d", m.RunID, len(m.Tasks)-len(tasks), len(tasks)))
+	plan.checkpoint, err = manifest.Open(path, m)
+	if err != nil {
+		return runPlan{}, err
+	}
+	return plan, nil
+}
+
+// key 与运行记录中的任务键一致：输入绝对路径加候选序号。
+func (t generateTask) key() string {
+	return manifest.TaskKey(mustAbsPath(t.file.Path), t.index)
+}
+
+// planRequeue 以新的运行重新生成指定运行中失败或取消的任务，输出目录与命名方式沿用原运行。
+func planRequeue(log *Logger, opts GenOptions, runID string, startedAt time.Time) (runPlan, error) {
+	source, err := loadRunManifest(opts.RequeueRunID)
+	if err != nil {
+		return runPlan{}, err
+	}
+	if err := checkRunKeyProfile(source, opts.KeyProfile); err != nil {
+		return runPlan{}, err
+	}
+	failed := manifest.Manifest{RunID: source.RunID}
+	for _, item := range source.Tasks {
+		if item.Status == manifest.StatusFailed || item.Status == manifest.StatusCancelled {
+			item.Status = manifest.StatusPending
+			item.JobID = ""
+			failed.Tasks = append(failed.Tasks, item)
+		}
+	}
+	if opts.Label == "" {
+		opts.Label = source.Label
+	}
+	plan := runPlan{outputDir: source.OutputDir, num: source.Num, sections: source.Sections, keywords: source.Keywords, marketplace: source.Marketplace, searchTerms: source.SearchTerms, attributes: manifestAttributes(source), label: opts.Label}
+	if len(failed.Tasks) == 0 {
+		log.Info(i18n.T("运行 %s 没有失败任务，无需重新生成", source.RunID))
+		return plan, nil
+	}
+	tasks, err := tasksFromManifest(failed, runID)
+	if err != nil {
+		return runPlan{}, err
+	}
+	for i := range tasks {
+		tasks[i].idempotencyKey = idempotencyKey(runID, tasks[i])
+	}
+	log.Info(i18n.T("重新生成运行 %s 的失败任务：%d 个", source.RunID, len(tasks)))
+	opts.OutputDir = source.OutputDir
+	opts.Num = source.Num
+	opts.Inputs = source.Inputs
+	opts.Sections = source.Sections
+	opts.Keywords = source.Keywords
+	opts.Marketplace = source.Marketplace
+	opts.SearchTerms = source.SearchTerms
+	opts.Attributes = plan.attributes
+	plan.tasks = tasks
+	m := newRunManifest(runID, startedAt, opts, tasks)
+	plan.pending = &m
+	return plan, nil
+}
+
+// checkRunKeyProfile 拒绝用另一个 KEY 配置续跑或重新生成运行，避免跨租户操作任务。
+func checkRunKeyProfile(m manifest.Manifest, profile string) error {
+	recorded := m.KeyProfile
+	if recorded == "" {
+		recorded = config.DefaultKeyProfile
+	}
+	if profile == "" {
+		profile = config.DefaultKeyProfile
+	}
+	if recorded != profile {
+		return i18n.Errorf("运行 %s 使用的是 KEY 配置 %s，当前为 %s；请加 --key-profile %s", m.RunID, recorded, profile, recorded)
+	}
+	return nil
+}
+
+func loadRunManifest(runID string) (manifest.Manifest, error) {
+	id := strings.TrimSpace(runID)
+	if id == "" || strings.ContainsAny(id, `/\`) || strings.HasPrefix(id, ".") {
+		return manifest.Manifest{}, i18n.Errorf("运行编号无效: %q", runID)
+	}
+	dir, err := util.DefaultRunsDir()
+	if err != nil {
+		return manifest.Manifest{}, err
+	}
+	m, err := manifest.Load(manifest.FilePath(dir, id))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return manifest.Manifest{}, i18n.Errorf("未找到运行记录: %s", id)
+		}
+		return manifest.Manifest{}, err
+	}
+	return m, nil
+}
+
+func newRunManifest(runID string, startedAt time.Time, opts GenOptions, tasks []generateTask) manifest.Manifest {
+	m := manifest.Manifest{
+		RunID:          runID,
+		StartedAt:      startedAt.Format(time.RFC3339),
+		OutputDir:      mustAbsPath(opts.OutputDir),
+		Num:            opts.Num,
+		KeyProfile:     opts.KeyProfile,
+		Sections:       opts.Sections,
+		Keywords:       opts.Keywords,
+		Marketplace:    opts.Marketplace,
+		SearchTerms:    opts.SearchTerms,
+		Label:          opts.Label,
+		Brand:          opts.Attributes.Brand,
+		Category:       opts.Attributes.Category,
+		TargetAudience: opts.Attributes.TargetAudience,
+		KeyFeatures:    opts.Attributes.KeyFeatures,
+		Inputs:         opts.Inputs,
+		Tasks:          make([]manifest.Task, 0, len(tasks)),
+	}
+	for _, task := range tasks {
+		item := manifest.Task{
+			Key:            task.key(),
+			InputPath:      mustAbsPath(task.file.Path),
+			Index:          task.index,
+			Label:          task.label,
+			ContentHash:    task.file.ContentHash(),
+			IdempotencyKey: task.idempotencyKey,
+			Status:         manifest.StatusPending,
+		}
+		for _, mirror := range task.mirrors {
+			item.Mirrors = append(item.Mirrors, mustAbsPath(mirror.Path))
+		}
+		m.Tasks = append(m.Tasks, item)
+	}
+	return m
+}
+
+// tasksFromManifest 还原尚未成功的任务；已提交的任务保留 job ID 以便重新接入，
+// 已失败或取消的任务换用新的幂等键重新提交，避免服务端直接返回旧任务。
+func tasksFromManifest(m manifest.Manifest, runID string) ([]generateTask, error) {
+	var tasks []generateTask
+	for _, item := range m.Tasks {
+		if item.Done() || item.Status == manifest.StatusSkipped {
+			continue
+		}
+		file, err := readRequirementFile(item.InputPath)
+		if err != nil {
+			return nil, err
+		}
+		task := generateTask{
+			file:           file,
+			index:          item.Index,
+			label:          item.Label,
+			idempotencyKey: item.IdempotencyKey,
+		}
+		switch item.Status {
+		case manifest.StatusSubmitted:
+			task.jobID = item.JobID
+		case manifest.StatusFailed, manifest.StatusCancelled:
+			task.idempotencyKey = idempotencyKey(runID, task)
+		}
+		for _, p := range item.Mirrors {
+			mirror, err := readRequirementFile(p)
+			if err != nil {
+				return nil, err
+			}
+			task.mirrors = append(task.mirrors, mirror)
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks, nil
+}
+
+func readRequirementFile(path string) (input.RequirementFile, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return input.RequirementFile{}, i18n.Errorf("读取输入失败: %w", err)
+	}
+	return input.RequirementFile{Path: path, Content: string(b)}, nil
+}
+
+func createRunCheckpoint(log *Logger, m manifest.Manifest) *manifest.Checkpoint {
+	dir, err := util.DefaultRunsDir()
+	if err == nil {
+		var cp *manifest.Checkpoint
+		cp, err = manifest.Create(dir, m)
+		if err == nil {
+			return cp
+		}
+	}
+	log.Info(i18n.T("运行记录创建失败，本次运行不支持续跑：%v", err))
+	return nil
+}
+
+func recordTaskCheckpoint(cp *manifest.Checkpoint, log *Logger, task generateTask, fn func(*manifest.Task)) {
+	if err := cp.Update(task.key(), fn); err != nil {
+		log.Info(i18n.T("运行记录写入失败（%s）：%v", task.key(), err))
+	}
+}
+
+func recordTaskResult(ctx context.Context, cp *manifest.Checkpoint, log *Logger, task generateTask, result taskResult) {
+	recordTaskCheckpoint(cp, log, task, func(item *manifest.Task) {
+		if result.jobID != "" {
+			item.JobID = result.jobID
+		}
+		item.Outputs = result.outputs
+		item.RemoteURLs = result.remoteURLs
+		item.ValidationReport = result.listing.ValidationReport
+		item.RulesVersion = result.rulesVersion
+		item.DurationMs = result.duration.Milliseconds()
+		item.SectionDurationsMs = result.sectionDurations
+		if u := result.listing.Usage; u != nil {
+			item.Tokens = u.Tokens()
+			item.Credits = u.Credits
+		}
+		item.Error = ""
+		if result.err != nil {
+			item.Error = result.err.Error()
+		}
+		switch {
+		case result.ok:
+			item.Status = manifest.StatusSucceeded
+		case result.skipped:
+			item.Status = manifest.StatusSkipped
+		case client.IsCanceled(ctx.Err()):
+			item.Status = manifest.StatusCancelled
+		default:
+			item.Status = manifest.StatusFailed
+		}
+	})
+}
@@ -0,0 +1,79 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"syl-listing-pro/internal/i18n"
+	"syl-listing-pro/internal/util"
+)
+
+// preflightMinFreeBytes 为开跑前各写入目录要求的最小可用空间。
+const preflightMinFreeBytes = 64 << 20
+
+// diskFreeBytes 返回 dir 所在文件系统对当前用户可用的字节数；便于测试替换。
+var diskFreeBytes = diskFree
+
+type preflightDir struct {
+	label string
+	dir   string
+}
+
+// preflightRun 在提交任务前检查输出目录、运行记录目录（以及 --log-dir）是否可写、空间是否足够，
+// 避免每个任务都到写文件阶段才失败。
+func preflightRun(opts GenOptions) error {
+	dirs := []preflightDir{{label: i18n.T("输出目录"), dir: opts.OutputDir}}
+	if runsDir, err := util.DefaultRunsDir(); err == nil {
+		dirs = append(dirs, preflightDir{label: i18n.T("运行记录目录"), dir: runsDir})
+	}
+	if opts.LogDir != "" {
+		dirs = append(dirs, preflightDir{label: i18n.T("日志目录"), dir: opts.LogDir})
+	}
+	return checkWritableDirs(dirs)
+}
+
+func checkWritableDirs(dirs []preflightDir) error {
+	for _, d := range dirs {
+		abs := mustAbsPath(d.dir)
+		if err := probeWritable(d.dir); err != nil {
+			return i18n.Errorf("%s不可写：%s：%v", d.label, abs, err)
+		}
+		free, err := diskFreeBytes(d.dir)
+		if err != nil {
+			// 无法读取可用空间时只做可写检查。
+			continue
+		}
+		if free < preflightMinFreeBytes {
+			return i18n.Errorf("%s所在磁盘空间不足：%s 仅剩 %s，至少需要 %s", d.label, abs, formatBytes(free), formatBytes(preflightMinFreeBytes))
+		}
+	}
+	return nil
+}
+
+func probeWritable(dir string) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(dir, ".syl-preflight-*")
+	if err != nil {
+		return err
+	}
+	name := f.Name()
+	_ = f.Close()
+	return os.Remove(filepath.Clean(name))
+}
+
+// formatBytes 以 1024 进制输出易读的字节数，例如 12.5 MB。
+func formatBytes(n uint64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := uint64(unit), 0
+	for v := n / unit; v >= unit; v /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,56 @@
+package app
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCheckWritableDirs(t *testing.T) {
+	oldFree := diskFreeBytes
+	defer func() { diskFreeBytes = oldFree }()
+	diskFreeBytes = func(string) (uint64, error) { return 1 << 30, nil }
+
+	dir := filepath.Join(t.TempDir(), "out", "nested")
+	if err := checkWritableDirs([]preflightDir{{label: "输出目录", dir: dir}}); err != nil {
+		t.Fatalf("checkWritableDirs error: %v", err)
+	}
+	if _, err := os.Stat(dir); err != nil {
+		t.Fatalf("dir should be created: %v", err)
+	}
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 0 {
+		t.Fatalf("probe file left behind: %v", entries)
+	}
+
+	blocker := filepath.Join(t.TempDir(), "file")
+	if err := os.WriteFile(blocker, []byte("x"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	err := checkWritableDirs([]preflightDir{{label: "输出目录", dir: filepath.Join(blocker, "sub")}})
+	if err == nil || !strings.Contains(err.Error(), "输出目录不可写") {
+		t.Fatalf("err=%v want not writable", err)
+	}
+
+	diskFreeBytes = func(string) (uint64, error) { return 10 << 20, nil }
+	err = checkWritableDirs([]preflightDir{{label: "日志目录", dir: t.TempDir()}})
+	if err == nil || !strings.Contains(err.Error(), "日志目录所在磁盘空间不足") || !strings.Contains(err.Error(), "10.0 MB") {
+		t.Fatalf("err=%v want low space", err)
+	}
+
+	diskFreeBytes = func(string) (uint64, error) { return 0, errors.New("unsupported") }
+	if err := checkWritableDirs([]preflightDir{{label: "输出目录", dir: t.TempDir()}}); err != nil {
+		t.Fatalf("unknown free space should not fail: %v", err)
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	cases := map[uint64]string{512: "512 B", 1536: "1.5 KB", 64 << 20: "64.0 MB", 3 << 30: "3.0 GB"}
+	for n, want := range cases {
+		if got := formatBytes(n); got != want {
+			t.Fatalf("formatBytes(%d)=%q want %q", n, got, want)
+		}
+	}
+}
@@ -0,0 +1,18 @@
+package app
+
+import (
+	"context"
+
+	"syl-listing-pro/internal/i18n"
+	"syl-listing-pro/internal/processor"
+)
+
+func runProcessors(ctx context.Context, log *Logger, procs []processor.Processor, prefix string, res processor.Result) {
+	for _, p := range procs {
+		if err := p.Process(ctx, res); err != nil {
+			log.Info(i18n.T("%s 处理器 %s 失败：%v", prefix, p.Name(), err))
+			continue
+		}
+		log.Event("processor_done", map[string]any{"processor": p.Name(), "input": res.InputPath, "index": res.Index})
+	}
+}
@@ -0,0 +1,91 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/pprof"
+	"os"
+	"runtime"
+	runtimepprof "runtime/pprof"
+	"time"
+
+	"syl-listing-pro/internal/i18n"
+)
+
+// ProfileOptions 为现场排查性能问题用的剖析选项，均为空时不做任何事。
+type ProfileOptions struct {
+	// PprofAddr 非空时在该地址（如 :6060、127.0.0.1:6060）提供 /debug/pprof/。
+	PprofAddr string
+	// CPUProfile 非空时把整个进程的 CPU 剖析写到该文件。
+	CPUProfile string
+	// MemProfile 非空时在退出前把堆剖析写到该文件。
+	MemProfile string
+}
+
+// StartProfiling 按 opts 开启剖析，返回的 stop 负责写出剖析文件并关闭 pprof 服务，进程退出前必须调用。
+func StartProfiling(w io.Writer, opts ProfileOptions) (func(), error) {
+	var stops []func()
+	stop := func() {
+		for i := len(stops) - 1; i >= 0; i-- {
+			stops[i]()
+		}
+		stops = nil
+	}
+	fail := func(err error) (func(), error) {
+		stop()
+		return nil, err
+	}
+
+	if opts.PprofAddr != "" {
+		ln, err := net.Listen("tcp", opts.PprofAddr)
+		if err != nil {
+			return fail(i18n.Errorf("--pprof 监听失败：%v", err))
+		}
+		mux := http.NewServeMux()
+		mux.HandleFunc("/debug/pprof/", pprof.Index)
+		mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+		mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+		mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+		mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+		srv := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+		go func() { _ = srv.Serve(ln) }()
+		fmt.Fprintln(w, i18n.T("pprof 已开启：http://%s/debug/pprof/", ln.Addr()))
+		stops = append(stops, func() { _ = srv.Close() })
+	}
+	if opts.CPUProfile != "" {
+		f, err := os.Create(opts.CPUProfile)
+		if err != nil {
+			return fail(i18n.Errorf("创建 CPU 剖析文件失败：%v", err))
+		}
+		if err := runtimepprof.StartCPUProfile(f); err != nil {
+			_ = f.Close()
+			return fail(i18n.Errorf("开启 CPU 剖析失败：%v", err))
+		}
+		stops = append(stops, func() {
+			runtimepprof.StopCPUProfile()
+			_ = f.Close()
+		})
+	}
+	if opts.MemProfile != "" {
+		path := opts.MemProfile
+		stops = append(stops, func() {
+			if err := writeHeapProfile(path); err != nil {
+				fmt.Fprintln(w, i18n.T("写内存剖析失败：%v", err))
+			}
+		})
+	}
+	return stop, nil
+}
+
+func writeHeapProfile(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	runtime.GC()
+	err = runtimepprof.WriteHeapProfile(f)
+	return errors.Join(err, f.Close())
+}
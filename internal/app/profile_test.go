@@ -0,0 +1,48 @@
+package app
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+)
+
+func TestStartProfiling(t *testing.T) {
+	dir := t.TempDir()
+	cpu := filepath.Join(dir, "cpu.pprof")
+	mem := filepath.Join(dir, "mem.pprof")
+	var buf bytes.Buffer
+	stop, err := StartProfiling(&buf, ProfileOptions{PprofAddr: "127.0.0.1:0", CPUProfile: cpu, MemProfile: mem})
+	if err != nil {
+		t.Fatalf("StartProfiling error: %v", err)
+	}
+	m := regexp.MustCompile(`http://\S+/debug/pprof/`).FindString(buf.String())
+	if m == "" {
+		t.Fatalf("pprof address not printed: %q", buf.String())
+	}
+	resp, err := http.Get(m)
+	if err != nil {
+		t.Fatalf("GET %s: %v", m, err)
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("pprof status=%d", resp.StatusCode)
+	}
+	stop()
+	for _, p := range []string{cpu, mem} {
+		if st, err := os.Stat(p); err != nil || st.Size() == 0 {
+			t.Fatalf("profile %s not written: %v", p, err)
+		}
+	}
+	if _, err := http.Get(m); err == nil {
+		t.Fatalf("pprof server should be closed after stop")
+	}
+
+	if _, err := StartProfiling(&buf, ProfileOptions{CPUProfile: filepath.Join(dir, "missing", "cpu.pprof")}); err == nil {
+		t.Fatalf("expected error for unwritable cpu profile")
+	}
+}
@@ -0,0 +1,51 @@
+package app
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"syl-listing-pro/internal/listing"
+)
+
+func TestRunGen_ProvenanceHeader(t *testing.T) {
+	prepareRunGenHome(t)
+	ts := newRunGenFastSuccessServer(t)
+	defer ts.Close()
+	oldBase := workerBaseURL
+	oldTimeout := streamTimeoutSecond
+	workerBaseURL = ts.URL
+	streamTimeoutSecond = 5
+	defer func() {
+		workerBaseURL = oldBase
+		streamTimeoutSecond = oldTimeout
+	}()
+
+	inputPath := filepath.Join(t.TempDir(), "req.md")
+	if err := os.WriteFile(inputPath, []byte("# 输入"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	outDir := t.TempDir()
+	if _, err := captureStdoutRun(t, func() error {
+		return RunGen(context.Background(), GenOptions{OutputDir: outDir, Inputs: []string{inputPath}, SkipDocx: true, ProvenanceHeader: true})
+	}); err != nil {
+		t.Fatalf("RunGen error: %v", err)
+	}
+	for _, pattern := range []string{"*_en.md", "*_cn.md"} {
+		matches, _ := filepath.Glob(filepath.Join(outDir, pattern))
+		if len(matches) != 1 {
+			t.Fatalf("%s: %v", pattern, matches)
+		}
+		b, _ := os.ReadFile(matches[0])
+		first, rest, _ := strings.Cut(string(b), "\n")
+		p := listing.ParseProvenance(first)
+		if p["job"] != "job_1" || p["rules"] != "v1" || p["generated"] == "" {
+			t.Fatalf("unexpected header %q", first)
+		}
+		if !strings.HasPrefix(rest, "# ") {
+			t.Fatalf("listing should follow the header: %q", rest)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package app
+
+import (
+	"context"
+	"errors"
+
+	"syl-listing-pro/internal/config"
+	"syl-listing-pro/internal/i18n"
+	"syl-listing-pro/internal/publish"
+)
+
+// outputPublisher 在任务成功后把产物上传到对象存储。
+type outputPublisher struct {
+	uploader publish.Uploader
+	prefix   string
+	runID    string
+}
+
+func newOutputPublisher(runID string) (*outputPublisher, error) {
+	cfg, err := config.LoadPublishConfig()
+	if err != nil {
+		if errors.Is(err, config.ErrPublishNotConfigured) {
+			return nil, i18n.Errorf("尚未配置上传，需要在 ~/.syl-listing-pro/.env 中设置 SYL_PUBLISH_PROVIDER 等参数")
+		}
+		return nil, err
+	}
+	uploader, err := publish.New(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &outputPublisher{uploader: uploader, prefix: cfg.Prefix, runID: runID}, nil
+}
+
+// publish 逐个上传产物并返回远端 URL；上传失败只记录日志，不影响任务结果。
+func (p *outputPublisher) publish(ctx context.Context, log *Logger, prefix string, paths []string) []string {
+	if p == nil {
+		return nil
+	}
+	urls := make([]string, 0, len(paths))
+	for _, local := range paths {
+		remote, err := p.uploader.Upload(ctx, local, publish.ObjectKey(p.prefix, p.runID, local))
+		if err != nil {
+			log.Info(i18n.T("%s 上传失败：%s：%v", prefix, mustAbsPath(local), err))
+			continue
+		}
+		log.Info(i18n.T("%s 已上传：%s", prefix, remote))
+		urls = append(urls, remote)
+	}
+	return urls
+}
@@ -0,0 +1,48 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"syl-listing-pro/internal/client"
+	"syl-listing-pro/internal/i18n"
+)
+
+// RunQuota 查询当前 KEY 的剩余额度，便于大批量生成前确认。
+func RunQuota(ctx context.Context, w io.Writer, profile string) error {
+	sylKey, err := loadSYLKeyForRun(profile)
+	if err != nil {
+		return err
+	}
+	api := client.New(resolveWorkerBaseURL())
+	ex, err := api.Exchange(ctx, sylKey)
+	if err != nil {
+		return withRemediation(err)
+	}
+	q, err := api.Quota(ctx, ex.AccessToken)
+	if err != nil {
+		if errors.Is(err, client.ErrQuotaUnsupported) {
+			return i18n.Errorf("服务端暂不支持额度查询")
+		}
+		return err
+	}
+	tenant := q.TenantID
+	if tenant == "" {
+		tenant = ex.TenantID
+	}
+	fmt.Fprint(w, i18n.T("租户：%s\n", tenant))
+	if q.CreditsLimit > 0 {
+		fmt.Fprint(w, i18n.T("剩余 credits：%.2f / %.2f\n", q.CreditsRemaining, q.CreditsLimit))
+	} else {
+		fmt.Fprint(w, i18n.T("剩余 credits：%.2f\n", q.CreditsRemaining))
+	}
+	if q.TokensRemaining > 0 {
+		fmt.Fprint(w, i18n.T("剩余 tokens：%d\n", q.TokensRemaining))
+	}
+	if q.ResetAt != "" {
+		fmt.Fprint(w, i18n.T("重置时间：%s\n", q.ResetAt))
+	}
+	return nil
+}
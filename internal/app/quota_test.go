@@ -0,0 +1,48 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRunQuota(t *testing.T) {
+	prepareRunGenHome(t)
+	supported := true
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/auth/exchange":
+			_, _ = io.WriteString(w, `{"access_token":"at","tenant_id":"demo","expires_in":3600}`)
+		case "/v1/quota":
+			if !supported {
+				http.NotFound(w, r)
+				return
+			}
+			_, _ = io.WriteString(w, `{"credits_remaining":42.5,"credits_limit":100,"reset_at":"2026-11-01"}`)
+		default:
+			t.Fatalf("unexpected request: %s", r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+	oldBase := workerBaseURL
+	workerBaseURL = ts.URL
+	defer func() { workerBaseURL = oldBase }()
+
+	var buf bytes.Buffer
+	if err := RunQuota(context.Background(), &buf, ""); err != nil {
+		t.Fatalf("RunQuota error: %v", err)
+	}
+	for _, want := range []string{"租户：demo", "剩余 credits：42.50 / 100.00", "重置时间：2026-11-01"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Fatalf("missing %q in:\n%s", want, buf.String())
+		}
+	}
+	supported = false
+	if err := RunQuota(context.Background(), &buf, ""); err == nil || !strings.Contains(err.Error(), "暂不支持") {
+		t.Fatalf("unexpected err: %v", err)
+	}
+}
@@ -0,0 +1,36 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+
+	"syl-listing-pro/internal/client"
+	"syl-listing-pro/internal/i18n"
+)
+
+// remediationHint 按错误分类给出处理建议；没有针对性建议时返回空字符串。
+func remediationHint(err error) string {
+	switch {
+	case errors.Is(err, client.ErrUnauthorized):
+		return i18n.T("KEY 无效或已过期，可执行 syl-listing-pro auth check 查看原因")
+	case errors.Is(err, client.ErrQuotaExceeded):
+		return i18n.T("额度已用完，可执行 syl-listing-pro quota 查看剩余额度")
+	case errors.Is(err, client.ErrJobNotFound):
+		return i18n.T("服务端已找不到该任务（可能已过期清理），可用 requeue 重新生成")
+	}
+	return ""
+}
+
+// withRemediation 在错误后附加处理建议，用于直接返回给命令行的错误。
+func withRemediation(err error) error {
+	if hint := remediationHint(err); hint != "" {
+		return fmt.Errorf("%w\n%s", err, hint)
+	}
+	return err
+}
+
+func logRemediation(log *Logger, prefix string, err error) {
+	if hint := remediationHint(err); hint != "" {
+		log.Info(fmt.Sprintf("%s %s", prefix, hint))
+	}
+}
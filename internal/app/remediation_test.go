@@ -0,0 +1,37 @@
+package app
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunGen_UnauthorizedSuggestsAuthCheck(t *testing.T) {
+	prepareRunGenHome(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = io.WriteString(w, `{"error":"invalid key"}`)
+	}))
+	defer ts.Close()
+	oldBase := workerBaseURL
+	workerBaseURL = ts.URL
+	defer func() { workerBaseURL = oldBase }()
+
+	inputPath := filepath.Join(t.TempDir(), "req.md")
+	if err := os.WriteFile(inputPath, []byte("# 输入"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	err := RunGen(context.Background(), GenOptions{OutputDir: t.TempDir(), Inputs: []string{inputPath}, SkipDocx: true})
+	if err == nil || !strings.Contains(err.Error(), "auth check") {
+		t.Fatalf("expected auth check hint, got %v", err)
+	}
+	if remediationHint(io.EOF) != "" {
+		t.Fatal("unexpected hint for generic error")
+	}
+}
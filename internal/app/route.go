@@ -0,0 +1,116 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"syl-listing-pro/internal/config"
+	"syl-listing-pro/internal/i18n"
+	"syl-listing-pro/internal/input"
+)
+
+// matchRoute 判断需求文件是否匹配路由模式：通配符依次对原路径、绝对路径与文件名匹配，
+// 以 / 结尾的模式匹配该目录（含子目录）下的文件。
+func matchRoute(pattern, path string) bool {
+	abs := mustAbsPath(path)
+	if strings.HasSuffix(pattern, "/") || strings.HasSuffix(pattern, string(filepath.Separator)) {
+		dir := mustAbsPath(pattern) + string(filepath.Separator)
+		return strings.HasPrefix(abs, dir)
+	}
+	for _, candidate := range []string{path, abs, filepath.Base(path)} {
+		if ok, _ := filepath.Match(pattern, candidate); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// routeInputs 按路由把需求文件分到各 KEY 配置；没有匹配的文件归入 fallback。
+func routeInputs(files []input.RequirementFile, routes []config.Route, fallback string) map[string][]string {
+	groups := map[string][]string{}
+	for _, f := range files {
+		profile := fallback
+		for _, r := range routes {
+			if matchRoute(r.Pattern, f.Path) {
+				profile = r.Profile
+				break
+			}
+		}
+		groups[profile] = append(groups[profile], f.Path)
+	}
+	return groups
+}
+
+// runRouted 按路由文件把输入分给多个租户，各自换取令牌并发生成，产物写到 <输出目录>/<KEY 配置名>。
+func runRouted(ctx context.Context, opts GenOptions) error {
+	if opts.ResumeLast || opts.RequeueRunID != "" || opts.Record != "" {
+		return i18n.Errorf("--route 不能与 --resume-last、requeue、--record 同时使用")
+	}
+	if opts.Pick || opts.AutoPick || opts.Copy != "" || opts.Open || opts.Combined != "" || len(opts.Formats) > 0 || opts.Stdout != "" {
+		return i18n.Errorf("--route 不能与 --pick、--auto-pick、--copy、--open、--combined、--format、--stdout 同时使用")
+	}
+	if opts.Confirm {
+		return i18n.Errorf("--route 不能与 --confirm 同时使用：各租户会同时在终端询问确认")
+	}
+	if opts.EventsFD > 0 || opts.EventsFile != "" {
+		return i18n.Errorf("--route 暂不支持 --events-fd / --events-file")
+	}
+	routes, err := config.LoadRoutes(opts.RouteFile)
+	if err != nil {
+		return err
+	}
+	fallback, err := resolveKeyProfile(opts.KeyProfile)
+	if err != nil {
+		return err
+	}
+	files, err := input.Discover(opts.Inputs)
+	if err != nil {
+		return err
+	}
+	groups := routeInputs(files, routes, fallback)
+	profiles := make([]string, 0, len(groups))
+	for p := range groups {
+		profiles = append(profiles, p)
+	}
+	sort.Strings(profiles)
+
+	log, err := NewLogger(opts.Verbose, opts.LogFile)
+	if err != nil {
+		return err
+	}
+	if err := log.SetLogFormat(opts.LogFormat); err != nil {
+		_ = log.Close()
+		return err
+	}
+	for _, p := range profiles {
+		log.Info(i18n.T("路由：KEY 配置 %s 处理 %d 个需求文件，输出到 %s", p, len(groups[p]), mustAbsPath(filepath.Join(opts.OutputDir, p))))
+	}
+	defer log.Close()
+	pause := &pauseGate{}
+	stopPause := watchPause(ctx, log, pause, pauseKeys(opts))
+	defer stopPause()
+
+	errs := make([]error, len(profiles))
+	var wg sync.WaitGroup
+	for i, p := range profiles {
+		sub := opts
+		sub.RouteFile = ""
+		sub.KeyProfile = p
+		sub.Inputs = groups[p]
+		sub.OutputDir = filepath.Join(opts.OutputDir, p)
+		sub.pause = pause
+		wg.Add(1)
+		go func(i int, p string) {
+			defer wg.Done()
+			if err := RunGen(ctx, sub); err != nil {
+				errs[i] = i18n.Errorf("KEY 配置 %s：%w", p, err)
+			}
+		}(i, p)
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
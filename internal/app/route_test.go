@@ -0,0 +1,115 @@
+package app
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"syl-listing-pro/internal/config"
+	"syl-listing-pro/internal/input"
+)
+
+func TestRouteInputs(t *testing.T) {
+	dir := t.TempDir()
+	files := []input.RequirementFile{
+		{Path: filepath.Join(dir, "brand-a", "x.md")},
+		{Path: filepath.Join(dir, "shoe_b.md")},
+		{Path: filepath.Join(dir, "other.md")},
+	}
+	routes := []config.Route{
+		{Pattern: filepath.Join(dir, "brand-a") + "/", Profile: "tenant_a"},
+		{Pattern: "*_b.md", Profile: "tenant_b"},
+	}
+	got := routeInputs(files, routes, "default")
+	if len(got["tenant_a"]) != 1 || len(got["tenant_b"]) != 1 || len(got["default"]) != 1 {
+		t.Fatalf("unexpected groups: %v", got)
+	}
+	if matchRoute(filepath.Join(dir, "brand")+"/", files[0].Path) {
+		t.Fatalf("directory prefix must match whole path components")
+	}
+}
+
+func TestRunGen_RoutesInputsToKeyProfiles(t *testing.T) {
+	stubDocxConverter(t)
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	envPath := filepath.Join(home, ".syl-listing-pro", ".env")
+	if err := os.MkdirAll(filepath.Dir(envPath), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(envPath, []byte("SYL_LISTING_KEY=key-default\nSYL_LISTING_KEY_tenant_b=key-b\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	inDir := t.TempDir()
+	for name, body := range map[string]string{"a.md": "# A\n\nbody-a", "shoe_b.md": "# B\n\nbody-b"} {
+		if err := os.WriteFile(filepath.Join(inDir, name), []byte(body), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	routeFile := filepath.Join(t.TempDir(), "routes.txt")
+	if err := os.WriteFile(routeFile, []byte("*_b.md tenant_b\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var mu sync.Mutex
+	submitted := map[string]string{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/v1/auth/exchange":
+			tenant := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer key-")
+			_, _ = io.WriteString(w, `{"access_token":"at-`+tenant+`","tenant_id":"`+tenant+`","expires_in":3600}`)
+		case r.URL.Path == "/v1/generate":
+			body, _ := io.ReadAll(r.Body)
+			job := "job_a"
+			if strings.Contains(string(body), "body-b") {
+				job = "job_b"
+			}
+			mu.Lock()
+			submitted[job] = r.Header.Get("Authorization")
+			mu.Unlock()
+			_, _ = io.WriteString(w, `{"job_id":"`+job+`","status":"queued"}`)
+		case strings.HasSuffix(r.URL.Path, "/events"):
+			job := strings.Split(r.URL.Path, "/")[3]
+			writeSSEEvent(t, w, "status", `{"job_id":"`+job+`","status":"succeeded"}`)
+		case strings.HasSuffix(r.URL.Path, "/result"):
+			_, _ = io.WriteString(w, `{"en_markdown":"# EN","cn_markdown":"# CN"}`)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+	oldBase := workerBaseURL
+	oldTimeout := streamTimeoutSecond
+	workerBaseURL = ts.URL
+	streamTimeoutSecond = 5
+	defer func() {
+		workerBaseURL = oldBase
+		streamTimeoutSecond = oldTimeout
+	}()
+
+	out := t.TempDir()
+	if err := RunGen(context.Background(), GenOptions{OutputDir: out, Inputs: []string{inDir}, RouteFile: routeFile, SkipDocx: true}); err != nil {
+		t.Fatalf("RunGen error: %v", err)
+	}
+	if submitted["job_a"] != "Bearer at-default" || submitted["job_b"] != "Bearer at-b" {
+		t.Fatalf("jobs not submitted with their tenant tokens: %v", submitted)
+	}
+	for _, sub := range []string{"default", "tenant_b"} {
+		matches, _ := filepath.Glob(filepath.Join(out, sub, "*_en.md"))
+		if len(matches) != 1 {
+			t.Fatalf("%s outputs=%v", sub, matches)
+		}
+	}
+
+	err := RunGen(context.Background(), GenOptions{OutputDir: out, Inputs: []string{inDir}, RouteFile: routeFile, Pick: true})
+	if err == nil || !strings.Contains(err.Error(), "--route") {
+		t.Fatalf("err=%v want --route conflict", err)
+	}
+}
@@ -1,16 +1,26 @@
 package app
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
+
+	"syl-listing-pro/internal/client"
+	"syl-listing-pro/internal/manifest"
+	"syl-listing-pro/internal/processor"
+	"syl-listing-pro/internal/util"
 )
 
 func writeKeyEnvForTest(t *testing.T, home string) {
@@ -340,3 +350,964 @@ func TestRunGen_ContextCancelReturnsQuickly(t *testing.T) {
 		t.Fatalf("RunGen should return quickly on cancel")
 	}
 }
+
+func TestRunGen_DedupContentSubmitsOnce(t *testing.T) {
+	stubDocxConverter(t)
+	prepareRunGenHome(t)
+
+	var generateCalls atomic.Int64
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/auth/exchange":
+			_, _ = io.WriteString(w, `{"access_token":"at","tenant_id":"demo","expires_in":3600}`)
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/generate":
+			generateCalls.Add(1)
+			_, _ = io.WriteString(w, `{"job_id":"job_dedup","status":"queued"}`)
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/jobs/job_dedup/events":
+			writeSSEEvent(t, w, "status", `{"job_id":"job_dedup","tenant_id":"demo","status":"succeeded","updated_at":"2026-03-13T00:00:02Z"}`)
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/jobs/job_dedup/result":
+			_, _ = io.WriteString(w, `{"en_markdown":"# EN","cn_markdown":"# CN"}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	oldBase := workerBaseURL
+	oldTimeout := streamTimeoutSecond
+	workerBaseURL = ts.URL
+	streamTimeoutSecond = 5
+	defer func() {
+		workerBaseURL = oldBase
+		streamTimeoutSecond = oldTimeout
+	}()
+
+	dir := t.TempDir()
+	for _, name := range []string{"a.md", "b.md"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("# 同一份需求"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	outDir := t.TempDir()
+	if _, err := captureStdoutRun(t, func() error {
+		return RunGen(context.Background(), GenOptions{OutputDir: outDir, Inputs: []string{dir}, DedupContent: true})
+	}); err != nil {
+		t.Fatalf("RunGen error: %v", err)
+	}
+	if got := generateCalls.Load(); got != 1 {
+		t.Fatalf("generate calls=%d want=1", got)
+	}
+	for _, prefix := range []string{"a_", "b_"} {
+		matches, _ := filepath.Glob(filepath.Join(outDir, prefix+"*_en.md"))
+		if len(matches) != 1 {
+			t.Fatalf("expected one %sxxxx_en.md, got %v", prefix, matches)
+		}
+	}
+}
+
+func TestRunGen_IncrementalSkipsProcessedInputs(t *testing.T) {
+	stubDocxConverter(t)
+	prepareRunGenHome(t)
+	ts := newRunGenFastSuccessServer(t)
+	defer ts.Close()
+
+	oldBase := workerBaseURL
+	oldTimeout := streamTimeoutSecond
+	workerBaseURL = ts.URL
+	streamTimeoutSecond = 5
+	defer func() {
+		workerBaseURL = oldBase
+		streamTimeoutSecond = oldTimeout
+	}()
+
+	dir := t.TempDir()
+	inputPath := filepath.Join(dir, "a.md")
+	if err := os.WriteFile(inputPath, []byte("# 第一版"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	outDir := t.TempDir()
+	run := func() string {
+		out, err := captureStdoutRun(t, func() error {
+			return RunGen(context.Background(), GenOptions{OutputDir: outDir, Inputs: []string{dir}, Incremental: true})
+		})
+		if err != nil {
+			t.Fatalf("RunGen error: %v", err)
+		}
+		return out
+	}
+
+	run()
+	if out := run(); !strings.Contains(out, "没有新增或变更的需求文件") {
+		t.Fatalf("second run should skip, got: %s", out)
+	}
+	if err := os.WriteFile(inputPath, []byte("# 第二版"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	run()
+	matches, _ := filepath.Glob(filepath.Join(outDir, "a_*_en.md"))
+	if len(matches) != 2 {
+		t.Fatalf("expected outputs from two generations, got %v", matches)
+	}
+
+	// 复制出的新文件内容与已处理的文件相同，但路径不同，仍需生成。
+	if err := os.WriteFile(filepath.Join(dir, "b.md"), []byte("# 第二版"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if out := run(); strings.Contains(out, "b.md 内容未变化") {
+		t.Fatalf("copied file at a new path should not be skipped: %s", out)
+	}
+	if matches, _ := filepath.Glob(filepath.Join(outDir, "b_*_en.md")); len(matches) != 1 {
+		t.Fatalf("expected output for the copied file, got %v", matches)
+	}
+}
+
+func TestRunGen_RetryFailedReattachesAfterStreamTimeout(t *testing.T) {
+	stubDocxConverter(t)
+	prepareRunGenHome(t)
+
+	var generateCalls, eventCalls atomic.Int64
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/auth/exchange":
+			_, _ = io.WriteString(w, `{"access_token":"at","tenant_id":"demo","expires_in":3600}`)
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/generate":
+			n := generateCalls.Add(1)
+			_, _ = io.WriteString(w, fmt.Sprintf(`{"job_id":"job_%d","status":"queued"}`, n))
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/jobs/job_1/events":
+			if eventCalls.Add(1) == 1 {
+				w.Header().Set("Content-Type", "text/event-stream")
+				<-r.Context().Done()
+				return
+			}
+			writeSSEEvent(t, w, "status", `{"job_id":"job_1","tenant_id":"demo","status":"succeeded","updated_at":"2026-03-13T00:00:02Z"}`)
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/jobs/job_1/result":
+			_, _ = io.WriteString(w, `{"en_markdown":"# EN","cn_markdown":"# CN"}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	oldBase := workerBaseURL
+	oldTimeout := streamTimeoutSecond
+	workerBaseURL = ts.URL
+	streamTimeoutSecond = 1
+	defer func() {
+		workerBaseURL = oldBase
+		streamTimeoutSecond = oldTimeout
+	}()
+
+	inputPath := filepath.Join(t.TempDir(), "req.md")
+	if err := os.WriteFile(inputPath, []byte("# 输入"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	out, err := captureStdoutRun(t, func() error {
+		return RunGen(context.Background(), GenOptions{OutputDir: t.TempDir(), Inputs: []string{inputPath}, RetryFailed: 1})
+	})
+	if err != nil {
+		t.Fatalf("RunGen error: %v, out=%s", err, out)
+	}
+	if !strings.Contains(out, "第 1/1 轮重试") || !strings.Contains(out, "继续跟踪已提交任务（job_id=job_1）") || !strings.Contains(out, "成功 1，失败 0") {
+		t.Fatalf("unexpected output: %s", out)
+	}
+	if got := generateCalls.Load(); got != 1 {
+		t.Fatalf("stream timeout should reattach instead of resubmitting, generate calls=%d", got)
+	}
+	if got := eventCalls.Load(); got != 2 {
+		t.Fatalf("event stream calls=%d want=2", got)
+	}
+}
+
+func TestRunGen_RetryFailedResubmitsSubmitTimeoutWithSameKey(t *testing.T) {
+	stubDocxConverter(t)
+	prepareRunGenHome(t)
+
+	var mu sync.Mutex
+	var keys []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/auth/exchange":
+			_, _ = io.WriteString(w, `{"access_token":"at","tenant_id":"demo","expires_in":3600}`)
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/generate":
+			mu.Lock()
+			keys = append(keys, r.Header.Get("Idempotency-Key"))
+			n := len(keys)
+			mu.Unlock()
+			if n <= 3 {
+				// 前 3 次（客户端内部的全部尝试）都超过 --submit-timeout。
+				select {
+				case <-r.Context().Done():
+				case <-time.After(2 * time.Second):
+				}
+				return
+			}
+			_, _ = io.WriteString(w, `{"job_id":"job_1","status":"queued"}`)
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/jobs/job_1/events":
+			writeSSEEvent(t, w, "status", `{"job_id":"job_1","tenant_id":"demo","status":"succeeded","updated_at":"2026-03-13T00:00:02Z"}`)
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/jobs/job_1/result":
+			_, _ = io.WriteString(w, `{"en_markdown":"# EN","cn_markdown":"# CN"}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	oldBase := workerBaseURL
+	workerBaseURL = ts.URL
+	defer func() { workerBaseURL = oldBase }()
+
+	inputPath := filepath.Join(t.TempDir(), "req.md")
+	if err := os.WriteFile(inputPath, []byte("# 输入"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	out, err := captureStdoutRun(t, func() error {
+		return RunGen(context.Background(), GenOptions{
+			OutputDir:     t.TempDir(),
+			Inputs:        []string{inputPath},
+			RetryFailed:   1,
+			StageTimeouts: map[client.Stage]time.Duration{client.StageSubmit: 100 * time.Millisecond},
+		})
+	})
+	if err != nil {
+		t.Fatalf("RunGen error: %v, out=%s", err, out)
+	}
+	if strings.Contains(out, "已取消") || !strings.Contains(out, "第 1/1 轮重试") || !strings.Contains(out, "成功 1，失败 0") {
+		t.Fatalf("submit timeout should be retried as a failure, out=%s", out)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(keys) != 4 {
+		t.Fatalf("generate calls=%d want=4", len(keys))
+	}
+	for _, k := range keys {
+		if k == "" || k != keys[0] {
+			t.Fatalf("resubmission should keep the idempotency key: %v", keys)
+		}
+	}
+}
+
+func TestRunGen_RetryFailedRotatesKeyAfterFailedJob(t *testing.T) {
+	stubDocxConverter(t)
+	prepareRunGenHome(t)
+
+	var mu sync.Mutex
+	var keys []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/auth/exchange":
+			_, _ = io.WriteString(w, `{"access_token":"at","tenant_id":"demo","expires_in":3600}`)
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/generate":
+			mu.Lock()
+			keys = append(keys, r.Header.Get("Idempotency-Key"))
+			n := len(keys)
+			mu.Unlock()
+			_, _ = io.WriteString(w, fmt.Sprintf(`{"job_id":"job_%d","status":"queued"}`, n))
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/jobs/job_1/events":
+			writeSSEEvent(t, w, "status", `{"job_id":"job_1","tenant_id":"demo","status":"failed","error":"upstream timeout","updated_at":"2026-03-13T00:00:02Z"}`)
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/jobs/job_2/events":
+			writeSSEEvent(t, w, "status", `{"job_id":"job_2","tenant_id":"demo","status":"succeeded","updated_at":"2026-03-13T00:00:02Z"}`)
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/jobs/job_2/result":
+			_, _ = io.WriteString(w, `{"en_markdown":"# EN","cn_markdown":"# CN"}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	oldBase := workerBaseURL
+	workerBaseURL = ts.URL
+	defer func() { workerBaseURL = oldBase }()
+
+	inputPath := filepath.Join(t.TempDir(), "req.md")
+	if err := os.WriteFile(inputPath, []byte("# 输入"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	out, err := captureStdoutRun(t, func() error {
+		return RunGen(context.Background(), GenOptions{OutputDir: t.TempDir(), Inputs: []string{inputPath}, RetryFailed: 1})
+	})
+	if err != nil {
+		t.Fatalf("RunGen error: %v, out=%s", err, out)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(keys) != 2 || keys[0] == keys[1] {
+		t.Fatalf("failed job should be resubmitted with a new key: %v", keys)
+	}
+}
+
+func TestRunGen_RetryFailedSkipsPermanentFailures(t *testing.T) {
+	stubDocxConverter(t)
+	prepareRunGenHome(t)
+
+	var generateCalls atomic.Int64
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/auth/exchange":
+			_, _ = io.WriteString(w, `{"access_token":"at","tenant_id":"demo","expires_in":3600}`)
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/generate":
+			generateCalls.Add(1)
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = io.WriteString(w, "bad req")
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	oldBase := workerBaseURL
+	workerBaseURL = ts.URL
+	defer func() { workerBaseURL = oldBase }()
+
+	inputPath := filepath.Join(t.TempDir(), "req.md")
+	if err := os.WriteFile(inputPath, []byte("# 输入"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	_, err := captureStdoutRun(t, func() error {
+		return RunGen(context.Background(), GenOptions{OutputDir: t.TempDir(), Inputs: []string{inputPath}, RetryFailed: 2})
+	})
+	if err == nil || !strings.Contains(err.Error(), "存在失败任务") {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if got := generateCalls.Load(); got != 1 {
+		t.Fatalf("permanent failure should not be retried, generate calls=%d", got)
+	}
+}
+
+func TestRunGen_SkipDocxDoesNotConvert(t *testing.T) {
+	prepareRunGenHome(t)
+
+	oldConvert := convertMarkdownToDocxFunc
+	convertMarkdownToDocxFunc = func(_ context.Context, _ string, _ string) (string, error) {
+		return "", errors.New("converter broken")
+	}
+	defer func() { convertMarkdownToDocxFunc = oldConvert }()
+
+	ts := newRunGenFastSuccessServer(t)
+	defer ts.Close()
+	oldBase := workerBaseURL
+	oldTimeout := streamTimeoutSecond
+	workerBaseURL = ts.URL
+	streamTimeoutSecond = 5
+	defer func() {
+		workerBaseURL = oldBase
+		streamTimeoutSecond = oldTimeout
+	}()
+
+	inputPath := filepath.Join(t.TempDir(), "req.md")
+	if err := os.WriteFile(inputPath, []byte("# 输入"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	outDir := t.TempDir()
+	if _, err := captureStdoutRun(t, func() error {
+		return RunGen(context.Background(), GenOptions{OutputDir: outDir, Inputs: []string{inputPath}, SkipDocx: true})
+	}); err != nil {
+		t.Fatalf("RunGen error: %v", err)
+	}
+	if matches, _ := filepath.Glob(filepath.Join(outDir, "*.docx")); len(matches) != 0 {
+		t.Fatalf("unexpected docx outputs: %v", matches)
+	}
+	if matches, _ := filepath.Glob(filepath.Join(outDir, "*.md")); len(matches) != 2 {
+		t.Fatalf("expected en/cn markdown, got %v", matches)
+	}
+}
+
+func TestRunGen_OnConflictSkipKeepsExistingOutputs(t *testing.T) {
+	prepareRunGenHome(t)
+
+	ts := newRunGenFastSuccessServer(t)
+	defer ts.Close()
+	oldBase := workerBaseURL
+	oldTimeout := streamTimeoutSecond
+	workerBaseURL = ts.URL
+	streamTimeoutSecond = 5
+	defer func() {
+		workerBaseURL = oldBase
+		streamTimeoutSecond = oldTimeout
+	}()
+
+	inputPath := filepath.Join(t.TempDir(), "req.md")
+	if err := os.WriteFile(inputPath, []byte("# 输入"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	outDir := t.TempDir()
+	existing := filepath.Join(outDir, "req_en.md")
+	if err := os.WriteFile(existing, []byte("old"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	sidecar := filepath.Join(outDir, "req.json")
+	if err := os.WriteFile(sidecar, []byte(`{"job_id":"old"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	catalog := filepath.Join(t.TempDir(), "catalog.md")
+	out, err := captureStdoutRun(t, func() error {
+		return RunGen(context.Background(), GenOptions{OutputDir: outDir, Inputs: []string{inputPath}, SkipDocx: true, OnConflict: "skip", Label: "spring", Append: catalog})
+	})
+	if err != nil {
+		t.Fatalf("RunGen error: %v", err)
+	}
+	if data, _ := os.ReadFile(existing); string(data) != "old" {
+		t.Fatalf("existing output overwritten: %q", data)
+	}
+	if data, _ := os.ReadFile(sidecar); string(data) != `{"job_id":"old"}` {
+		t.Fatalf("existing sidecar rewritten: %s", data)
+	}
+	if _, err := os.Stat(catalog); !os.IsNotExist(err) {
+		t.Fatalf("skipped task should not be appended to the catalog: %v", err)
+	}
+	if !strings.Contains(out, "输出已存在，跳过写入") || !strings.Contains(out, "成功 0，失败 0") || !strings.Contains(out, "跳过 1 个任务") {
+		t.Fatalf("unexpected output: %s", out)
+	}
+	if matches, _ := filepath.Glob(filepath.Join(outDir, "*.md")); len(matches) != 1 {
+		t.Fatalf("unexpected markdown outputs: %v", matches)
+	}
+	m, _, err := manifest.LoadLatest(filepath.Join(os.Getenv("HOME"), ".syl-listing-pro", "runs"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(m.Tasks) != 1 || m.Tasks[0].Status != manifest.StatusSkipped {
+		t.Fatalf("task should be recorded as skipped: %+v", m.Tasks)
+	}
+}
+
+func TestRunGen_OutLayoutPerInput(t *testing.T) {
+	prepareRunGenHome(t)
+
+	ts := newRunGenFastSuccessServer(t)
+	defer ts.Close()
+	oldBase := workerBaseURL
+	oldTimeout := streamTimeoutSecond
+	workerBaseURL = ts.URL
+	streamTimeoutSecond = 5
+	defer func() {
+		workerBaseURL = oldBase
+		streamTimeoutSecond = oldTimeout
+	}()
+
+	inputPath := filepath.Join(t.TempDir(), "req.md")
+	if err := os.WriteFile(inputPath, []byte("# 输入"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	outDir := t.TempDir()
+	if _, err := captureStdoutRun(t, func() error {
+		return RunGen(context.Background(), GenOptions{OutputDir: outDir, Inputs: []string{inputPath}, SkipDocx: true, OutLayout: "per-input"})
+	}); err != nil {
+		t.Fatalf("RunGen error: %v", err)
+	}
+	if matches, _ := filepath.Glob(filepath.Join(outDir, "req", "*.md")); len(matches) != 2 {
+		t.Fatalf("expected en/cn under per-input dir, got %v", matches)
+	}
+}
+
+func TestRunGen_PublishUploadsOutputsAndRecordsURLs(t *testing.T) {
+	prepareRunGenHome(t)
+
+	var uploads atomic.Int64
+	store := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 ") {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		uploads.Add(1)
+	}))
+	defer store.Close()
+	home := os.Getenv("HOME")
+	env := fmt.Sprintf("SYL_PUBLISH_PROVIDER=s3\nSYL_PUBLISH_BUCKET=bkt\nSYL_PUBLISH_ACCESS_KEY_ID=id\nSYL_PUBLISH_SECRET_ACCESS_KEY=secret\nSYL_PUBLISH_ENDPOINT=%s\nSYL_PUBLISH_PATH_STYLE=true\n", store.URL)
+	f, err := os.OpenFile(filepath.Join(home, ".syl-listing-pro", ".env"), os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = f.WriteString("\n" + env)
+	_ = f.Close()
+
+	ts := newRunGenFastSuccessServer(t)
+	defer ts.Close()
+	oldBase := workerBaseURL
+	oldTimeout := streamTimeoutSecond
+	workerBaseURL = ts.URL
+	streamTimeoutSecond = 5
+	defer func() {
+		workerBaseURL = oldBase
+		streamTimeoutSecond = oldTimeout
+	}()
+
+	inputPath := filepath.Join(t.TempDir(), "req.md")
+	if err := os.WriteFile(inputPath, []byte("# 输入"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := captureStdoutRun(t, func() error {
+		return RunGen(context.Background(), GenOptions{OutputDir: t.TempDir(), Inputs: []string{inputPath}, SkipDocx: true, Publish: true})
+	}); err != nil {
+		t.Fatalf("RunGen error: %v", err)
+	}
+	// EN/CN Markdown 与记录分节字符数的 JSON 附带文件。
+	if uploads.Load() != 3 {
+		t.Fatalf("uploads=%d want=3", uploads.Load())
+	}
+	runsDir, _ := util.DefaultRunsDir()
+	m, _, err := manifest.LoadLatest(runsDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(m.Tasks) != 1 || len(m.Tasks[0].RemoteURLs) != 3 || !strings.HasPrefix(m.Tasks[0].RemoteURLs[0], store.URL+"/bkt/") {
+		t.Fatalf("unexpected remote urls: %+v", m.Tasks)
+	}
+}
+
+type recordingProcessor struct {
+	mu      sync.Mutex
+	results []processor.Result
+}
+
+func (p *recordingProcessor) Name() string { return "recorder" }
+
+func (p *recordingProcessor) Process(_ context.Context, res processor.Result) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.results = append(p.results, res)
+	return nil
+}
+
+type failingProcessor struct{}
+
+func (failingProcessor) Name() string { return "broken" }
+
+func (failingProcessor) Process(context.Context, processor.Result) error {
+	return errors.New("cms down")
+}
+
+func TestRunGen_ProcessorsReceiveResults(t *testing.T) {
+	prepareRunGenHome(t)
+
+	ts := newRunGenFastSuccessServer(t)
+	defer ts.Close()
+	oldBase := workerBaseURL
+	oldTimeout := streamTimeoutSecond
+	workerBaseURL = ts.URL
+	streamTimeoutSecond = 5
+	defer func() {
+		workerBaseURL = oldBase
+		streamTimeoutSecond = oldTimeout
+	}()
+
+	inputPath := filepath.Join(t.TempDir(), "req.md")
+	if err := os.WriteFile(inputPath, []byte("# 输入"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	rec := &recordingProcessor{}
+	out, err := captureStdoutRun(t, func() error {
+		return RunGen(context.Background(), GenOptions{
+			OutputDir:  t.TempDir(),
+			Inputs:     []string{inputPath},
+			SkipDocx:   true,
+			Processors: []processor.Processor{failingProcessor{}, rec},
+		})
+	})
+	if err != nil {
+		t.Fatalf("RunGen error: %v", err)
+	}
+	if !strings.Contains(out, "处理器 broken 失败：cms down") {
+		t.Fatalf("missing processor failure log: %s", out)
+	}
+	if len(rec.results) != 1 {
+		t.Fatalf("processor calls=%d want=1", len(rec.results))
+	}
+	res := rec.results[0]
+	if res.RunID == "" || res.JobID == "" || res.Listing.ENMarkdown == "" || len(res.Outputs) != 3 {
+		t.Fatalf("unexpected processor result: %+v", res)
+	}
+}
+
+func TestRunGen_ConsistencyReportWritesSidecar(t *testing.T) {
+	prepareRunGenHome(t)
+
+	ts := newRunGenFastSuccessServer(t)
+	defer ts.Close()
+	oldBase := workerBaseURL
+	oldTimeout := streamTimeoutSecond
+	workerBaseURL = ts.URL
+	streamTimeoutSecond = 5
+	defer func() {
+		workerBaseURL = oldBase
+		streamTimeoutSecond = oldTimeout
+	}()
+
+	inputPath := filepath.Join(t.TempDir(), "req.md")
+	if err := os.WriteFile(inputPath, []byte("# 输入"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	outDir := t.TempDir()
+	out, err := captureStdoutRun(t, func() error {
+		return RunGen(context.Background(), GenOptions{OutputDir: outDir, Inputs: []string{inputPath}, SkipDocx: true, ConsistencyReport: true})
+	})
+	if err != nil {
+		t.Fatalf("RunGen error: %v", err)
+	}
+	if !strings.Contains(out, "EN/CN 一致性：0 个任务存在不一致") {
+		t.Fatalf("missing consistency summary: %s", out)
+	}
+	matches, _ := filepath.Glob(filepath.Join(outDir, "*.json"))
+	if len(matches) != 1 {
+		t.Fatalf("expected one sidecar, got %v", matches)
+	}
+	b, _ := os.ReadFile(matches[0])
+	if !strings.Contains(string(b), `"consistent": true`) {
+		t.Fatalf("unexpected sidecar: %s", b)
+	}
+}
+
+func TestRunGen_StrictComplianceFailsOnBannedWords(t *testing.T) {
+	prepareRunGenHome(t)
+
+	ts := newRunGenFastSuccessServer(t)
+	defer ts.Close()
+	oldBase := workerBaseURL
+	oldTimeout := streamTimeoutSecond
+	workerBaseURL = ts.URL
+	streamTimeoutSecond = 5
+	defer func() {
+		workerBaseURL = oldBase
+		streamTimeoutSecond = oldTimeout
+	}()
+
+	dir := t.TempDir()
+	inputPath := filepath.Join(dir, "req.md")
+	if err := os.WriteFile(inputPath, []byte("# 输入"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	wordsPath := filepath.Join(dir, "banned.txt")
+	if err := os.WriteFile(wordsPath, []byte("# 测试\nen\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	outDir := t.TempDir()
+	out, err := captureStdoutRun(t, func() error {
+		return RunGen(context.Background(), GenOptions{OutputDir: outDir, Inputs: []string{inputPath}, SkipDocx: true, BannedWordsFile: wordsPath, StrictCompliance: true})
+	})
+	if err == nil {
+		t.Fatal("expected strict compliance failure")
+	}
+	for _, want := range []string{"命中禁用词：en", "合规检查：1 个任务命中禁用词", "成功 0，失败 1"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("missing %q in output: %s", want, out)
+		}
+	}
+	if md, _ := filepath.Glob(filepath.Join(outDir, "*_en.md")); len(md) != 1 {
+		t.Fatalf("outputs should still be written, got %v", md)
+	}
+	matches, _ := filepath.Glob(filepath.Join(outDir, "req_*.json"))
+	if len(matches) != 1 {
+		t.Fatalf("expected one sidecar, got %v", matches)
+	}
+	b, _ := os.ReadFile(matches[0])
+	if !strings.Contains(string(b), `"banned_words": [`) {
+		t.Fatalf("unexpected sidecar: %s", b)
+	}
+}
+
+func TestRunGen_SpellcheckReportsMisspellings(t *testing.T) {
+	prepareRunGenHome(t)
+
+	dir := t.TempDir()
+	inputPath := filepath.Join(dir, "req.md")
+	if err := os.WriteFile(inputPath, []byte("# 输入"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	dictPath := filepath.Join(dir, "words.txt")
+	if err := os.WriteFile(dictPath, []byte("title\nsteel\nbottle\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/auth/exchange":
+			_, _ = io.WriteString(w, `{"access_token":"at","tenant_id":"demo","expires_in":3600}`)
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/generate":
+			_, _ = io.WriteString(w, `{"job_id":"job_spell","status":"queued"}`)
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/jobs/job_spell/events":
+			writeSSEEvent(t, w, "status", `{"job_id":"job_spell","tenant_id":"demo","status":"succeeded"}`)
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/jobs/job_spell/result":
+			_, _ = io.WriteString(w, `{"en_markdown":"# Title\nSteel botle","cn_markdown":"# 标题\n钢瓶"}`)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+	oldBase := workerBaseURL
+	oldTimeout := streamTimeoutSecond
+	workerBaseURL = ts.URL
+	streamTimeoutSecond = 5
+	defer func() {
+		workerBaseURL = oldBase
+		streamTimeoutSecond = oldTimeout
+	}()
+
+	outDir := t.TempDir()
+	out, err := captureStdoutRun(t, func() error {
+		return RunGen(context.Background(), GenOptions{OutputDir: outDir, Inputs: []string{inputPath}, SkipDocx: true, Spellcheck: true, SpellDictFile: dictPath})
+	})
+	if err != nil {
+		t.Fatalf("RunGen error: %v\n%s", err, out)
+	}
+	for _, want := range []string{"疑似拼写错误：botle", "拼写检查：1 个任务有疑似拼写错误"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("missing %q in output: %s", want, out)
+		}
+	}
+	matches, _ := filepath.Glob(filepath.Join(outDir, "*.json"))
+	if len(matches) != 1 {
+		t.Fatalf("expected one sidecar, got %v", matches)
+	}
+	b, _ := os.ReadFile(matches[0])
+	if !strings.Contains(string(b), `"misspellings": [`) || !strings.Contains(string(b), "botle") {
+		t.Fatalf("unexpected sidecar: %s", b)
+	}
+}
+
+func TestRunGen_PersistsValidationReport(t *testing.T) {
+	prepareRunGenHome(t)
+
+	inputPath := filepath.Join(t.TempDir(), "req.md")
+	if err := os.WriteFile(inputPath, []byte("# 输入"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/auth/exchange":
+			_, _ = io.WriteString(w, `{"access_token":"at","tenant_id":"demo","expires_in":3600}`)
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/generate":
+			_, _ = io.WriteString(w, `{"job_id":"job_report","status":"queued"}`)
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/jobs/job_report/events":
+			writeSSEEvent(t, w, "status", `{"job_id":"job_report","tenant_id":"demo","status":"succeeded","updated_at":"2026-03-13T00:00:02Z"}`)
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/jobs/job_report/result":
+			_, _ = io.WriteString(w, `{"en_markdown":"# EN","cn_markdown":"# CN","validation_report":["第2条长度不满足约束: 251（规则区间 [200,250]，容差区间 [190,260]）"],"usage":{"input_tokens":1000,"output_tokens":200,"credits":1.5}}`)
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+	oldBase := workerBaseURL
+	oldTimeout := streamTimeoutSecond
+	workerBaseURL = ts.URL
+	streamTimeoutSecond = 5
+	defer func() {
+		workerBaseURL = oldBase
+		streamTimeoutSecond = oldTimeout
+	}()
+
+	outDir := t.TempDir()
+	out, err := captureStdoutRun(t, func() error {
+		return RunGen(context.Background(), GenOptions{OutputDir: outDir, Inputs: []string{inputPath}, SkipDocx: true})
+	})
+	if err != nil {
+		t.Fatalf("RunGen error: %v", err)
+	}
+	if !strings.Contains(out, "用量：tokens 1200（输入 1000 / 输出 200），credits 1.50") || !strings.Contains(out, "本次用量：tokens 1200") {
+		t.Fatalf("usage not rendered: %s", out)
+	}
+	if !strings.Contains(out, "校验报告：") || !strings.Contains(out, "第2条长度不满足约束: [190[200,250]260] < 251 高于上限") {
+		t.Fatalf("validation report not rendered: %s", out)
+	}
+	matches, _ := filepath.Glob(filepath.Join(outDir, "*.json"))
+	if len(matches) != 1 {
+		t.Fatalf("expected one sidecar, got %v", matches)
+	}
+	if b, _ := os.ReadFile(matches[0]); !strings.Contains(string(b), "validation_report") {
+		t.Fatalf("sidecar missing validation report: %s", b)
+	}
+	runsDir, _ := util.DefaultRunsDir()
+	m, _, err := manifest.LoadLatest(runsDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(m.Tasks) != 1 || len(m.Tasks[0].ValidationReport) != 1 || m.Tasks[0].Tokens != 1200 {
+		t.Fatalf("manifest missing validation report: %+v", m.Tasks)
+	}
+}
+
+func TestRunGen_HTMLReport(t *testing.T) {
+	prepareRunGenHome(t)
+
+	ts := newRunGenFastSuccessServer(t)
+	defer ts.Close()
+	oldBase := workerBaseURL
+	oldTimeout := streamTimeoutSecond
+	workerBaseURL = ts.URL
+	streamTimeoutSecond = 5
+	defer func() {
+		workerBaseURL = oldBase
+		streamTimeoutSecond = oldTimeout
+	}()
+
+	inputPath := filepath.Join(t.TempDir(), "req.md")
+	if err := os.WriteFile(inputPath, []byte("# 输入"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	outDir := t.TempDir()
+	if _, err := captureStdoutRun(t, func() error {
+		return RunGen(context.Background(), GenOptions{OutputDir: outDir, Inputs: []string{inputPath}, SkipDocx: true, HTMLReport: true})
+	}); err != nil {
+		t.Fatalf("RunGen error: %v", err)
+	}
+	matches, _ := filepath.Glob(filepath.Join(outDir, "report_*.html"))
+	if len(matches) != 1 {
+		t.Fatalf("expected one html report, got %v", matches)
+	}
+	b, _ := os.ReadFile(matches[0])
+	if !strings.Contains(string(b), "succeeded") || !strings.Contains(string(b), "_en.md") {
+		t.Fatalf("unexpected report: %s", b)
+	}
+}
+
+func TestRunGen_PickCandidate(t *testing.T) {
+	prepareRunGenHome(t)
+
+	ts := newRunGenFastSuccessServer(t)
+	defer ts.Close()
+	oldBase := workerBaseURL
+	oldTimeout := streamTimeoutSecond
+	oldIn, oldOut, oldTerm := pickInput, pickOutput, stdinIsTerminal
+	workerBaseURL = ts.URL
+	streamTimeoutSecond = 5
+	var prompt bytes.Buffer
+	pickInput = strings.NewReader("9\n2\n")
+	pickOutput = &prompt
+	stdinIsTerminal = func() bool { return true }
+	defer func() {
+		workerBaseURL = oldBase
+		streamTimeoutSecond = oldTimeout
+		pickInput, pickOutput, stdinIsTerminal = oldIn, oldOut, oldTerm
+	}()
+
+	inputPath := filepath.Join(t.TempDir(), "req.md")
+	if err := os.WriteFile(inputPath, []byte("# 输入"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	outDir := t.TempDir()
+	if _, err := captureStdoutRun(t, func() error {
+		return RunGen(context.Background(), GenOptions{OutputDir: outDir, Inputs: []string{inputPath}, Num: 2, SkipDocx: true, Pick: true})
+	}); err != nil {
+		t.Fatalf("RunGen error: %v", err)
+	}
+	if !strings.Contains(prompt.String(), "[2]") || !strings.Contains(prompt.String(), "无效选择：9") {
+		t.Fatalf("unexpected picker output: %s", prompt.String())
+	}
+	if _, err := os.Stat(filepath.Join(outDir, "req_final_en.md")); err != nil {
+		t.Fatalf("final EN not written: %v", err)
+	}
+	m, _, err := manifest.LoadLatest(filepath.Join(os.Getenv("HOME"), ".syl-listing-pro", "runs"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var selected []int
+	for _, task := range m.Tasks {
+		if task.Selected {
+			selected = append(selected, task.Index)
+		}
+	}
+	if len(selected) != 1 || selected[0] != 2 {
+		t.Fatalf("unexpected selection: %v", selected)
+	}
+}
+
+func TestRunGen_AutoPick(t *testing.T) {
+	prepareRunGenHome(t)
+
+	ts := newRunGenFastSuccessServer(t)
+	defer ts.Close()
+	oldBase := workerBaseURL
+	oldTimeout := streamTimeoutSecond
+	workerBaseURL = ts.URL
+	streamTimeoutSecond = 5
+	defer func() {
+		workerBaseURL = oldBase
+		streamTimeoutSecond = oldTimeout
+	}()
+
+	inputPath := filepath.Join(t.TempDir(), "req.md")
+	if err := os.WriteFile(inputPath, []byte("# 输入"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	outDir := t.TempDir()
+	logs, err := captureStdoutRun(t, func() error {
+		return RunGen(context.Background(), GenOptions{OutputDir: outDir, Inputs: []string{inputPath}, Num: 2, SkipDocx: true, AutoPick: true})
+	})
+	if err != nil {
+		t.Fatalf("RunGen error: %v", err)
+	}
+	if !strings.Contains(logs, "候选评分") || !strings.Contains(logs, "已自动选定候选 1") {
+		t.Fatalf("unexpected logs: %s", logs)
+	}
+	if _, err := os.Stat(filepath.Join(outDir, "req_best_en.md")); err != nil {
+		t.Fatalf("best EN not written: %v", err)
+	}
+	m, _, err := manifest.LoadLatest(filepath.Join(os.Getenv("HOME"), ".syl-listing-pro", "runs"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	bestCount := 0
+	for _, task := range m.Tasks {
+		var sidecar string
+		for _, p := range task.Outputs {
+			if strings.HasSuffix(p, ".json") {
+				sidecar = p
+			}
+		}
+		b, err := os.ReadFile(sidecar)
+		if err != nil {
+			t.Fatalf("candidate %d sidecar: %v", task.Index, err)
+		}
+		var data listingSidecar
+		if err := json.Unmarshal(b, &data); err != nil || data.CandidateScore == nil {
+			t.Fatalf("candidate %d score missing: %s", task.Index, b)
+		}
+		if data.CandidateScore.Best {
+			bestCount++
+			if !task.Selected || task.Index != 1 {
+				t.Fatalf("unexpected best task: %+v", task)
+			}
+		}
+	}
+	if bestCount != 1 {
+		t.Fatalf("expected exactly one best candidate, got %d", bestCount)
+	}
+}
+
+func TestRunGen_PickWithAutoPickRejected(t *testing.T) {
+	err := RunGen(context.Background(), GenOptions{OutputDir: t.TempDir(), Inputs: []string{"x.md"}, Num: 2, Pick: true, AutoPick: true})
+	if err == nil || !strings.Contains(err.Error(), "--auto-pick") {
+		t.Fatalf("expected conflict error, got %v", err)
+	}
+}
+
+func TestRunGen_SidecarKeepsCharCountsAndLabel(t *testing.T) {
+	prepareRunGenHome(t)
+
+	ts := newRunGenFastSuccessServer(t)
+	defer ts.Close()
+	oldBase := workerBaseURL
+	oldTimeout := streamTimeoutSecond
+	workerBaseURL = ts.URL
+	streamTimeoutSecond = 5
+	defer func() {
+		workerBaseURL = oldBase
+		streamTimeoutSecond = oldTimeout
+	}()
+
+	inputPath := filepath.Join(t.TempDir(), "req.md")
+	if err := os.WriteFile(inputPath, []byte("# 输入"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	outDir := t.TempDir()
+	if _, err := captureStdoutRun(t, func() error {
+		return RunGen(context.Background(), GenOptions{OutputDir: outDir, Inputs: []string{inputPath}, SkipDocx: true, Label: "spring"})
+	}); err != nil {
+		t.Fatalf("RunGen error: %v", err)
+	}
+	matches, _ := filepath.Glob(filepath.Join(outDir, "*.json"))
+	if len(matches) != 1 {
+		t.Fatalf("expected one sidecar, got %v", matches)
+	}
+	var sc listingSidecar
+	b, _ := os.ReadFile(matches[0])
+	if err := json.Unmarshal(b, &sc); err != nil || len(sc.CharCounts) != 2 || sc.Label != "spring" {
+		t.Fatalf("sidecar should keep char counts and label: %s", b)
+	}
+}
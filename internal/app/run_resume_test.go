@@ -0,0 +1,295 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"syl-listing-pro/internal/client"
+	"syl-listing-pro/internal/manifest"
+)
+
+func TestRunGen_WritesCheckpoint(t *testing.T) {
+	stubDocxConverter(t)
+	prepareRunGenEnv(t)
+	ts := newRunGenFastSuccessServer(t)
+	defer ts.Close()
+
+	oldBase := workerBaseURL
+	oldTimeout := streamTimeoutSecond
+	workerBaseURL = ts.URL
+	streamTimeoutSecond = 5
+	defer func() {
+		workerBaseURL = oldBase
+		streamTimeoutSecond = oldTimeout
+	}()
+
+	input := filepath.Join(t.TempDir(), "one.md")
+	if err := os.WriteFile(input, []byte("#MARK\ncontent"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := captureStdoutRun(t, func() error {
+		return RunGen(context.Background(), GenOptions{OutputDir: t.TempDir(), Inputs: []string{input}, Num: 2})
+	}); err != nil {
+		t.Fatalf("RunGen error: %v", err)
+	}
+
+	m, _, err := manifest.LoadLatest(filepath.Join(os.Getenv("HOME"), ".syl-listing-pro", "runs"))
+	if err != nil {
+		t.Fatalf("LoadLatest error: %v", err)
+	}
+	if len(m.Tasks) != 2 || m.Pending() != 0 || m.FinishedAt == "" {
+		t.Fatalf("unexpected manifest: %+v", m)
+	}
+	for _, task := range m.Tasks {
+		if task.JobID == "" || len(task.Outputs) != 5 {
+			t.Fatalf("task not fully recorded: %+v", task)
+		}
+	}
+}
+
+func TestRunGen_ResumeLastSkipsDoneAndReattaches(t *testing.T) {
+	stubDocxConverter(t)
+	prepareRunGenEnv(t)
+
+	var generateCalls atomic.Int64
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/auth/exchange":
+			_, _ = io.WriteString(w, `{"access_token":"at","tenant_id":"demo","expires_in":3600}`)
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/generate":
+			generateCalls.Add(1)
+			_, _ = io.WriteString(w, `{"job_id":"job_new","status":"queued"}`)
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/events"):
+			jobID := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/v1/jobs/"), "/events")
+			if jobID != "job_new" && jobID != "job_inflight" {
+				t.Errorf("unexpected job: %s", jobID)
+			}
+			writeSSEEvent(t, w, "status", `{"job_id":"`+jobID+`","tenant_id":"demo","status":"succeeded","updated_at":"2026-03-13T00:00:02Z"}`)
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/result"):
+			_, _ = io.WriteString(w, `{"en_markdown":"# EN","cn_markdown":"# CN"}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	oldBase := workerBaseURL
+	oldTimeout := streamTimeoutSecond
+	workerBaseURL = ts.URL
+	streamTimeoutSecond = 5
+	defer func() {
+		workerBaseURL = oldBase
+		streamTimeoutSecond = oldTimeout
+	}()
+
+	inputDir := t.TempDir()
+	var paths []string
+	for _, name := range []string{"done.md", "inflight.md", "pending.md"} {
+		p := filepath.Join(inputDir, name)
+		if err := os.WriteFile(p, []byte("# "+name), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		paths = append(paths, p)
+	}
+	outDir := t.TempDir()
+	runsDir := filepath.Join(os.Getenv("HOME"), ".syl-listing-pro", "runs")
+	if _, err := manifest.Create(runsDir, manifest.Manifest{
+		RunID:     "20260313-000000-abcdef",
+		OutputDir: outDir,
+		Num:       1,
+		Tasks: []manifest.Task{
+			{Key: manifest.TaskKey(paths[0], 1), InputPath: paths[0], Index: 1, Label: "done.md", Status: manifest.StatusSucceeded, JobID: "job_done"},
+			{Key: manifest.TaskKey(paths[1], 1), InputPath: paths[1], Index: 1, Label: "inflight.md", Status: manifest.StatusSubmitted, JobID: "job_inflight"},
+			{Key: manifest.TaskKey(paths[2], 1), InputPath: paths[2], Index: 1, Label: "pending.md", Status: manifest.StatusPending},
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := captureStdoutRun(t, func() error {
+		return RunGen(context.Background(), GenOptions{ResumeLast: true})
+	})
+	if err != nil {
+		t.Fatalf("RunGen error: %v, out=%s", err, out)
+	}
+	if !strings.Contains(out, "续跑 20260313-000000-abcdef：已完成 1，待处理 2") {
+		t.Fatalf("missing resume summary: %s", out)
+	}
+	if got := generateCalls.Load(); got != 1 {
+		t.Fatalf("generate calls=%d want=1", got)
+	}
+	if matches, _ := filepath.Glob(filepath.Join(outDir, "*_en.md")); len(matches) != 2 {
+		t.Fatalf("expected two outputs in recorded dir, got %v", matches)
+	}
+	m, _, err := manifest.LoadLatest(runsDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Pending() != 0 {
+		t.Fatalf("all tasks should be done after resume: %+v", m.Tasks)
+	}
+}
+
+func TestRunGen_ResumeLastRejectsInputs(t *testing.T) {
+	prepareRunGenEnv(t)
+	ts := newRunGenFastSuccessServer(t)
+	defer ts.Close()
+	oldBase := workerBaseURL
+	workerBaseURL = ts.URL
+	defer func() { workerBaseURL = oldBase }()
+
+	err := RunGen(context.Background(), GenOptions{ResumeLast: true, Inputs: []string{"a.md"}})
+	if err == nil || !strings.Contains(err.Error(), "--resume-last") {
+		t.Fatalf("unexpected err: %v", err)
+	}
+}
+
+func TestRunGen_ResumeLastRejectsOtherKeyProfile(t *testing.T) {
+	prepareRunGenEnv(t)
+	ts := newRunGenFastSuccessServer(t)
+	defer ts.Close()
+	oldBase := workerBaseURL
+	workerBaseURL = ts.URL
+	defer func() { workerBaseURL = oldBase }()
+
+	runsDir := filepath.Join(os.Getenv("HOME"), ".syl-listing-pro", "runs")
+	m := manifest.Manifest{RunID: "20260101-000000-aaaa", KeyProfile: "clientA", OutputDir: t.TempDir(), Tasks: []manifest.Task{{Key: "k", Status: manifest.StatusPending}}}
+	if _, err := manifest.Create(runsDir, m); err != nil {
+		t.Fatal(err)
+	}
+	err := RunGen(context.Background(), GenOptions{ResumeLast: true})
+	if err == nil || !strings.Contains(err.Error(), "--key-profile clientA") {
+		t.Fatalf("unexpected err: %v", err)
+	}
+}
+
+func TestRunRequeue_RegeneratesOnlyFailedTasks(t *testing.T) {
+	stubDocxConverter(t)
+	prepareRunGenEnv(t)
+
+	var generated []string
+	var mu sync.Mutex
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/auth/exchange":
+			_, _ = io.WriteString(w, `{"access_token":"at","tenant_id":"demo","expires_in":3600}`)
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/generate":
+			var req client.GenerateReq
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			mu.Lock()
+			generated = append(generated, req.InputFilename)
+			mu.Unlock()
+			_, _ = io.WriteString(w, `{"job_id":"job_requeue","status":"queued"}`)
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/jobs/job_requeue/events":
+			writeSSEEvent(t, w, "status", `{"job_id":"job_requeue","tenant_id":"demo","status":"succeeded","updated_at":"2026-03-13T00:00:02Z"}`)
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/jobs/job_requeue/result":
+			_, _ = io.WriteString(w, `{"en_markdown":"# EN","cn_markdown":"# CN"}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	oldBase := workerBaseURL
+	oldTimeout := streamTimeoutSecond
+	workerBaseURL = ts.URL
+	streamTimeoutSecond = 5
+	defer func() {
+		workerBaseURL = oldBase
+		streamTimeoutSecond = oldTimeout
+	}()
+
+	inputDir := t.TempDir()
+	okPath := filepath.Join(inputDir, "ok.md")
+	badPath := filepath.Join(inputDir, "bad.md")
+	for _, p := range []string{okPath, badPath} {
+		if err := os.WriteFile(p, []byte("# "+filepath.Base(p)), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	outDir := t.TempDir()
+	runsDir := filepath.Join(os.Getenv("HOME"), ".syl-listing-pro", "runs")
+	if _, err := manifest.Create(runsDir, manifest.Manifest{
+		RunID:     "20260313-010000-aaaaaa",
+		OutputDir: outDir,
+		Num:       1,
+		Tasks: []manifest.Task{
+			{Key: manifest.TaskKey(okPath, 1), InputPath: okPath, Index: 1, Label: "ok.md", Status: manifest.StatusSucceeded},
+			{Key: manifest.TaskKey(badPath, 1), InputPath: badPath, Index: 1, Label: "bad.md", Status: manifest.StatusFailed, JobID: "job_old"},
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := captureStdoutRun(t, func() error {
+		return RunRequeue(context.Background(), "20260313-010000-aaaaaa", GenOptions{})
+	}); err != nil {
+		t.Fatalf("RunRequeue error: %v", err)
+	}
+	if len(generated) != 1 || generated[0] != "bad.md" {
+		t.Fatalf("generated=%v", generated)
+	}
+	if matches, _ := filepath.Glob(filepath.Join(outDir, "bad_*_en.md")); len(matches) != 1 {
+		t.Fatalf("expected requeued output in original dir, got %v", matches)
+	}
+
+	if err := RunRequeue(context.Background(), "../x", GenOptions{}); err == nil || !strings.Contains(err.Error(), "运行编号无效") {
+		t.Fatalf("unexpected err: %v", err)
+	}
+}
+
+func TestRunRequeue_KeepsSourceCandidateCount(t *testing.T) {
+	stubDocxConverter(t)
+	prepareRunGenEnv(t)
+
+	ts := newRunGenFastSuccessServer(t)
+	defer ts.Close()
+	oldBase := workerBaseURL
+	oldTimeout := streamTimeoutSecond
+	workerBaseURL = ts.URL
+	streamTimeoutSecond = 5
+	defer func() {
+		workerBaseURL = oldBase
+		streamTimeoutSecond = oldTimeout
+	}()
+
+	inputPath := filepath.Join(t.TempDir(), "foo.md")
+	if err := os.WriteFile(inputPath, []byte("# foo"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	outDir := t.TempDir()
+	runsDir := filepath.Join(os.Getenv("HOME"), ".syl-listing-pro", "runs")
+	if _, err := manifest.Create(runsDir, manifest.Manifest{
+		RunID:     "20260313-010000-bbbbbb",
+		OutputDir: outDir,
+		Num:       3,
+		Tasks: []manifest.Task{
+			{Key: manifest.TaskKey(inputPath, 1), InputPath: inputPath, Index: 1, Label: "foo.md#1", Status: manifest.StatusFailed},
+			{Key: manifest.TaskKey(inputPath, 2), InputPath: inputPath, Index: 2, Label: "foo.md#2", Status: manifest.StatusSucceeded},
+			{Key: manifest.TaskKey(inputPath, 3), InputPath: inputPath, Index: 3, Label: "foo.md#3", Status: manifest.StatusSucceeded},
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := captureStdoutRun(t, func() error {
+		return RunRequeue(context.Background(), "20260313-010000-bbbbbb", GenOptions{OnConflict: "overwrite"})
+	}); err != nil {
+		t.Fatalf("RunRequeue error: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(outDir, "foo_1_en.md")); err != nil {
+		t.Fatalf("requeued candidate should keep its index in the name: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(outDir, "foo_en.md")); err == nil {
+		t.Fatal("requeued candidate written without its index")
+	}
+}
@@ -0,0 +1,81 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"syl-listing-pro/internal/client"
+	"syl-listing-pro/internal/listing"
+)
+
+func TestRunGen_SearchTermsWrittenWithinBudget(t *testing.T) {
+	prepareRunGenHome(t)
+	inner := newRunGenFastSuccessServer(t)
+	defer inner.Close()
+	var requested bool
+	terms := "yoga mat, Yoga exercise"
+	for i := 0; i < 30; i++ {
+		terms += fmt.Sprintf(" pilates%02d", i)
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/generate":
+			var req client.GenerateReq
+			b, _ := io.ReadAll(r.Body)
+			_ = json.Unmarshal(b, &req)
+			requested = req.SearchTerms
+			r.Body = io.NopCloser(strings.NewReader(string(b)))
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/result"):
+			_ = json.NewEncoder(w).Encode(client.ResultResp{ENMarkdown: "# EN", CNMarkdown: "# CN", SearchTerms: terms})
+			return
+		}
+		inner.Config.Handler.ServeHTTP(w, r)
+	}))
+	defer ts.Close()
+	oldBase := workerBaseURL
+	oldTimeout := streamTimeoutSecond
+	workerBaseURL = ts.URL
+	streamTimeoutSecond = 5
+	defer func() {
+		workerBaseURL = oldBase
+		streamTimeoutSecond = oldTimeout
+	}()
+
+	inputPath := filepath.Join(t.TempDir(), "req.md")
+	if err := os.WriteFile(inputPath, []byte("# 输入"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	outDir := t.TempDir()
+	out, err := captureStdoutRun(t, func() error {
+		return RunGen(context.Background(), GenOptions{OutputDir: outDir, Inputs: []string{inputPath}, SkipDocx: true, SearchTerms: true})
+	})
+	if err != nil {
+		t.Fatalf("RunGen error: %v", err)
+	}
+	if !requested {
+		t.Fatal("search_terms not requested")
+	}
+	files, _ := filepath.Glob(filepath.Join(outDir, "*_search_terms.txt"))
+	if len(files) != 1 {
+		t.Fatalf("expected one search terms file, got %v", files)
+	}
+	b, err := os.ReadFile(files[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := strings.TrimSuffix(string(b), "\n")
+	if !strings.HasPrefix(got, "yoga mat exercise pilates00 ") || len(got) > listing.SearchTermsByteBudget {
+		t.Fatalf("search terms=%q", got)
+	}
+	if !strings.Contains(out, "已舍弃：") || !strings.Contains(out, "后台搜索词已写入") {
+		t.Fatalf("search terms not logged: %s", out)
+	}
+}
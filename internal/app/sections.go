@@ -0,0 +1,63 @@
+package app
+
+import (
+	"strings"
+
+	"syl-listing-pro/internal/client"
+	"syl-listing-pro/internal/i18n"
+	"syl-listing-pro/internal/listing"
+)
+
+// regenerableSections 为 --sections 可选的分节，顺序即拼接输出时的顺序。
+var regenerableSections = []string{listing.KindTitle, listing.KindBullets, listing.KindDescription}
+
+// normalizeSections 去空白、转小写、去重并按固定顺序返回；含未知分节时报错。
+func normalizeSections(in []string) ([]string, error) {
+	want := map[string]bool{}
+	for _, raw := range in {
+		s := strings.ToLower(strings.TrimSpace(raw))
+		if s == "" {
+			continue
+		}
+		if !containsString(regenerableSections, s) {
+			return nil, i18n.Errorf("--sections 只支持 %s: %s", strings.Join(regenerableSections, "|"), raw)
+		}
+		want[s] = true
+	}
+	var out []string
+	for _, s := range regenerableSections {
+		if want[s] {
+			out = append(out, s)
+		}
+	}
+	return out, nil
+}
+
+// applySectionResults 在服务端只返回分节结果时拼出 EN/CN Markdown，并判断结果是否只含请求的分节；
+// 不支持按节生成的旧服务端会忽略请求返回完整 listing，此时第二个返回值为 false。
+func applySectionResults(res client.ResultResp, requested []string) (client.ResultResp, bool) {
+	if strings.TrimSpace(res.ENMarkdown) == "" && len(res.Sections) > 0 {
+		var en, cn []string
+		for _, s := range res.Sections {
+			en = append(en, strings.TrimSpace(s.ENMarkdown))
+			cn = append(cn, strings.TrimSpace(s.CNMarkdown))
+		}
+		res.ENMarkdown = strings.Join(en, "\n\n") + "\n"
+		res.CNMarkdown = strings.Join(cn, "\n\n") + "\n"
+	}
+	for _, s := range listing.Parse(res.ENMarkdown).Sections {
+		if s.Kind != listing.KindOther && !containsString(requested, s.Kind) {
+			return res, false
+		}
+	}
+	return res, true
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
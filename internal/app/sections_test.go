@@ -0,0 +1,32 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"syl-listing-pro/internal/client"
+)
+
+func TestNormalizeSections(t *testing.T) {
+	got, err := normalizeSections([]string{" Bullets", "title", "bullets", ""})
+	if err != nil || strings.Join(got, ",") != "title,bullets" {
+		t.Fatalf("got=%v err=%v", got, err)
+	}
+	if _, err := normalizeSections([]string{"keywords"}); err == nil || !strings.Contains(err.Error(), "--sections") {
+		t.Fatalf("unexpected err: %v", err)
+	}
+}
+
+func TestApplySectionResults(t *testing.T) {
+	res, partial := applySectionResults(client.ResultResp{Sections: []client.SectionResult{
+		{Section: "title", ENMarkdown: "# Title\nNew title", CNMarkdown: "# 标题\n新标题"},
+	}}, []string{"title"})
+	if !partial || !strings.Contains(res.ENMarkdown, "New title") || !strings.Contains(res.CNMarkdown, "新标题") {
+		t.Fatalf("unexpected result: partial=%v %+v", partial, res)
+	}
+
+	full := client.ResultResp{ENMarkdown: "# Title\nT\n\n# Bullet Points\n- a\n", CNMarkdown: "# 标题\nT\n"}
+	if _, partial := applySectionResults(full, []string{"title"}); partial {
+		t.Fatal("full listing should not be reported as partial")
+	}
+}
@@ -1,11 +1,57 @@
 package app
 
 import (
+	"bufio"
 	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
 
 	"syl-listing-pro/internal/config"
+	"syl-listing-pro/internal/i18n"
 )
 
-func RunSetKey(_ context.Context, key string) error {
-	return config.SaveSYLListingKey(key)
+// RunSetKey 保存 KEY；profile 为空时写入 default 配置。
+func RunSetKey(_ context.Context, profile, key string) error {
+	if profile != "" {
+		if err := config.ValidateKeyProfile(profile); err != nil {
+			return i18n.Errorf("KEY 配置名只能包含字母、数字和下划线: %s", profile)
+		}
+	}
+	return config.SaveSYLListingKeyProfile(profile, key)
+}
+
+// ReadKey 从 r 读取第一行作为 KEY，供 set key --stdin 使用。
+func ReadKey(r io.Reader) (string, error) {
+	line, err := bufio.NewReader(r).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", i18n.Errorf("读取标准输入失败: %w", err)
+	}
+	key := strings.TrimSpace(line)
+	if key == "" {
+		return "", i18n.Errorf("标准输入中没有 KEY")
+	}
+	return key, nil
+}
+
+// RunUseKeyProfile 切换默认使用的 KEY 配置。
+func RunUseKeyProfile(w io.Writer, profile string) error {
+	if _, err := loadSYLKeyForRun(profile); err != nil {
+		return err
+	}
+	if err := config.SaveActiveKeyProfile(profile); err != nil {
+		return err
+	}
+	fmt.Fprintln(w, i18n.T("已切换到 KEY 配置：%s", profile))
+	return nil
+}
+
+// KeyProfiles 返回已保存 KEY 的配置名，供补全使用。
+func KeyProfiles() []string {
+	profiles, err := config.ListKeyProfiles()
+	if err != nil {
+		return nil
+	}
+	return profiles
 }
@@ -1,6 +1,7 @@
 package app
 
 import (
+	"bytes"
 	"os"
 	"path/filepath"
 	"strings"
@@ -10,7 +11,7 @@ import (
 func TestRunSetKey(t *testing.T) {
 	home := t.TempDir()
 	t.Setenv("HOME", home)
-	if err := RunSetKey(nil, "new-key"); err != nil {
+	if err := RunSetKey(nil, "", "new-key"); err != nil {
 		t.Fatalf("RunSetKey error: %v", err)
 	}
 	b, err := os.ReadFile(filepath.Join(home, ".syl-listing-pro", ".env"))
@@ -21,3 +22,34 @@ func TestRunSetKey(t *testing.T) {
 		t.Fatalf("unexpected env content: %s", string(b))
 	}
 }
+
+func TestRunUseKeyProfile(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	var buf bytes.Buffer
+	if err := RunUseKeyProfile(&buf, "clientA"); err == nil || !strings.Contains(err.Error(), "set key --name clientA") {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if err := RunSetKey(nil, "clientA", "kA"); err != nil {
+		t.Fatal(err)
+	}
+	if err := RunUseKeyProfile(&buf, "clientA"); err != nil {
+		t.Fatalf("RunUseKeyProfile error: %v", err)
+	}
+	key, err := loadSYLKeyForRun("")
+	if err != nil || key != "kA" {
+		t.Fatalf("key=%q err=%v", key, err)
+	}
+	if got := KeyProfiles(); len(got) != 1 || got[0] != "clientA" {
+		t.Fatalf("profiles=%v", got)
+	}
+}
+
+func TestReadKey(t *testing.T) {
+	key, err := ReadKey(strings.NewReader("  abc \nignored\n"))
+	if err != nil || key != "abc" {
+		t.Fatalf("key=%q err=%v", key, err)
+	}
+	if _, err := ReadKey(strings.NewReader("\n")); err == nil {
+		t.Fatal("expected error for empty input")
+	}
+}
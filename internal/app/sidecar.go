@@ -0,0 +1,67 @@
+package app
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"syl-listing-pro/internal/i18n"
+	"syl-listing-pro/internal/listing"
+	"syl-listing-pro/internal/manifest"
+	"syl-listing-pro/internal/output"
+	"syl-listing-pro/internal/report"
+)
+
+// listingSidecar 是与 EN/CN 产物同名的 JSON 附带文件，记录任务的检查结果。
+type listingSidecar struct {
+	InputPath        string                     `json:"input_path"`
+	JobID            string                     `json:"job_id"`
+	Label            string                     `json:"label,omitempty"`
+	ValidationReport []string                   `json:"validation_report,omitempty"`
+	Consistency      *listing.ConsistencyReport `json:"consistency,omitempty"`
+	BannedWords      []string                   `json:"banned_words,omitempty"`
+	// Misspellings 为 --spellcheck 发现的主稿疑似拼写错误。
+	Misspellings []string `json:"misspellings,omitempty"`
+	// KeywordCoverage 记录每个关键词在标题、五点、描述中的出现次数。
+	KeywordCoverage []listing.KeywordCoverage `json:"keyword_coverage,omitempty"`
+	// CharCounts 按语言记录各分节的字符数与字节数。
+	CharCounts map[string][]sectionCount `json:"char_counts,omitempty"`
+	// CandidateScore 为 --auto-pick 给该候选打出的本地评分。
+	CandidateScore *candidateScore `json:"candidate_score,omitempty"`
+}
+
+// sidecarPath 由主稿产物路径得到附带文件路径：xxx_en.md -> xxx.json。
+func sidecarPath(enPath string) string {
+	base := strings.TrimSuffix(enPath, filepath.Ext(enPath))
+	if lang := output.LangOf(enPath); lang != "" {
+		base = strings.TrimSuffix(base, "_"+lang)
+	}
+	return base + ".json"
+}
+
+func writeSidecar(enPath string, data listingSidecar) (string, error) {
+	p := sidecarPath(enPath)
+	b, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(p, append(b, '\n'), 0o644); err != nil {
+		return "", i18n.Errorf("写报告失败: %w", err)
+	}
+	return p, nil
+}
+
+// writeHTMLReport 根据运行记录生成 HTML 报告；没有运行记录时跳过。
+func writeHTMLReport(log *Logger, outDir string, cp *manifest.Checkpoint) {
+	if cp == nil {
+		log.Info(i18n.T("运行记录不可用，跳过 HTML 报告"))
+		return
+	}
+	path, err := report.Write(outDir, cp.Snapshot())
+	if err != nil {
+		log.Info(i18n.T("HTML 报告写入失败：%v", err))
+		return
+	}
+	log.Info(i18n.T("HTML 报告已写入：%s", mustAbsPath(path)))
+}
@@ -0,0 +1,159 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"syl-listing-pro/internal/client"
+	"syl-listing-pro/internal/i18n"
+	"syl-listing-pro/internal/input"
+)
+
+// smokeRequirement 为内置的最小需求文件；服务端规则要求首行标记时，用 smoke <file> 改用官方模板填写的文件。
+const smokeRequirement = `# 冒烟测试
+
+品牌：SYL Smoke
+品名：不锈钢保温杯 500ml
+卖点：
+- 双层真空保温，12 小时保热
+- 304 不锈钢内胆
+- 防漏杯盖，单手开合
+`
+
+// smokeStage 是冒烟测试的一个阶段；run 返回通过时附带的说明。
+type smokeStage struct {
+	name string
+	run  func(ctx context.Context) (string, error)
+}
+
+// RunSmoke 用一份很小的需求文件走完整条链路（换取令牌、提交、生成、读取结果、Word 转换），
+// 逐阶段输出耗时，用于新机器上线前确认环境可用。产物写在临时目录，结束后删除。
+func RunSmoke(ctx context.Context, w io.Writer, inputPath, profile string, skipDocx bool) error {
+	name, body := "smoke.md", smokeRequirement
+	if inputPath != "" {
+		files, err := input.Discover([]string{inputPath})
+		if err != nil {
+			return err
+		}
+		if len(files) != 1 {
+			return i18n.Errorf("smoke 只接受一个需求文件：%s", inputPath)
+		}
+		_, body = files[0].Frontmatter()
+		name = filepath.Base(files[0].Path)
+	}
+	profile, err := resolveKeyProfile(profile)
+	if err != nil {
+		return err
+	}
+	sylKey, err := loadSYLKeyForRun(profile)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.MkdirTemp("", "syl-smoke-*")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmp)
+
+	api := client.New(resolveWorkerBaseURL())
+	fmt.Fprintln(w, i18n.T("服务端：%s", resolveWorkerBaseURL()))
+	start := time.Now()
+	var (
+		ex       client.ExchangeResp
+		jobID    string
+		status   client.JobStatusResp
+		result   client.ResultResp
+		rulesVer string
+		mdPath   = filepath.Join(tmp, "smoke_en.md")
+	)
+	stages := []smokeStage{
+		{name: i18n.T("换取令牌"), run: func(ctx context.Context) (string, error) {
+			ex, err = api.Exchange(ctx, sylKey)
+			if err != nil {
+				return "", withRemediation(err)
+			}
+			return i18n.T("租户 %s", ex.TenantID), nil
+		}},
+		{name: i18n.T("提交任务"), run: func(ctx context.Context) (string, error) {
+			resp, err := api.Generate(ctx, ex.AccessToken, client.GenerateReq{
+				InputMarkdown:  body,
+				InputFilename:  name,
+				CandidateCount: 1,
+			})
+			if err != nil {
+				return "", withRemediation(err)
+			}
+			jobID = resp.JobID
+			return "job_id=" + jobID, nil
+		}},
+		{name: i18n.T("生成"), run: func(ctx context.Context) (string, error) {
+			streamCtx, cancel := context.WithTimeout(ctx, time.Duration(streamTimeoutSecond)*time.Second)
+			defer cancel()
+			status, err = api.JobEvents(streamCtx, ex.AccessToken, jobID, func(ev client.JobEvent) {
+				if ev.Type == "trace" && ev.Trace != nil && ev.Trace.Item.Event == "rules_loaded" {
+					rulesVer = stringPayload(ev.Trace.Item.Payload, "rules_version")
+				}
+			})
+			if err != nil {
+				return "", err
+			}
+			if status.Status != "succeeded" {
+				return "", i18n.Errorf("任务状态 %s：%s", status.Status, strings.TrimSpace(status.Error))
+			}
+			if rulesVer != "" {
+				return i18n.T("规则版本 %s", rulesVer), nil
+			}
+			return "", nil
+		}},
+		{name: i18n.T("读取结果"), run: func(ctx context.Context) (string, error) {
+			result, err = api.Result(ctx, ex.AccessToken, jobID)
+			if err != nil {
+				return "", withRemediation(err)
+			}
+			if strings.TrimSpace(result.ENMarkdown) == "" {
+				return "", i18n.Errorf("结果为空")
+			}
+			if err := os.WriteFile(mdPath, []byte(result.ENMarkdown), 0o644); err != nil {
+				return "", err
+			}
+			return i18n.T("%d 字符", len([]rune(result.ENMarkdown))), nil
+		}},
+	}
+	if !skipDocx {
+		stages = append(stages, smokeStage{name: i18n.T("Word 转换"), run: func(ctx context.Context) (string, error) {
+			if _, err := convertMarkdownToDocxFunc(ctx, mdPath, filepath.Join(tmp, "smoke_en.docx")); err != nil {
+				return "", err
+			}
+			return "", nil
+		}})
+	}
+
+	for _, st := range stages {
+		stageStart := time.Now()
+		detail, err := st.run(ctx)
+		took := time.Since(stageStart)
+		if err != nil {
+			fmt.Fprintln(w, i18n.T("[失败] %s（%s）：%v", st.name, formatStageDuration(took), err))
+			return i18n.Errorf("冒烟测试在“%s”阶段失败", st.name)
+		}
+		if detail != "" {
+			fmt.Fprintln(w, i18n.T("[通过] %s（%s）：%s", st.name, formatStageDuration(took), detail))
+		} else {
+			fmt.Fprintln(w, i18n.T("[通过] %s（%s）", st.name, formatStageDuration(took)))
+		}
+	}
+	fmt.Fprintln(w, i18n.T("全部 %d 个阶段通过，总耗时 %s", len(stages), formatStageDuration(time.Since(start))))
+	return nil
+}
+
+func formatStageDuration(d time.Duration) string {
+	if d < time.Second {
+		return fmt.Sprintf("%dms", d.Milliseconds())
+	}
+	return fmt.Sprintf("%.1fs", d.Seconds())
+}
@@ -0,0 +1,70 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRunSmoke(t *testing.T) {
+	stubDocxConverter(t)
+	prepareRunGenHome(t)
+	failResult := false
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/auth/exchange":
+			_, _ = io.WriteString(w, `{"access_token":"at","tenant_id":"demo","expires_in":3600}`)
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/generate":
+			body, _ := io.ReadAll(r.Body)
+			if !strings.Contains(string(body), "SYL Smoke") {
+				t.Errorf("built-in requirement not sent: %s", body)
+			}
+			_, _ = io.WriteString(w, `{"job_id":"job_smoke","status":"queued"}`)
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/jobs/job_smoke/events":
+			writeSSETrace(t, w, 1, `{"job_id":"job_smoke","tenant_id":"demo","offset":1,"item":{"source":"generation","event":"rules_loaded","tenant_id":"demo","job_id":"job_smoke","elapsed_ms":1,"payload":{"rules_version":"rules-syl-20260313"}}}`)
+			writeSSEEvent(t, w, "status", `{"job_id":"job_smoke","tenant_id":"demo","status":"succeeded"}`)
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/jobs/job_smoke/result":
+			if failResult {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = io.WriteString(w, `{"error":"bad"}`)
+				return
+			}
+			_, _ = io.WriteString(w, `{"en_markdown":"# EN","cn_markdown":"# CN"}`)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+	oldBase := workerBaseURL
+	oldTimeout := streamTimeoutSecond
+	workerBaseURL = ts.URL
+	streamTimeoutSecond = 5
+	defer func() {
+		workerBaseURL = oldBase
+		streamTimeoutSecond = oldTimeout
+	}()
+
+	var buf bytes.Buffer
+	if err := RunSmoke(context.Background(), &buf, "", "", false); err != nil {
+		t.Fatalf("RunSmoke error: %v\n%s", err, buf.String())
+	}
+	for _, want := range []string{"[通过] 换取令牌", "租户 demo", "job_id=job_smoke", "规则版本 rules-syl-20260313", "[通过] 读取结果", "[通过] Word 转换", "全部 5 个阶段通过"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Fatalf("missing %q in:\n%s", want, buf.String())
+		}
+	}
+
+	failResult = true
+	buf.Reset()
+	err := RunSmoke(context.Background(), &buf, "", "", true)
+	if err == nil || !strings.Contains(err.Error(), "读取结果") {
+		t.Fatalf("err=%v want failure at result stage\n%s", err, buf.String())
+	}
+	if !strings.Contains(buf.String(), "[失败] 读取结果") || strings.Contains(buf.String(), "Word 转换") {
+		t.Fatalf("unexpected output:\n%s", buf.String())
+	}
+}
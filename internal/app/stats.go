@@ -0,0 +1,145 @@
+package app
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"syl-listing-pro/internal/i18n"
+	"syl-listing-pro/internal/manifest"
+)
+
+type statsBucket struct {
+	key       string
+	total     int
+	succeeded int
+}
+
+// RunStats 按天或按周汇总本地运行记录：生成数、成功率、各分节平均耗时与常见校验问题。
+func RunStats(w io.Writer, by string, since string, label string) error {
+	by = strings.ToLower(strings.TrimSpace(by))
+	if by == "" {
+		by = "day"
+	}
+	if by != "day" && by != "week" {
+		return i18n.Errorf("--by 仅支持 day、week：%s", by)
+	}
+	d, err := parseSince(since)
+	if err != nil {
+		return err
+	}
+	runs, err := loadRunHistory(d, time.Now())
+	if err != nil {
+		return err
+	}
+	runs = filterRunsByLabel(runs, label)
+	if len(runs) == 0 {
+		fmt.Fprintln(w, i18n.T("没有运行记录"))
+		return nil
+	}
+
+	buckets := map[string]*statsBucket{}
+	sectionTotal := map[string]int64{}
+	sectionCount := map[string]int{}
+	issues := map[string]int{}
+	for _, m := range runs {
+		started, err := time.Parse(time.RFC3339, m.StartedAt)
+		if err != nil {
+			continue
+		}
+		key := statsBucketKey(started.Local(), by)
+		b := buckets[key]
+		if b == nil {
+			b = &statsBucket{key: key}
+			buckets[key] = b
+		}
+		for _, t := range m.Tasks {
+			if t.Status == manifest.StatusPending || t.Status == manifest.StatusSubmitted {
+				continue
+			}
+			b.total++
+			if t.Status == manifest.StatusSucceeded {
+				b.succeeded++
+			}
+			for section, ms := range t.SectionDurationsMs {
+				sectionTotal[section] += ms
+				sectionCount[section]++
+			}
+			for _, item := range t.ValidationReport {
+				issues[validationIssueKey(item)]++
+			}
+		}
+	}
+
+	keys := make([]string, 0, len(buckets))
+	for k := range buckets {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	fmt.Fprintln(w, i18n.T("周期        生成  成功  成功率"))
+	for _, k := range keys {
+		b := buckets[k]
+		rate := 0.0
+		if b.total > 0 {
+			rate = float64(b.succeeded) * 100 / float64(b.total)
+		}
+		fmt.Fprintf(w, "%-10s  %4d  %4d  %5.1f%%\n", b.key, b.total, b.succeeded, rate)
+	}
+
+	if len(sectionTotal) > 0 {
+		fmt.Fprintln(w, i18n.T("\n分节平均耗时："))
+		sections := make([]string, 0, len(sectionTotal))
+		for s := range sectionTotal {
+			sections = append(sections, s)
+		}
+		sort.Strings(sections)
+		for _, s := range sections {
+			avg := time.Duration(sectionTotal[s]/int64(sectionCount[s])) * time.Millisecond
+			fmt.Fprint(w, i18n.T("  %s：%s（%d 次）\n", runtimeSectionLabel(map[string]any{"section": s}, false), humanDurationShort(avg), sectionCount[s]))
+		}
+	}
+
+	if len(issues) > 0 {
+		fmt.Fprintln(w, i18n.T("\n常见校验问题："))
+		type issueCount struct {
+			text  string
+			count int
+		}
+		list := make([]issueCount, 0, len(issues))
+		for text, n := range issues {
+			list = append(list, issueCount{text, n})
+		}
+		sort.Slice(list, func(i, j int) bool {
+			if list[i].count != list[j].count {
+				return list[i].count > list[j].count
+			}
+			return list[i].text < list[j].text
+		})
+		if len(list) > 5 {
+			list = list[:5]
+		}
+		for _, it := range list {
+			fmt.Fprint(w, i18n.T("  %d 次  %s\n", it.count, it.text))
+		}
+	}
+	return nil
+}
+
+func statsBucketKey(t time.Time, by string) string {
+	if by == "week" {
+		year, week := t.ISOWeek()
+		return fmt.Sprintf("%d-W%02d", year, week)
+	}
+	return t.Format("2006-01-02")
+}
+
+// validationIssueKey 去掉校验报告中的具体数值部分，把同类问题归在一起。
+func validationIssueKey(item string) string {
+	item = strings.TrimSpace(item)
+	if i := strings.IndexAny(item, ":："); i > 0 {
+		return strings.TrimSpace(item[:i])
+	}
+	return item
+}
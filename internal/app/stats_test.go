@@ -0,0 +1,62 @@
+package app
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"syl-listing-pro/internal/manifest"
+)
+
+func TestRunStats(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	day := time.Now().Add(-2 * time.Hour)
+	writeHistoryManifest(t, manifest.Manifest{
+		RunID:     "20990101-000000-cccccc",
+		StartedAt: day.UTC().Format(time.RFC3339),
+		Tasks: []manifest.Task{
+			{Key: "a#1", Status: manifest.StatusSucceeded, SectionDurationsMs: map[string]int64{"title": 2000, "bullets": 10000},
+				ValidationReport: []string{"第2条长度不满足约束: 251（规则区间 [200,250]，容差区间 [190,260]）"}},
+			{Key: "b#1", Status: manifest.StatusSucceeded, SectionDurationsMs: map[string]int64{"title": 4000},
+				ValidationReport: []string{"第2条长度不满足约束: 189（规则区间 [200,250]，容差区间 [190,260]）", "关键词缺失: pet"}},
+			{Key: "c#1", Status: manifest.StatusFailed},
+			{Key: "d#1", Status: manifest.StatusPending},
+		},
+	})
+
+	var buf bytes.Buffer
+	if err := RunStats(&buf, "day", "", ""); err != nil {
+		t.Fatalf("RunStats error: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		day.Format("2006-01-02") + "     3     2   66.7%",
+		"标题：3s（2 次）",
+		"2 次  第2条长度不满足约束",
+		"1 次  关键词缺失",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("missing %q in:\n%s", want, out)
+		}
+	}
+
+	buf.Reset()
+	if err := RunStats(&buf, "week", "7d", ""); err != nil {
+		t.Fatal(err)
+	}
+	year, week := day.ISOWeek()
+	if !strings.Contains(buf.String(), strings.TrimSpace(statsBucketKey(day, "week"))) || year == 0 || week == 0 {
+		t.Fatalf("unexpected weekly stats:\n%s", buf.String())
+	}
+	buf.Reset()
+	if err := RunStats(&buf, "day", "", "spring-launch"); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "没有运行记录") {
+		t.Fatalf("--label not applied:\n%s", buf.String())
+	}
+	if err := RunStats(&buf, "month", "", ""); err == nil {
+		t.Fatal("expected invalid --by error")
+	}
+}
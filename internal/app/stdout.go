@@ -0,0 +1,64 @@
+package app
+
+import (
+	"io"
+	"strings"
+
+	"syl-listing-pro/internal/client"
+	"syl-listing-pro/internal/i18n"
+	"syl-listing-pro/internal/input"
+)
+
+// parseStdoutMode 校验 --stdout：en 为主稿，cn 为对照稿，both 先主稿后对照稿。
+func parseStdoutMode(s string) (string, error) {
+	switch v := strings.ToLower(strings.TrimSpace(s)); v {
+	case "", "en", "cn", "both":
+		return v, nil
+	default:
+		return "", i18n.Errorf("--stdout 只支持 en|cn|both: %s", s)
+	}
+}
+
+// checkStdoutOptions 拒绝与 --stdout 冲突的参数：结果只输出到标准输出，不写任何产物文件。
+func checkStdoutOptions(opts GenOptions) error {
+	if opts.Stdout == "" {
+		return nil
+	}
+	if opts.ResumeLast || opts.RequeueRunID != "" {
+		return i18n.Errorf("--stdout 不能与 --resume-last、requeue 同时使用")
+	}
+	if opts.Num > 1 || opts.Pick || opts.AutoPick || opts.Copy != "" || opts.Open || opts.Combined != "" || opts.SearchTerms || len(opts.Formats) > 0 || opts.Append != "" {
+		return i18n.Errorf("--stdout 不能与 -n 大于 1、--pick、--auto-pick、--copy、--open、--combined、--search-terms、--format、--append 同时使用")
+	}
+	files, err := input.Discover(opts.Inputs)
+	if err != nil {
+		return err
+	}
+	if len(files) != 1 {
+		return i18n.Errorf("--stdout 只用于单个需求文件，当前为 %d 个", len(files))
+	}
+	return nil
+}
+
+// writeStdoutListing 把结果 Markdown 写到 w；both 时两份之间空一行。
+func writeStdoutListing(w io.Writer, mode string, res client.ResultResp) error {
+	var parts []string
+	if mode == "en" || mode == "both" {
+		parts = append(parts, res.ENMarkdown)
+	}
+	if mode == "cn" || mode == "both" {
+		parts = append(parts, res.CNMarkdown)
+	}
+	for i, p := range parts {
+		if i > 0 {
+			p = "\n" + p
+		}
+		if !strings.HasSuffix(p, "\n") {
+			p += "\n"
+		}
+		if _, err := io.WriteString(w, p); err != nil {
+			return i18n.Errorf("写标准输出失败: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package app
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCheckStdoutOptions(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"a.md", "b.md"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("# 输入"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := checkStdoutOptions(GenOptions{Stdout: "en", Inputs: []string{filepath.Join(dir, "a.md")}}); err != nil {
+		t.Fatalf("single input should pass: %v", err)
+	}
+	if err := checkStdoutOptions(GenOptions{Stdout: "en", Inputs: []string{dir}}); err == nil || !strings.Contains(err.Error(), "单个需求文件") {
+		t.Fatalf("expected single input error, got %v", err)
+	}
+	if err := checkStdoutOptions(GenOptions{Stdout: "en", Num: 2, Inputs: []string{filepath.Join(dir, "a.md")}}); err == nil {
+		t.Fatal("expected -n conflict error")
+	}
+	if _, err := parseStdoutMode("de"); err == nil {
+		t.Fatal("expected invalid mode error")
+	}
+}
+
+func TestRunGen_StdoutBoth(t *testing.T) {
+	prepareRunGenHome(t)
+	ts := newRunGenFastSuccessServer(t)
+	defer ts.Close()
+	oldBase := workerBaseURL
+	oldTimeout := streamTimeoutSecond
+	workerBaseURL = ts.URL
+	streamTimeoutSecond = 5
+	defer func() {
+		workerBaseURL = oldBase
+		streamTimeoutSecond = oldTimeout
+	}()
+
+	inputPath := filepath.Join(t.TempDir(), "req.md")
+	if err := os.WriteFile(inputPath, []byte("# 输入"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	outDir := t.TempDir()
+	oldStderr := os.Stderr
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	os.Stderr = w
+	stderr := make(chan string, 1)
+	go func() {
+		b, _ := io.ReadAll(r)
+		stderr <- string(b)
+	}()
+	out, err := captureStdoutRun(t, func() error {
+		return RunGen(context.Background(), GenOptions{OutputDir: outDir, Inputs: []string{inputPath}, Stdout: "both"})
+	})
+	_ = w.Close()
+	os.Stderr = oldStderr
+	logs := <-stderr
+	if err != nil {
+		t.Fatalf("RunGen error: %v\n%s", err, logs)
+	}
+	if out != "# EN\n\n# CN\n" {
+		t.Fatalf("stdout=%q", out)
+	}
+	if !strings.Contains(logs, "任务完成：成功 1") {
+		t.Fatalf("logs should go to stderr: %q", logs)
+	}
+	if entries, _ := os.ReadDir(outDir); len(entries) != 0 {
+		t.Fatalf("--stdout should not write outputs, got %d entries", len(entries))
+	}
+}
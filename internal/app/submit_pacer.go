@@ -0,0 +1,145 @@
+package app
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"syl-listing-pro/internal/i18n"
+)
+
+// submitPacer 控制新任务的提交节奏：按 --submit-rate 均匀间隔提交，并且只在 --submit-window 时段内提交。
+// 已提交任务的跟踪不受影响。nil 表示不限制。
+type submitPacer struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+	window   *submitWindow
+	now      func() time.Time
+	// waitingLogged 避免每个任务都重复提示等待时段。
+	waitingLogged bool
+}
+
+// submitWindow 为每天允许提交的时段（本地时间，分钟数）；end 小于 start 表示跨午夜。
+type submitWindow struct {
+	start, end int
+}
+
+// newSubmitPacer 解析 --submit-rate 与 --submit-window；两者都为空时返回 nil。
+func newSubmitPacer(rate, window string) (*submitPacer, error) {
+	interval, err := parseSubmitRate(rate)
+	if err != nil {
+		return nil, err
+	}
+	w, err := parseSubmitWindow(window)
+	if err != nil {
+		return nil, err
+	}
+	if interval == 0 && w == nil {
+		return nil, nil
+	}
+	return &submitPacer{interval: interval, window: w, now: time.Now}, nil
+}
+
+// parseSubmitRate 解析 N/sec|min|hour（如 10/min），返回相邻两次提交的最小间隔；为空时返回 0。
+func parseSubmitRate(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, nil
+	}
+	count, unit, ok := strings.Cut(s, "/")
+	n, err := strconv.Atoi(strings.TrimSpace(count))
+	if !ok || err != nil || n <= 0 {
+		return 0, i18n.Errorf("--submit-rate 格式应为 N/sec、N/min 或 N/hour，例如 10/min：%s", s)
+	}
+	var per time.Duration
+	switch strings.ToLower(strings.TrimSpace(unit)) {
+	case "s", "sec", "second":
+		per = time.Second
+	case "m", "min", "minute":
+		per = time.Minute
+	case "h", "hour":
+		per = time.Hour
+	default:
+		return 0, i18n.Errorf("--submit-rate 格式应为 N/sec、N/min 或 N/hour，例如 10/min：%s", s)
+	}
+	return per / time.Duration(n), nil
+}
+
+// parseSubmitWindow 解析 HH:MM-HH:MM（如 00:00-06:00，可跨午夜）；为空时返回 nil。
+func parseSubmitWindow(s string) (*submitWindow, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, nil
+	}
+	from, to, ok := strings.Cut(s, "-")
+	start, err1 := parseClock(from)
+	end, err2 := parseClock(to)
+	if !ok || err1 != nil || err2 != nil || start == end {
+		return nil, i18n.Errorf("--submit-window 格式应为 HH:MM-HH:MM，例如 00:00-06:00：%s", s)
+	}
+	return &submitWindow{start: start, end: end}, nil
+}
+
+func parseClock(s string) (int, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(s))
+	if err != nil {
+		return 0, err
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// untilOpen 返回从 now 起到时段开放还需等待的时长；已在时段内时返回 0。
+func (w *submitWindow) untilOpen(now time.Time) time.Duration {
+	cur := now.Hour()*60 + now.Minute()
+	inside := cur >= w.start && cur < w.end
+	if w.end < w.start {
+		inside = cur >= w.start || cur < w.end
+	}
+	if inside {
+		return 0
+	}
+	open := time.Date(now.Year(), now.Month(), now.Day(), w.start/60, w.start%60, 0, 0, now.Location())
+	if !open.After(now) {
+		open = open.AddDate(0, 0, 1)
+	}
+	return open.Sub(now)
+}
+
+// wait 阻塞到允许提交下一个任务为止；ctx 取消时返回其错误。
+func (p *submitPacer) wait(ctx context.Context, log *Logger, prefix string) error {
+	if p == nil {
+		return nil
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		now := p.now()
+		var d time.Duration
+		if p.window != nil {
+			if d = p.window.untilOpen(now); d > 0 && !p.waitingLogged {
+				p.waitingLogged = true
+				log.Info(i18n.T("%s 不在提交时段（--submit-window）内，等待至 %s 再提交", prefix, now.Add(d).Format("01-02 15:04")))
+			}
+		}
+		if d == 0 {
+			if d = p.next.Sub(now); d <= 0 {
+				p.waitingLogged = false
+				p.next = now.Add(p.interval)
+				return nil
+			}
+		}
+		timer := time.NewTimer(d)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
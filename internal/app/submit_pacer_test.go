@@ -0,0 +1,90 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseSubmitRate(t *testing.T) {
+	cases := map[string]time.Duration{"": 0, "10/min": 6 * time.Second, "2/sec": 500 * time.Millisecond, " 60 / hour ": time.Minute}
+	for in, want := range cases {
+		got, err := parseSubmitRate(in)
+		if err != nil || got != want {
+			t.Fatalf("parseSubmitRate(%q)=%v,%v want %v", in, got, err, want)
+		}
+	}
+	for _, bad := range []string{"10", "0/min", "x/min", "10/day"} {
+		if _, err := parseSubmitRate(bad); err == nil {
+			t.Fatalf("parseSubmitRate(%q) should fail", bad)
+		}
+	}
+}
+
+func TestSubmitWindow_UntilOpen(t *testing.T) {
+	night, err := parseSubmitWindow("22:00-06:00")
+	if err != nil {
+		t.Fatal(err)
+	}
+	day := func(h, m int) time.Time { return time.Date(2026, 3, 1, h, m, 0, 0, time.Local) }
+	cases := []struct {
+		at   time.Time
+		want time.Duration
+	}{
+		{day(23, 0), 0},
+		{day(5, 59), 0},
+		{day(6, 0), 16 * time.Hour},
+		{day(21, 30), 30 * time.Minute},
+	}
+	for _, c := range cases {
+		if got := night.untilOpen(c.at); got != c.want {
+			t.Fatalf("untilOpen(%s)=%s want %s", c.at.Format("15:04"), got, c.want)
+		}
+	}
+	early, _ := parseSubmitWindow("00:00-06:00")
+	if got := early.untilOpen(day(7, 0)); got != 17*time.Hour {
+		t.Fatalf("untilOpen(07:00)=%s want 17h", got)
+	}
+	for _, bad := range []string{"00:00", "25:00-06:00", "06:00-06:00"} {
+		if _, err := parseSubmitWindow(bad); err == nil {
+			t.Fatalf("parseSubmitWindow(%q) should fail", bad)
+		}
+	}
+}
+
+func TestSubmitPacer_SpacesSubmissions(t *testing.T) {
+	p, err := newSubmitPacer("20/sec", "")
+	if err != nil || p == nil {
+		t.Fatalf("newSubmitPacer: %v %v", p, err)
+	}
+	lg, _ := NewLogger(false, "")
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := p.wait(context.Background(), lg, ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 100*time.Millisecond {
+		t.Fatalf("3 submissions at 20/sec took %s, want >= 100ms", elapsed)
+	}
+	if p, _ := newSubmitPacer("", ""); p != nil {
+		t.Fatal("empty options should disable pacing")
+	}
+}
+
+func TestSubmitPacer_WaitCanceled(t *testing.T) {
+	p, _ := newSubmitPacer("", "00:00-00:01")
+	p.now = func() time.Time { return time.Date(2026, 3, 1, 12, 0, 0, 0, time.Local) }
+	lg, _ := NewLogger(false, "")
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	out := captureStdout(t, func() {
+		if err := p.wait(ctx, lg, "[a]"); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("wait err=%v", err)
+		}
+	})
+	if out == "" {
+		t.Fatal("expected a waiting hint")
+	}
+}
@@ -0,0 +1,137 @@
+package app
+
+import (
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"syl-listing-pro/internal/client"
+	"syl-listing-pro/internal/i18n"
+)
+
+const (
+	// summaryLabelWidth 为汇总表任务列的最大显示宽度。
+	summaryLabelWidth = 40
+	// summaryErrorWidth 为汇总表中错误摘要的最大显示宽度。
+	summaryErrorWidth = 60
+)
+
+// taskSummary 收集各任务的最终结果，运行结束时输出为对齐的汇总表；重试中的任务以最后一轮为准。
+type taskSummary struct {
+	mu   sync.Mutex
+	rows map[string]summaryRow
+}
+
+type summaryRow struct {
+	status   string
+	duration time.Duration
+	detail   string
+}
+
+func newTaskSummary() *taskSummary {
+	return &taskSummary{rows: map[string]summaryRow{}}
+}
+
+func (s *taskSummary) record(task generateTask, result taskResult, canceled bool) {
+	row := summaryRow{duration: result.duration}
+	switch {
+	case result.ok:
+		row.status = i18n.T("成功")
+		if len(result.outputs) > 0 {
+			row.detail = filepath.Base(result.outputs[0])
+		}
+	case result.skipped:
+		row.status = i18n.T("已跳过")
+		if len(result.outputs) > 0 {
+			row.detail = i18n.T("已存在：%s", filepath.Base(result.outputs[0]))
+		}
+	case canceled || (result.err == nil && !result.ok):
+		row.status = i18n.T("已取消")
+	default:
+		row.status = i18n.T("失败")
+		row.detail = clipWidth(strings.Join(strings.Fields(client.RedactText(result.err.Error())), " "), summaryErrorWidth)
+	}
+	s.mu.Lock()
+	s.rows[task.key()] = row
+	s.mu.Unlock()
+}
+
+// write 按任务顺序输出汇总表；没有结果的任务标为未完成。
+func (s *taskSummary) write(log *Logger, tasks []generateTask) {
+	header := []string{i18n.T("任务"), i18n.T("状态"), i18n.T("耗时"), i18n.T("产物/错误")}
+	table := [][]string{header}
+	s.mu.Lock()
+	for _, task := range tasks {
+		row, ok := s.rows[task.key()]
+		if !ok {
+			table = append(table, []string{clipWidth(task.label, summaryLabelWidth), i18n.T("未完成"), "-", ""})
+			continue
+		}
+		table = append(table, []string{clipWidth(task.label, summaryLabelWidth), row.status, humanDurationShort(row.duration), row.detail})
+	}
+	s.mu.Unlock()
+	widths := make([]int, len(header))
+	for _, cells := range table {
+		for i, c := range cells {
+			widths[i] = max(widths[i], displayWidth(c))
+		}
+	}
+	for _, cells := range table {
+		var b strings.Builder
+		for i, c := range cells {
+			if i > 0 {
+				b.WriteString("  ")
+			}
+			b.WriteString(c)
+			if i < len(cells)-1 {
+				b.WriteString(strings.Repeat(" ", widths[i]-displayWidth(c)))
+			}
+		}
+		log.Info(strings.TrimRight(b.String(), " "))
+	}
+}
+
+// displayWidth 返回文本在终端中占的列数：中日韩文字、全角符号与 emoji 按 2 列计，组合符号不占列。
+func displayWidth(s string) int {
+	n := 0
+	for _, r := range s {
+		n += runeWidth(r)
+	}
+	return n
+}
+
+func runeWidth(r rune) int {
+	switch {
+	case isZeroWidth(r):
+		return 0
+	case unicode.Is(unicode.Han, r), unicode.Is(unicode.Hangul, r), unicode.Is(unicode.Hiragana, r), unicode.Is(unicode.Katakana, r):
+		return 2
+	case r >= 0x3000 && r <= 0x303F, r >= 0xFF01 && r <= 0xFF60, r >= 0xFFE0 && r <= 0xFFE6:
+		return 2
+	case r >= 0x1F300 && r <= 0x1FAFF:
+		return 2
+	}
+	return 1
+}
+
+// isZeroWidth 判断组合符号、零宽连接符、变体选择符与 emoji 肤色修饰符等不单独占列的字符。
+func isZeroWidth(r rune) bool {
+	return unicode.In(r, unicode.Mn, unicode.Me) || r == 0x200B || r == 0x200D ||
+		(r >= 0xFE00 && r <= 0xFE0F) || (r >= 0x1F3FB && r <= 0x1F3FF)
+}
+
+// clipWidth 把 s 截到最多 width 列（含结尾的 "..."），按显示宽度计算，中日韩文字不会被截出半个字符的宽度。
+func clipWidth(s string, width int) string {
+	if width <= 0 || displayWidth(s) <= width {
+		return s
+	}
+	runes := []rune(s)
+	n, w := 0, 0
+	for n < len(runes) && w+runeWidth(runes[n]) <= width-3 {
+		w += runeWidth(runes[n])
+		n++
+	}
+	return strings.TrimSpace(string(runes[:clusterCut(runes, n)])) + "..."
+}
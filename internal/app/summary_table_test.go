@@ -0,0 +1,107 @@
+package app
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"syl-listing-pro/internal/input"
+)
+
+func TestDisplayWidth(t *testing.T) {
+	cases := map[string]int{"abc": 3, "成功": 4, "a：b": 4, "": 0}
+	for s, want := range cases {
+		if got := displayWidth(s); got != want {
+			t.Fatalf("displayWidth(%q)=%d, want %d", s, got, want)
+		}
+	}
+}
+
+func TestClipWidth(t *testing.T) {
+	cases := []struct {
+		in    string
+		width int
+		want  string
+	}{
+		{"abcdef", 10, "abcdef"},
+		{"abcdefghijk", 8, "abcde..."},
+		{"规则区间超出上限", 9, "规则区..."},
+		{"规则区间超出上限", 10, "规则区..."},
+		{"a规则", 5, "a规则"},
+		{"ab规则区间", 6, "ab..."},
+	}
+	for _, c := range cases {
+		got := clipWidth(c.in, c.width)
+		if got != c.want {
+			t.Fatalf("clipWidth(%q, %d)=%q, want %q", c.in, c.width, got, c.want)
+		}
+		if displayWidth(got) > c.width {
+			t.Fatalf("clipWidth(%q, %d) too wide: %q", c.in, c.width, got)
+		}
+	}
+	if got := displayWidth("e\u0301👍🏽"); got != 3 {
+		t.Fatalf("displayWidth with combining marks = %d, want 3", got)
+	}
+}
+
+func TestTaskSummary_WriteAlignedRows(t *testing.T) {
+	logPath := filepath.Join(t.TempDir(), "run.log")
+	lg, err := NewLogger(false, logPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ok := generateTask{file: input.RequirementFile{Path: "a.md"}, index: 1, label: "a.md"}
+	bad := generateTask{file: input.RequirementFile{Path: "长名字.md"}, index: 1, label: "长名字.md"}
+	pending := generateTask{file: input.RequirementFile{Path: "c.md"}, index: 1, label: "c.md"}
+
+	s := newTaskSummary()
+	s.record(ok, taskResult{ok: true, duration: 2 * time.Second, outputs: []string{"/out/a_en.md", "/out/a_cn.md"}}, false)
+	s.record(bad, taskResult{err: errors.New("生成失败：\n  rules   not found"), duration: time.Second}, false)
+	_ = captureStdout(t, func() { s.write(lg, []generateTask{ok, bad, pending}) })
+	lg.Close()
+
+	b, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var rows []string
+	for _, line := range strings.Split(strings.TrimSpace(string(b)), "\n") {
+		if i := strings.Index(line, "] "); i >= 0 {
+			line = line[i+2:]
+		}
+		rows = append(rows, line)
+	}
+	if len(rows) != 4 {
+		t.Fatalf("want header + 3 rows, got %q", rows)
+	}
+	if !strings.Contains(rows[1], "a_en.md") || !strings.Contains(rows[2], "生成失败： rules not found") || !strings.Contains(rows[3], "未完成") {
+		t.Fatalf("unexpected rows: %q", rows)
+	}
+	// 状态列在各行中的显示位置一致。
+	col := displayWidth(rows[0][:strings.Index(rows[0], "状态")])
+	for _, r := range rows[1:] {
+		var prefix string
+		for _, st := range []string{"成功", "失败", "未完成"} {
+			if i := strings.Index(r, st); i >= 0 {
+				prefix = r[:i]
+				break
+			}
+		}
+		if displayWidth(prefix) != col {
+			t.Fatalf("status column misaligned in %q: %d != %d", r, displayWidth(prefix), col)
+		}
+	}
+}
+
+func TestTaskSummary_RecordSkipped(t *testing.T) {
+	task := generateTask{file: input.RequirementFile{Path: "a.md"}, index: 1, label: "a.md"}
+	s := newTaskSummary()
+	s.record(task, taskResult{skipped: true, outputs: []string{"/out/a_en.md", "/out/a_cn.md"}}, false)
+	row := s.rows[task.key()]
+	if row.status != "已跳过" || row.detail != "已存在：a_en.md" {
+		t.Fatalf("unexpected row: %+v", row)
+	}
+}
@@ -0,0 +1,71 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"syl-listing-pro/internal/client"
+	"syl-listing-pro/internal/i18n"
+)
+
+// RunTenantInfo 打印服务端对当前租户的配置与限制（套餐、并发、候选数、规则通道），
+// 便于在生成前了解服务端限制，而不是从报错中发现。
+func RunTenantInfo(ctx context.Context, w io.Writer, profile string) error {
+	sylKey, err := loadSYLKeyForRun(profile)
+	if err != nil {
+		return err
+	}
+	api := client.New(resolveWorkerBaseURL())
+	ex, err := api.Exchange(ctx, sylKey)
+	if err != nil {
+		return withRemediation(err)
+	}
+	info, err := api.TenantInfo(ctx, ex.AccessToken)
+	if err != nil {
+		if errors.Is(err, client.ErrTenantInfoUnsupported) {
+			return i18n.Errorf("服务端暂不支持查询租户信息")
+		}
+		return err
+	}
+	tenant := info.TenantID
+	if tenant == "" {
+		tenant = ex.TenantID
+	}
+	if info.Name != "" {
+		tenant = i18n.T("%s（%s）", tenant, info.Name)
+	}
+	fmt.Fprintln(w, i18n.T("租户：%s", tenant))
+	if info.Plan != "" {
+		fmt.Fprintln(w, i18n.T("套餐：%s", info.Plan))
+	}
+	if info.MaxConcurrentJobs > 0 {
+		fmt.Fprintln(w, i18n.T("并发任务上限：%d", info.MaxConcurrentJobs))
+	}
+	if info.MaxQueuedJobs > 0 {
+		fmt.Fprintln(w, i18n.T("排队任务上限：%d", info.MaxQueuedJobs))
+	}
+	if info.SubmitRatePerMinute > 0 {
+		fmt.Fprintln(w, i18n.T("提交速率上限：%d/min", info.SubmitRatePerMinute))
+	}
+	if len(info.AllowedCandidateCounts) > 0 {
+		counts := make([]string, 0, len(info.AllowedCandidateCounts))
+		for _, n := range info.AllowedCandidateCounts {
+			counts = append(counts, strconv.Itoa(n))
+		}
+		fmt.Fprintln(w, i18n.T("允许的候选数：%s", strings.Join(counts, ", ")))
+	}
+	if info.RulesChannel != "" {
+		fmt.Fprintln(w, i18n.T("规则通道：%s", info.RulesChannel))
+	}
+	if info.RulesVersion != "" {
+		fmt.Fprintln(w, i18n.T("规则版本：%s", info.RulesVersion))
+	}
+	if len(info.Marketplaces) > 0 {
+		fmt.Fprintln(w, i18n.T("可用站点：%s", strings.Join(info.Marketplaces, ", ")))
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRunTenantInfo(t *testing.T) {
+	prepareRunGenHome(t)
+	supported := true
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/auth/exchange":
+			_, _ = io.WriteString(w, `{"access_token":"at","tenant_id":"demo","expires_in":3600}`)
+		case "/v1/tenant":
+			if !supported {
+				http.NotFound(w, r)
+				return
+			}
+			_, _ = io.WriteString(w, `{"plan":"pro","max_concurrent_jobs":8,"submit_rate_per_minute":30,"allowed_candidate_counts":[1,3,5],"rules_channel":"stable","rules_version":"v7"}`)
+		default:
+			t.Fatalf("unexpected request: %s", r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+	oldBase := workerBaseURL
+	workerBaseURL = ts.URL
+	defer func() { workerBaseURL = oldBase }()
+
+	var buf bytes.Buffer
+	if err := RunTenantInfo(context.Background(), &buf, ""); err != nil {
+		t.Fatalf("RunTenantInfo error: %v", err)
+	}
+	for _, want := range []string{"租户：demo", "套餐：pro", "并发任务上限：8", "提交速率上限：30/min", "允许的候选数：1, 3, 5", "规则通道：stable", "规则版本：v7"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Fatalf("missing %q in:\n%s", want, buf.String())
+		}
+	}
+	if strings.Contains(buf.String(), "排队任务上限") {
+		t.Fatalf("unset limits should be omitted:\n%s", buf.String())
+	}
+	supported = false
+	if err := RunTenantInfo(context.Background(), &buf, ""); err == nil || !strings.Contains(err.Error(), "暂不支持") {
+		t.Fatalf("unexpected err: %v", err)
+	}
+}
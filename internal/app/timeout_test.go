@@ -0,0 +1,65 @@
+package app
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRunGen_TimeoutCancelsSubmittedJobs(t *testing.T) {
+	prepareRunGenHome(t)
+
+	var cancelled atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/auth/exchange":
+			_, _ = io.WriteString(w, `{"access_token":"at","tenant_id":"demo","expires_in":3600}`)
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/generate":
+			_, _ = io.WriteString(w, `{"job_id":"job_slow","status":"queued"}`)
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/jobs/job_slow/events":
+			w.Header().Set("Content-Type", "text/event-stream")
+			w.(http.Flusher).Flush()
+			<-r.Context().Done()
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/jobs/job_slow/cancel":
+			cancelled.Add(1)
+			_, _ = io.WriteString(w, `{"ok":true,"job_id":"job_slow","status":"cancelled","cancelled":true}`)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+	oldBase, oldTimeout := workerBaseURL, streamTimeoutSecond
+	workerBaseURL = ts.URL
+	streamTimeoutSecond = 30
+	defer func() { workerBaseURL, streamTimeoutSecond = oldBase, oldTimeout }()
+
+	inputPath := filepath.Join(t.TempDir(), "req.md")
+	if err := os.WriteFile(inputPath, []byte("# 输入"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	start := time.Now()
+	out, err := captureStdoutRun(t, func() error {
+		return RunGen(context.Background(), GenOptions{OutputDir: t.TempDir(), Inputs: []string{inputPath}, SkipDocx: true, Timeout: 300 * time.Millisecond})
+	})
+	if err == nil || !strings.Contains(err.Error(), "--timeout") {
+		t.Fatalf("expected timeout error, got %v", err)
+	}
+	if time.Since(start) > 10*time.Second {
+		t.Fatalf("run was not bounded: %s", time.Since(start))
+	}
+	if cancelled.Load() != 1 {
+		t.Fatalf("cancel calls=%d want 1", cancelled.Load())
+	}
+	for _, want := range []string{"运行已达 --timeout", "未完成 1", "requeue"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("missing %q in output:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package app
+
+import (
+	"strings"
+	"sync"
+)
+
+// traceSampler 对 --verbose 下的高频追踪事件按 1/n 采样；错误与警告级别总是保留。
+type traceSampler struct {
+	n      int
+	mu     sync.Mutex
+	counts map[string]int
+}
+
+func newTraceSampler(n int) *traceSampler {
+	if n < 1 {
+		n = 1
+	}
+	return &traceSampler{n: n, counts: map[string]int{}}
+}
+
+// keep 判断同一类事件（key）的本次出现是否写入日志：每类的第 1、n+1、2n+1… 次保留。
+func (s *traceSampler) keep(key, level string) bool {
+	if s == nil || s.n <= 1 {
+		return true
+	}
+	switch strings.ToLower(level) {
+	case "error", "warn", "warning":
+		return true
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c := s.counts[key]
+	s.counts[key] = c + 1
+	return c%s.n == 0
+}
@@ -0,0 +1,141 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"syl-listing-pro/internal/config"
+	"syl-listing-pro/internal/i18n"
+	"syl-listing-pro/internal/listing"
+	"syl-listing-pro/internal/output"
+)
+
+// requiredSections 为每份 listing 必须包含的分节。
+var requiredSections = []string{listing.KindTitle, listing.KindBullets, listing.KindDescription}
+
+// RunVerifyOutput 检查已生成（可能手工改过）的 listing：必需分节是否齐全、是否命中禁用词，
+// 以及同名 .json 附带文件中记录的长度规则区间；有问题时返回错误。
+func RunVerifyOutput(w io.Writer, paths []string, bannedWordsFile string) error {
+	files, err := collectOutputMarkdown(paths)
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return i18n.Errorf("未找到 listing 产物（文件名形如 xxx_en.md）")
+	}
+	banned, err := config.LoadBannedWords(bannedWordsFile)
+	if err != nil {
+		return err
+	}
+	failed := 0
+	for _, path := range files {
+		issues, err := verifyOutputFile(path, banned)
+		if err != nil {
+			return err
+		}
+		if len(issues) == 0 {
+			fmt.Fprintln(w, i18n.T("[通过] %s", mustAbsPath(path)))
+			continue
+		}
+		failed++
+		fmt.Fprintln(w, i18n.T("[问题] %s", mustAbsPath(path)))
+		for _, is := range issues {
+			fmt.Fprintf(w, "       - %s\n", is)
+		}
+	}
+	fmt.Fprintln(w, i18n.T("共检查 %d 个文件，%d 个有问题", len(files), failed))
+	if failed > 0 {
+		return i18n.Errorf("%d 个文件未通过检查", failed)
+	}
+	return nil
+}
+
+// collectOutputMarkdown 展开参数：文件原样保留，目录下只取带语言后缀的产物。
+func collectOutputMarkdown(paths []string) ([]string, error) {
+	var out []string
+	for _, p := range paths {
+		info, err := os.Stat(p)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			out = append(out, p)
+			continue
+		}
+		err = filepath.WalkDir(p, func(path string, d os.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return err
+			}
+			if strings.EqualFold(filepath.Ext(path), ".md") && output.LangOf(path) != "" {
+				out = append(out, path)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+func verifyOutputFile(path string, banned []string) ([]string, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, i18n.Errorf("读取 %s 失败: %w", path, err)
+	}
+	md := string(b)
+	parsed := listing.Parse(md)
+	var issues []string
+	for _, key := range requiredSections {
+		if s, ok := parsed.Section(key); !ok || strings.TrimSpace(s.Body) == "" {
+			issues = append(issues, i18n.T("缺少分节：%s", key))
+		}
+	}
+	if hits := listing.FindBannedWords(md, banned); len(hits) > 0 {
+		issues = append(issues, i18n.T("命中禁用词：%s", strings.Join(hits, ", ")))
+	}
+	if bullets, ok := parsed.Section(listing.KindBullets); ok {
+		rules := sidecarItemRules(path)
+		for i, item := range bullets.Items {
+			r, ok := rules[i+1]
+			if !ok {
+				continue
+			}
+			n := utf8.RuneCountInString(item)
+			if n < r.TolMin || n > r.TolMax {
+				issues = append(issues, i18n.T("第 %d 条长度不满足约束：%s", i+1, formatLengthConstraintRange(strconv.Itoa(n), strconv.Itoa(r.Min), strconv.Itoa(r.Max), strconv.Itoa(r.TolMin), strconv.Itoa(r.TolMax))))
+			}
+		}
+	}
+	return issues, nil
+}
+
+// sidecarItemRules 读取同名 .json 中该语言五点描述各条的规则区间；没有附带文件时返回空。
+func sidecarItemRules(path string) map[int]lengthRule {
+	b, err := os.ReadFile(sidecarPath(path))
+	if err != nil {
+		return nil
+	}
+	var sc listingSidecar
+	if json.Unmarshal(b, &sc) != nil {
+		return nil
+	}
+	rules := map[int]lengthRule{}
+	for _, c := range sc.CharCounts[output.LangOf(path)] {
+		if c.Section != listing.KindBullets {
+			continue
+		}
+		for i, it := range c.Items {
+			if it.Rule != nil {
+				rules[i+1] = *it.Rule
+			}
+		}
+	}
+	return rules
+}
@@ -0,0 +1,53 @@
+package app
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunVerifyOutput(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	dir := t.TempDir()
+	good := "# Title\nSteel Bottle\n\n## Bullets\n- " + strings.Repeat("a", 10) + "\n- second\n\n## Description\nBody text\n"
+	if err := os.WriteFile(filepath.Join(dir, "good_AbCd_en.md"), []byte(good), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	bad := "# Title\nBest cheap bottle\n\n## Bullets\n- " + strings.Repeat("b", 30) + "\n"
+	if err := os.WriteFile(filepath.Join(dir, "bad_WxYz_en.md"), []byte(bad), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	sidecar := `{"char_counts":{"en":[{"section":"bullets","chars":30,"bytes":30,"items":[{"chars":12,"bytes":12,"rule":{"min":10,"max":20,"tolerance_min":8,"tolerance_max":22}}]}]}}`
+	if err := os.WriteFile(filepath.Join(dir, "bad_WxYz.json"), []byte(sidecar), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "input.md"), []byte("# not an output"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	words := filepath.Join(t.TempDir(), "banned.txt")
+	if err := os.WriteFile(words, []byte("best\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	err := RunVerifyOutput(&buf, []string{dir}, words)
+	if err == nil || !strings.Contains(err.Error(), "1 个文件未通过检查") {
+		t.Fatalf("err=%v\n%s", err, buf.String())
+	}
+	out := buf.String()
+	for _, want := range []string{"[通过] " + filepath.Join(dir, "good_AbCd_en.md"), "[问题] " + filepath.Join(dir, "bad_WxYz_en.md"), "缺少分节：description", "命中禁用词：best", "第 1 条长度不满足约束：[8[10,20]22] < 30 高于上限", "共检查 2 个文件，1 个有问题"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("missing %q in:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "input.md") {
+		t.Fatalf("requirement files in directories must be skipped:\n%s", out)
+	}
+
+	buf.Reset()
+	if err := RunVerifyOutput(&buf, []string{filepath.Join(dir, "good_AbCd_en.md")}, words); err != nil {
+		t.Fatalf("good file should pass: %v\n%s", err, buf.String())
+	}
+}
@@ -0,0 +1,71 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"syl-listing-pro/internal/client"
+	"syl-listing-pro/internal/i18n"
+	"syl-listing-pro/internal/util"
+)
+
+// RunWhoami 换取访问令牌并打印当前身份，便于排查 KEY 与租户配置。
+func RunWhoami(ctx context.Context, w io.Writer, profile string) error {
+	profile, err := resolveKeyProfile(profile)
+	if err != nil {
+		return err
+	}
+	sylKey, err := loadSYLKeyForRun(profile)
+	if err != nil {
+		return err
+	}
+	baseURL := resolveWorkerBaseURL()
+	api := client.New(baseURL)
+	ex, err := api.Exchange(ctx, sylKey)
+	if err != nil {
+		return withRemediation(err)
+	}
+	fmt.Fprintln(w, i18n.T("租户：%s", ex.TenantID))
+	fmt.Fprintln(w, i18n.T("KEY 配置：%s", profile))
+	fmt.Fprintln(w, i18n.T("KEY：%s", maskKey(sylKey)))
+	fmt.Fprintln(w, i18n.T("服务端：%s", baseURL))
+	if ex.ExpiresIn > 0 {
+		expiry := time.Now().Add(time.Duration(ex.ExpiresIn) * time.Second)
+		fmt.Fprintln(w, i18n.T("令牌有效期至：%s（%s 后过期）", expiry.Format("2006-01-02 15:04:05"), humanDurationShort(time.Duration(ex.ExpiresIn)*time.Second)))
+	}
+	if version := latestRulesVersion(); version != "" {
+		fmt.Fprintln(w, i18n.T("规则版本：%s（最近一次运行）", version))
+	}
+	if dir, err := util.DefaultAppDir(); err == nil {
+		fmt.Fprintln(w, i18n.T("本地目录：%s", dir))
+	}
+	return nil
+}
+
+// maskKey 只保留末 4 位，其余以 * 代替。
+func maskKey(key string) string {
+	const keep = 4
+	if len(key) <= keep {
+		return strings.Repeat("*", len(key))
+	}
+	return "****" + key[len(key)-keep:]
+}
+
+// latestRulesVersion 返回本地最近一次运行记录中的规则版本；规则由服务端下发，CLI 不缓存。
+func latestRulesVersion() string {
+	runs, err := loadRunHistory(0, time.Now())
+	if err != nil {
+		return ""
+	}
+	for _, m := range runs {
+		for _, task := range m.Tasks {
+			if task.RulesVersion != "" {
+				return task.RulesVersion
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,48 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"syl-listing-pro/internal/manifest"
+)
+
+func TestRunWhoami(t *testing.T) {
+	prepareRunGenHome(t)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/auth/exchange" {
+			t.Fatalf("unexpected request: %s", r.URL.Path)
+		}
+		_, _ = io.WriteString(w, `{"access_token":"at","tenant_id":"demo","expires_in":3600}`)
+	}))
+	defer ts.Close()
+	oldBase := workerBaseURL
+	workerBaseURL = ts.URL
+	defer func() { workerBaseURL = oldBase }()
+
+	runsDir := filepath.Join(os.Getenv("HOME"), ".syl-listing-pro", "runs")
+	m := manifest.Manifest{RunID: "20260101-000000-aaaa", Tasks: []manifest.Task{{Key: "k", RulesVersion: "rules-v7"}}}
+	if _, err := manifest.Create(runsDir, m); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := RunWhoami(context.Background(), &buf, ""); err != nil {
+		t.Fatalf("RunWhoami error: %v", err)
+	}
+	for _, want := range []string{"租户：demo", "KEY 配置：default", "令牌有效期至：", "规则版本：rules-v7", "本地目录："} {
+		if !strings.Contains(buf.String(), want) {
+			t.Fatalf("missing %q in:\n%s", want, buf.String())
+		}
+	}
+	if got := maskKey("sk-abcdef1234"); got != "****1234" {
+		t.Fatalf("maskKey=%q", got)
+	}
+}
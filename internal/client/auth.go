@@ -0,0 +1,98 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// AuthFailure 是 Exchange 失败的原因分类。
+type AuthFailure string
+
+const (
+	AuthFailureInvalidKey AuthFailure = "invalid_key"
+	AuthFailureExpiredKey AuthFailure = "expired_key"
+	AuthFailureClockSkew  AuthFailure = "clock_skew"
+	AuthFailureNetwork    AuthFailure = "network"
+	AuthFailureServer     AuthFailure = "server"
+	AuthFailureUnknown    AuthFailure = "unknown"
+)
+
+// maxClockSkew 为认证失败时判定为时钟偏差的阈值。
+const maxClockSkew = 5 * time.Minute
+
+// AuthDiagnosis 描述一次认证失败：原因分类、HTTP 状态码、服务端说明与时钟偏差。
+type AuthDiagnosis struct {
+	Failure    AuthFailure
+	StatusCode int
+	Message    string
+	// ClockSkew 为服务端时间减本机时间；响应没有 Date 头时为 0。
+	ClockSkew time.Duration
+}
+
+// DiagnoseAuthError 根据 Exchange 返回的错误判断失败原因，now 为本机当前时间。
+func DiagnoseAuthError(err error, now time.Time) AuthDiagnosis {
+	var statusErr *httpStatusError
+	if !errors.As(err, &statusErr) {
+		if isRetryableRequestErr(err) || isNetworkErr(err) {
+			return AuthDiagnosis{Failure: AuthFailureNetwork, Message: err.Error()}
+		}
+		return AuthDiagnosis{Failure: AuthFailureUnknown, Message: err.Error()}
+	}
+	d := AuthDiagnosis{StatusCode: statusErr.statusCode, Message: authErrorMessage(statusErr.body)}
+	if !statusErr.date.IsZero() {
+		d.ClockSkew = statusErr.date.Sub(now).Round(time.Second)
+	}
+	switch {
+	case statusErr.statusCode == http.StatusUnauthorized || statusErr.statusCode == http.StatusForbidden:
+		reason := strings.ToLower(statusErr.body)
+		switch {
+		case containsAny(reason, "skew", "clock", "not yet valid", "timestamp"):
+			d.Failure = AuthFailureClockSkew
+		case d.ClockSkew > maxClockSkew || d.ClockSkew < -maxClockSkew:
+			d.Failure = AuthFailureClockSkew
+		case strings.Contains(reason, "expire"):
+			d.Failure = AuthFailureExpiredKey
+		default:
+			d.Failure = AuthFailureInvalidKey
+		}
+	case statusErr.statusCode == http.StatusTooManyRequests || statusErr.statusCode/100 == 5:
+		d.Failure = AuthFailureServer
+	default:
+		d.Failure = AuthFailureUnknown
+	}
+	return d
+}
+
+// authErrorMessage 提取 {"error":...} / {"message":...} 形式的说明，否则返回原始响应体。
+func authErrorMessage(body string) string {
+	var payload struct {
+		Error   string `json:"error"`
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal([]byte(body), &payload); err == nil {
+		if payload.Message != "" {
+			return payload.Message
+		}
+		if payload.Error != "" {
+			return payload.Error
+		}
+	}
+	return strings.TrimSpace(body)
+}
+
+func isNetworkErr(err error) bool {
+	msg := strings.ToLower(err.Error())
+	return containsAny(msg, "no such host", "dial tcp", "network is unreachable", "proxyconnect")
+}
+
+func containsAny(s string, subs ...string) bool {
+	for _, sub := range subs {
+		if strings.Contains(s, sub) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,199 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"syl-listing-pro/internal/i18n"
+)
+
+// cassetteVersion 为录制文件格式版本。
+const cassetteVersion = 1
+
+// cassetteHeaders 为录制时保留的响应头，其余响应头与全部请求头（含 Authorization）都不写入。
+var cassetteHeaders = []string{"Content-Type", "Retry-After"}
+
+// Interaction 是录制文件中的一次请求与响应。
+type Interaction struct {
+	Method       string            `json:"method"`
+	URI          string            `json:"uri"`
+	RequestBody  string            `json:"request_body,omitempty"`
+	Status       int               `json:"status"`
+	Header       map[string]string `json:"header,omitempty"`
+	ResponseBody string            `json:"response_body"`
+}
+
+// Cassette 是 --record 写出、--replay 读入的录制文件。
+type Cassette struct {
+	Version      int           `json:"version"`
+	BaseURL      string        `json:"base_url,omitempty"`
+	Interactions []Interaction `json:"interactions"`
+}
+
+// StartRecording 开始录制之后的所有请求与响应，由 SaveCassette 脱敏后写出。
+func (a *API) StartRecording() {
+	rec := &recorder{next: a.http.Transport}
+	if rec.next == nil {
+		rec.next = http.DefaultTransport
+	}
+	a.http.Transport = rec
+	a.recorder = rec
+}
+
+// SaveCassette 把已录制的请求与响应写到 path；KEY、令牌与 Bearer 头在写出前隐去。
+func (a *API) SaveCassette(path string) error {
+	if a.recorder == nil {
+		return i18n.Errorf("未开始录制")
+	}
+	c := Cassette{Version: cassetteVersion, BaseURL: a.secrets.redact(a.baseURL)}
+	for _, it := range a.recorder.snapshot() {
+		it.URI = a.secrets.redact(it.URI)
+		it.RequestBody = a.secrets.redact(it.RequestBody)
+		it.ResponseBody = a.secrets.redact(it.ResponseBody)
+		c.Interactions = append(c.Interactions, it)
+	}
+	b, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	if dir := filepath.Dir(path); dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return i18n.Errorf("创建录制目录失败: %w", err)
+		}
+	}
+	if err := os.WriteFile(path, append(b, '\n'), 0o600); err != nil {
+		return i18n.Errorf("写录制文件失败: %w", err)
+	}
+	return nil
+}
+
+// Replay 读入录制文件，此后的请求都由录制内容应答，不再访问网络。
+func (a *API) Replay(path string) error {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return i18n.Errorf("读取录制文件失败: %w", err)
+	}
+	var c Cassette
+	if err := json.Unmarshal(b, &c); err != nil {
+		return i18n.Errorf("解析录制文件失败: %w", err)
+	}
+	if c.Version != cassetteVersion {
+		return i18n.Errorf("不支持的录制文件版本: %d", c.Version)
+	}
+	a.http.Transport = &replayer{interactions: c.Interactions, used: make([]bool, len(c.Interactions))}
+	return nil
+}
+
+type recorder struct {
+	next http.RoundTripper
+	mu   sync.Mutex
+	// entries 按请求发出的先后排列；响应体在读完或关闭时补齐。
+	entries []*Interaction
+}
+
+func (r *recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody := readReqBody(req)
+	resp, err := r.next.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+	it := &Interaction{Method: req.Method, URI: req.URL.RequestURI(), RequestBody: reqBody, Status: resp.StatusCode}
+	for _, h := range cassetteHeaders {
+		if v := resp.Header.Get(h); v != "" {
+			if it.Header == nil {
+				it.Header = map[string]string{}
+			}
+			it.Header[h] = v
+		}
+	}
+	r.mu.Lock()
+	r.entries = append(r.entries, it)
+	r.mu.Unlock()
+	resp.Body = &recordingBody{ReadCloser: resp.Body, rec: r, it: it}
+	return resp, nil
+}
+
+func (r *recorder) snapshot() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := make([]Interaction, 0, len(r.entries))
+	for _, it := range r.entries {
+		out = append(out, *it)
+	}
+	return out
+}
+
+// recordingBody 边读边记下响应体，SSE 这类流式响应也能完整录下已收到的部分。
+type recordingBody struct {
+	io.ReadCloser
+	rec *recorder
+	it  *Interaction
+}
+
+func (b *recordingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if n > 0 {
+		b.rec.mu.Lock()
+		b.it.ResponseBody += string(p[:n])
+		b.rec.mu.Unlock()
+	}
+	return n, err
+}
+
+type replayer struct {
+	mu           sync.Mutex
+	interactions []Interaction
+	used         []bool
+}
+
+// RoundTrip 按方法与 URI 找第一条未用过的录制，请求体完全相同的优先，便于并发任务各自对上。
+func (r *replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := req.Context().Err(); err != nil {
+		return nil, err
+	}
+	body := readReqBody(req)
+	uri := req.URL.RequestURI()
+	r.mu.Lock()
+	idx := -1
+	for i, it := range r.interactions {
+		if r.used[i] || it.Method != req.Method || it.URI != uri {
+			continue
+		}
+		if it.RequestBody == body {
+			idx = i
+			break
+		}
+		if idx < 0 {
+			idx = i
+		}
+	}
+	if idx >= 0 {
+		r.used[idx] = true
+	}
+	r.mu.Unlock()
+	if idx < 0 {
+		return nil, i18n.Errorf("录制文件中没有匹配的请求: %s %s", req.Method, uri)
+	}
+	it := r.interactions[idx]
+	header := http.Header{}
+	for k, v := range it.Header {
+		header.Set(k, v)
+	}
+	return &http.Response{
+		StatusCode:    it.Status,
+		Status:        fmt.Sprintf("%d %s", it.Status, http.StatusText(it.Status)),
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader([]byte(it.ResponseBody))),
+		ContentLength: int64(len(it.ResponseBody)),
+		Request:       req,
+	}, nil
+}
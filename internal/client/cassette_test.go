@@ -0,0 +1,80 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/auth/exchange":
+			_, _ = io.WriteString(w, `{"access_token":"at-secret-123","tenant_id":"demo","expires_in":3600}`)
+		case "/v1/generate":
+			body, _ := io.ReadAll(r.Body)
+			job := "j1"
+			if strings.Contains(string(body), "second") {
+				job = "j2"
+			}
+			_, _ = io.WriteString(w, `{"job_id":"`+job+`","status":"queued"}`)
+		case "/v1/jobs/j1/result":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = io.WriteString(w, `{"status":"succeeded","en_markdown":"# en"}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer ts.Close()
+
+	api := New(ts.URL)
+	api.StartRecording()
+	ex, err := api.Exchange(context.Background(), "syl-key-abcdef")
+	if err != nil {
+		t.Fatalf("Exchange error: %v", err)
+	}
+	for _, in := range []string{"first", "second"} {
+		if _, err := api.Generate(context.Background(), ex.AccessToken, GenerateReq{InputMarkdown: in}); err != nil {
+			t.Fatalf("Generate error: %v", err)
+		}
+	}
+	if _, err := api.Result(context.Background(), ex.AccessToken, "j1"); err != nil {
+		t.Fatalf("Result error: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	if err := api.SaveCassette(path); err != nil {
+		t.Fatalf("SaveCassette error: %v", err)
+	}
+	raw, _ := os.ReadFile(path)
+	for _, secret := range []string{"syl-key-abcdef", "at-secret-123"} {
+		if strings.Contains(string(raw), secret) {
+			t.Fatalf("cassette leaks %q:\n%s", secret, raw)
+		}
+	}
+
+	ts.Close()
+	replay := New("http://unused.invalid")
+	if err := replay.Replay(path); err != nil {
+		t.Fatalf("Replay error: %v", err)
+	}
+	if _, err := replay.Exchange(context.Background(), "other-key"); err != nil {
+		t.Fatalf("replayed Exchange error: %v", err)
+	}
+	// 请求体相同的录制优先匹配，与发出顺序无关。
+	gen, err := replay.Generate(context.Background(), "tk", GenerateReq{InputMarkdown: "second"})
+	if err != nil || gen.JobID != "j2" {
+		t.Fatalf("replayed Generate=%+v err=%v", gen, err)
+	}
+	res, err := replay.Result(context.Background(), "tk", "j1")
+	if err != nil || res.ENMarkdown != "# en" {
+		t.Fatalf("replayed Result=%+v err=%v", res, err)
+	}
+	if _, err := replay.Result(context.Background(), "tk", "j9"); err == nil || !strings.Contains(err.Error(), "没有匹配的请求") {
+		t.Fatalf("unmatched request err=%v", err)
+	}
+}
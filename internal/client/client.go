@@ -17,6 +17,8 @@ import (
 	"strings"
 	"time"
 	"unicode/utf8"
+
+	"syl-listing-pro/internal/i18n"
 )
 
 type TraceEvent struct {
@@ -34,6 +36,13 @@ type API struct {
 	baseURL string
 	http    *http.Client
 	trace   func(TraceEvent)
+	secrets secretSet
+	// traceBodyLimit 为追踪事件中请求/响应体保留的最大字节数，0 表示不限制。
+	traceBodyLimit int
+	// stageTimeouts 为 SetStageTimeout 设置的各阶段超时。
+	stageTimeouts map[Stage]time.Duration
+	// recorder 非空时正在录制请求与响应，见 StartRecording。
+	recorder *recorder
 }
 
 const (
@@ -47,16 +56,29 @@ const (
 	maxIdleConnsPerHost   = 10
 	retryBackoffBase      = 300 * time.Millisecond
 	retryBackoffMax       = 4 * time.Second
-	defaultMaxAttempts    = 3
-	exchangeMaxAttempts   = 5
-	generateMaxAttempts   = 3
-	jobPollMaxAttempts    = 5
+	// retryAfterMax 为服务端 Retry-After 的采用上限，避免一次等待过久。
+	retryAfterMax       = 30 * time.Second
+	defaultMaxAttempts  = 3
+	exchangeMaxAttempts = 5
+	generateMaxAttempts = 3
+	jobPollMaxAttempts  = 5
 )
 
+var ErrQuotaUnsupported = errors.New("quota_unsupported")
+
+// ErrTenantInfoUnsupported 表示服务端没有租户信息接口。
+var ErrTenantInfoUnsupported = errors.New("tenant_info_unsupported")
+
 type httpStatusError struct {
 	statusCode int
 	status     string
 	body       string
+	// path 为请求路径，用于区分同一状态码在不同接口上的含义。
+	path string
+	// date 为响应头 Date，用于判断本机与服务端的时钟偏差；缺失时为零值。
+	date time.Time
+	// retryAfter 为 429/503 响应头 Retry-After 给出的等待时间；缺失时为 0。
+	retryAfter time.Duration
 }
 
 func (e *httpStatusError) Error() string {
@@ -90,8 +112,17 @@ func (a *API) SetTrace(fn func(TraceEvent)) {
 	a.trace = fn
 }
 
+// SetTraceBodyLimit 限制追踪事件中请求/响应体的字节数，超出部分截断并注明原长度；n<=0 不限制。
+func (a *API) SetTraceBodyLimit(n int) {
+	a.traceBodyLimit = n
+}
+
+// emitTrace 是追踪事件的唯一出口：交给回调前统一隐去 KEY 与令牌。
 func (a *API) emitTrace(ev TraceEvent) {
 	if a.trace != nil {
+		ev = a.secrets.redactTrace(ev)
+		ev.Request = clipTraceBody(ev.Request, a.traceBodyLimit)
+		ev.Response = clipTraceBody(ev.Response, a.traceBodyLimit)
 		a.trace(ev)
 	}
 }
@@ -123,7 +154,21 @@ func traceBody(b []byte) string {
 	return fmt.Sprintf("<binary bytes=%d sha256=%s>", len(b), hex.EncodeToString(sum[:]))
 }
 
+// clipTraceBody 按字节截断追踪内容，截断点回退到完整的 UTF-8 字符。
+func clipTraceBody(s string, limit int) string {
+	if limit <= 0 || len(s) <= limit {
+		return s
+	}
+	cut := limit
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	return fmt.Sprintf("%s…<truncated bytes=%d>", s[:cut], len(s))
+}
+
 func (a *API) Exchange(ctx context.Context, sylKey string) (ExchangeResp, error) {
+	a.secrets.add(sylKey)
+	ctx = a.withStage(ctx, StageExchange)
 	var out ExchangeResp
 	err := a.doJSONWithRetry(ctx, exchangeMaxAttempts, func() (*http.Request, error) {
 		url := a.baseURL + "/v1/auth/exchange"
@@ -137,10 +182,12 @@ func (a *API) Exchange(ctx context.Context, sylKey string) (ExchangeResp, error)
 	if err != nil {
 		return ExchangeResp{}, err
 	}
+	a.secrets.add(out.AccessToken)
 	return out, nil
 }
 
 func (a *API) Generate(ctx context.Context, token string, in GenerateReq) (GenerateResp, error) {
+	ctx = a.withStage(ctx, StageSubmit)
 	b, _ := json.Marshal(in)
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.baseURL+"/v1/generate", bytes.NewReader(b))
 	if err != nil {
@@ -148,6 +195,9 @@ func (a *API) Generate(ctx context.Context, token string, in GenerateReq) (Gener
 	}
 	req.Header.Set("Authorization", "Bearer "+token)
 	req.Header.Set("Content-Type", "application/json")
+	if key := strings.TrimSpace(in.IdempotencyKey); key != "" {
+		req.Header.Set("Idempotency-Key", key)
+	}
 	var out GenerateResp
 	if err := a.doJSONWithRetry(ctx, generateMaxAttempts, func() (*http.Request, error) {
 		return cloneRequest(req)
@@ -173,6 +223,7 @@ func (a *API) CancelJob(ctx context.Context, token, jobID string) (CancelResp, e
 }
 
 func (a *API) Result(ctx context.Context, token, jobID string) (ResultResp, error) {
+	ctx = a.withStage(ctx, StageResult)
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, a.baseURL+"/v1/jobs/"+jobID+"/result", nil)
 	if err != nil {
 		return ResultResp{}, err
@@ -187,6 +238,46 @@ func (a *API) Result(ctx context.Context, token, jobID string) (ResultResp, erro
 	return out, nil
 }
 
+// Quota 查询当前租户的剩余额度；服务端不支持时返回 ErrQuotaUnsupported。
+func (a *API) Quota(ctx context.Context, token string) (QuotaResp, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, a.baseURL+"/v1/quota", nil)
+	if err != nil {
+		return QuotaResp{}, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	var out QuotaResp
+	if err := a.doJSONWithRetry(ctx, defaultMaxAttempts, func() (*http.Request, error) {
+		return cloneRequest(req)
+	}, &out); err != nil {
+		var statusErr *httpStatusError
+		if errors.As(err, &statusErr) && (statusErr.statusCode == http.StatusNotFound || statusErr.statusCode == http.StatusNotImplemented) {
+			return QuotaResp{}, ErrQuotaUnsupported
+		}
+		return QuotaResp{}, err
+	}
+	return out, nil
+}
+
+// TenantInfo 查询当前租户的套餐、并发与候选数限制、规则通道；服务端不支持时返回 ErrTenantInfoUnsupported。
+func (a *API) TenantInfo(ctx context.Context, token string) (TenantInfoResp, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, a.baseURL+"/v1/tenant", nil)
+	if err != nil {
+		return TenantInfoResp{}, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	var out TenantInfoResp
+	if err := a.doJSONWithRetry(ctx, defaultMaxAttempts, func() (*http.Request, error) {
+		return cloneRequest(req)
+	}, &out); err != nil {
+		var statusErr *httpStatusError
+		if errors.As(err, &statusErr) && (statusErr.statusCode == http.StatusNotFound || statusErr.statusCode == http.StatusNotImplemented) {
+			return TenantInfoResp{}, ErrTenantInfoUnsupported
+		}
+		return TenantInfoResp{}, err
+	}
+	return out, nil
+}
+
 func (a *API) JobEvents(ctx context.Context, token, jobID string, onEvent func(JobEvent)) (JobStatusResp, error) {
 	streamHTTP := *a.http
 	streamHTTP.Timeout = 0
@@ -252,6 +343,8 @@ func (a *API) JobEvents(ctx context.Context, token, jobID string, onEvent func(J
 				statusCode: resp.StatusCode,
 				status:     resp.Status,
 				body:       string(body),
+				path:       req.URL.Path,
+				retryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()),
 			}
 			if !isRetryableJobEventStreamErr(err) {
 				return JobStatusResp{}, err
@@ -301,7 +394,7 @@ func consumeJobEventStream(
 		case "trace":
 			var traceEvt JobEventTrace
 			if err := json.Unmarshal([]byte(data), &traceEvt); err != nil {
-				return fmt.Errorf("解析 SSE trace 失败: %w", err)
+				return i18n.Errorf("解析 SSE trace 失败: %w", err)
 			}
 			if lastTraceOffset != nil && traceEvt.Offset > 0 {
 				if traceEvt.Offset <= *lastTraceOffset {
@@ -321,7 +414,7 @@ func consumeJobEventStream(
 		case "status":
 			var statusEvt JobEventStatus
 			if err := json.Unmarshal([]byte(data), &statusEvt); err != nil {
-				return fmt.Errorf("解析 SSE status 失败: %w", err)
+				return i18n.Errorf("解析 SSE status 失败: %w", err)
 			}
 			if onEvent != nil {
 				onEvent(JobEvent{
@@ -380,49 +473,6 @@ func consumeJobEventStream(
 	}
 }
 
-func (a *API) downloadOnce(req *http.Request) ([]byte, string, error) {
-	reqBody := readReqBody(req)
-	a.emitTrace(TraceEvent{
-		Stage:   "request",
-		Method:  req.Method,
-		URL:     req.URL.String(),
-		Request: reqBody,
-	})
-	start := time.Now()
-	resp, err := a.http.Do(req)
-	if err != nil {
-		a.emitTrace(TraceEvent{
-			Stage:      "error",
-			Method:     req.Method,
-			URL:        req.URL.String(),
-			DurationMs: time.Since(start).Milliseconds(),
-			Request:    reqBody,
-			Error:      err.Error(),
-		})
-		return nil, "", err
-	}
-	defer resp.Body.Close()
-	body, _ := io.ReadAll(io.LimitReader(resp.Body, 2<<20))
-	a.emitTrace(TraceEvent{
-		Stage:      "response",
-		Method:     req.Method,
-		URL:        req.URL.String(),
-		StatusCode: resp.StatusCode,
-		DurationMs: time.Since(start).Milliseconds(),
-		Request:    reqBody,
-		Response:   traceBody(body),
-	})
-	if resp.StatusCode/100 != 2 {
-		return nil, "", &httpStatusError{
-			statusCode: resp.StatusCode,
-			status:     resp.Status,
-			body:       string(body),
-		}
-	}
-	h := sha256.Sum256(body)
-	return body, hex.EncodeToString(h[:]), nil
-}
-
 func (a *API) doJSONWithRetry(ctx context.Context, maxAttempts int, buildReq func() (*http.Request, error), out any) error {
 	if maxAttempts <= 0 {
 		maxAttempts = 1
@@ -432,14 +482,16 @@ func (a *API) doJSONWithRetry(ctx context.Context, maxAttempts int, buildReq fun
 		if err != nil {
 			return err
 		}
-		err = a.doJSONOnce(req, out)
+		attemptReq, hc, cancel := a.attemptRequest(req)
+		err = a.doJSONOnceWith(hc, attemptReq, out)
+		cancel()
 		if err == nil {
 			return nil
 		}
 		if !isRetryableRequestErr(err) || attempt >= maxAttempts {
 			return err
 		}
-		backoff := retryBackoff(attempt)
+		backoff := retryDelay(attempt, err)
 		a.emitTrace(TraceEvent{
 			Stage:      "retry",
 			Method:     req.Method,
@@ -460,6 +512,10 @@ func (a *API) doJSONWithRetry(ctx context.Context, maxAttempts int, buildReq fun
 }
 
 func (a *API) doJSONOnce(req *http.Request, out any) error {
+	return a.doJSONOnceWith(a.http, req, out)
+}
+
+func (a *API) doJSONOnceWith(hc *http.Client, req *http.Request, out any) error {
 	reqBody := readReqBody(req)
 	a.emitTrace(TraceEvent{
 		Stage:   "request",
@@ -468,7 +524,7 @@ func (a *API) doJSONOnce(req *http.Request, out any) error {
 		Request: reqBody,
 	})
 	start := time.Now()
-	resp, err := a.http.Do(req)
+	resp, err := hc.Do(req)
 	if err != nil {
 		a.emitTrace(TraceEvent{
 			Stage:      "error",
@@ -492,17 +548,21 @@ func (a *API) doJSONOnce(req *http.Request, out any) error {
 		Response:   traceBody(body),
 	})
 	if resp.StatusCode/100 != 2 {
+		date, _ := http.ParseTime(resp.Header.Get("Date"))
 		return &httpStatusError{
 			statusCode: resp.StatusCode,
 			status:     resp.Status,
 			body:       string(body),
+			path:       req.URL.Path,
+			date:       date,
+			retryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()),
 		}
 	}
 	if out == nil {
 		return nil
 	}
 	if err := json.Unmarshal(body, out); err != nil {
-		return fmt.Errorf("解析响应失败: %w", err)
+		return i18n.Errorf("解析响应失败: %w", err)
 	}
 	return nil
 }
@@ -518,6 +578,34 @@ func retryBackoff(attempt int) time.Duration {
 	return d
 }
 
+// retryDelay 优先采用服务端 Retry-After（不超过 retryAfterMax），否则按指数退避。
+func retryDelay(attempt int, err error) time.Duration {
+	var statusErr *httpStatusError
+	if errors.As(err, &statusErr) && statusErr.retryAfter > 0 {
+		return min(statusErr.retryAfter, retryAfterMax)
+	}
+	return retryBackoff(attempt)
+}
+
+// parseRetryAfter 解析 Retry-After：秒数或 HTTP 日期；无法解析或已过期时返回 0。
+func parseRetryAfter(v string, now time.Time) time.Duration {
+	v = strings.TrimSpace(v)
+	if v == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		if secs <= 0 {
+			return 0
+		}
+		return time.Duration(secs) * time.Second
+	}
+	t, err := http.ParseTime(v)
+	if err != nil || !t.After(now) {
+		return 0
+	}
+	return t.Sub(now)
+}
+
 func isRetryableJobEventStreamErr(err error) bool {
 	if err == nil {
 		return false
@@ -539,7 +627,7 @@ func waitJobEventStreamRetry(
 	lastTraceOffset int,
 	retryErr error,
 ) error {
-	backoff := retryBackoff(attempt)
+	backoff := retryDelay(attempt, retryErr)
 	api.emitTrace(TraceEvent{
 		Stage:      "retry",
 		Method:     req.Method,
@@ -558,18 +646,21 @@ func waitJobEventStreamRetry(
 	}
 }
 
+// IsRetryable 判断错误是否属于超时、5xx、网络抖动等重新提交有望成功的情况。
+func IsRetryable(err error) bool {
+	if errors.Is(err, context.Canceled) {
+		return false
+	}
+	return isRetryableRequestErr(err)
+}
+
 func isRetryableRequestErr(err error) bool {
 	if err == nil {
 		return false
 	}
 	var statusErr *httpStatusError
 	if errors.As(err, &statusErr) {
-		switch statusErr.statusCode {
-		case 408, 425, 429, 500, 502, 503, 504:
-			return true
-		default:
-			return false
-		}
+		return errors.Is(err, ErrRetryable)
 	}
 	if errors.Is(err, io.EOF) {
 		return true
@@ -132,6 +132,110 @@ func TestDoJSONWithRetry(t *testing.T) {
 
 }
 
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2026, 3, 13, 0, 0, 0, 0, time.UTC)
+	cases := map[string]time.Duration{
+		"":                              0,
+		"2":                             2 * time.Second,
+		"-1":                            0,
+		"soon":                          0,
+		"Fri, 13 Mar 2026 00:00:05 GMT": 5 * time.Second,
+		"Thu, 12 Mar 2026 23:59:00 GMT": 0,
+	}
+	for in, want := range cases {
+		if got := parseRetryAfter(in, now); got != want {
+			t.Fatalf("parseRetryAfter(%q)=%v want %v", in, got, want)
+		}
+	}
+	if d := retryDelay(1, &httpStatusError{statusCode: 503, retryAfter: time.Hour}); d != retryAfterMax {
+		t.Fatalf("expected capped Retry-After, got %v", d)
+	}
+	if d := retryDelay(1, io.EOF); d != retryBackoffBase {
+		t.Fatalf("expected exponential backoff, got %v", d)
+	}
+
+	var tries atomic.Int32
+	var waited int64
+	api := &API{
+		baseURL: "http://x",
+		http: &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			if tries.Add(1) == 1 {
+				resp := newResp(429, `{"error":"rate_limited"}`)
+				resp.Header.Set("Retry-After", "1")
+				return resp, nil
+			}
+			return newResp(200, `{}`), nil
+		})},
+	}
+	api.SetTrace(func(ev TraceEvent) {
+		if ev.Stage == "retry" {
+			waited = ev.DurationMs
+		}
+	})
+	ctx := context.Background()
+	if err := api.doJSONWithRetry(ctx, 2, func() (*http.Request, error) {
+		return http.NewRequestWithContext(ctx, http.MethodGet, "http://x/p", nil)
+	}, nil); err != nil {
+		t.Fatalf("doJSONWithRetry error: %v", err)
+	}
+	if waited != 1000 {
+		t.Fatalf("retry wait=%dms want 1000", waited)
+	}
+}
+
+func TestTraceRedactsKeyAndToken(t *testing.T) {
+	api := &API{
+		baseURL: "http://x",
+		http: &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			return newResp(200, `{"access_token":"at_secret","tenant_id":"demo","expires_in":3600}`), nil
+		})},
+	}
+	var traces []string
+	api.SetTrace(func(ev TraceEvent) {
+		traces = append(traces, ev.URL+ev.Request+ev.Response+ev.Error)
+	})
+	ex, err := api.Exchange(context.Background(), "syl_key_value")
+	if err != nil || ex.AccessToken != "at_secret" {
+		t.Fatalf("Exchange=%+v, %v", ex, err)
+	}
+	api.emitTrace(TraceEvent{Stage: "error", Error: "rejected at_secret / syl_key_value"})
+	joined := strings.Join(traces, "\n")
+	if strings.Contains(joined, "at_secret") || strings.Contains(joined, "syl_key_value") {
+		t.Fatalf("secret leaked in traces: %s", joined)
+	}
+	if got := RedactText("Authorization: Bearer abc.def"); got != "Authorization: Bearer [REDACTED]" {
+		t.Fatalf("RedactText=%q", got)
+	}
+}
+
+func TestTraceBodyLimit(t *testing.T) {
+	if got := clipTraceBody("abcdef", 0); got != "abcdef" {
+		t.Fatalf("unlimited clip=%q", got)
+	}
+	if got := clipTraceBody("标题abc", 4); got != "标…<truncated bytes=9>" {
+		t.Fatalf("clip=%q", got)
+	}
+	api := &API{
+		baseURL: "http://x",
+		http: &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			return newResp(200, `{"en_markdown":"`+strings.Repeat("x", 100)+`"}`), nil
+		})},
+	}
+	api.SetTraceBodyLimit(16)
+	var resp string
+	api.SetTrace(func(ev TraceEvent) {
+		if ev.Stage == "response" {
+			resp = ev.Response
+		}
+	})
+	if _, err := api.Result(context.Background(), "t", "j1"); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasSuffix(resp, "<truncated bytes=118>") || len(resp) > 16+len("…<truncated bytes=118>") {
+		t.Fatalf("unexpected traced response: %q", resp)
+	}
+}
+
 func TestAPIEndpointsAndTrace(t *testing.T) {
 	var gotAuth []string
 	var gotGenerateContentType string
@@ -324,7 +428,7 @@ func TestJobEventsReconnectsFromLastOffset(t *testing.T) {
 	}
 }
 
-func TestDoJSONOnceAndDownloadOnceErrorPaths(t *testing.T) {
+func TestDoJSONOnceErrorPaths(t *testing.T) {
 	api := &API{http: &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
 		if req.URL.Path == "/timeout" {
 			return nil, &net.DNSError{IsTimeout: true}
@@ -335,9 +439,6 @@ func TestDoJSONOnceAndDownloadOnceErrorPaths(t *testing.T) {
 		if req.URL.Path == "/status" {
 			return newResp(500, "oops"), nil
 		}
-		if req.URL.Path == "/bin" {
-			return &http.Response{StatusCode: 200, Status: "200 OK", Body: io.NopCloser(bytes.NewReader([]byte{0xff, 0x00})), Header: make(http.Header)}, nil
-		}
 		return newResp(200, `{}`), nil
 	})}}
 
@@ -362,11 +463,6 @@ func TestDoJSONOnceAndDownloadOnceErrorPaths(t *testing.T) {
 	if !strings.Contains(hs.Error(), "oops") {
 		t.Fatalf("unexpected status error text: %v", hs.Error())
 	}
-
-	req, _ = http.NewRequestWithContext(ctx, http.MethodGet, "http://x/bin", nil)
-	if _, _, err := api.downloadOnce(req); err != nil {
-		t.Fatalf("downloadOnce binary should pass, err=%v", err)
-	}
 }
 
 func TestContextCanceledInRetry(t *testing.T) {
@@ -386,3 +482,193 @@ func TestContextCanceledInRetry(t *testing.T) {
 		t.Fatalf("canceled context should return quickly")
 	}
 }
+
+func TestStageTimeout(t *testing.T) {
+	api := &API{http: &http.Client{Timeout: time.Hour, Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		<-req.Context().Done()
+		return nil, req.Context().Err()
+	})}}
+	if got := api.stageTimeout(StageExchange); got != 20*time.Second {
+		t.Fatalf("default exchange timeout=%s", got)
+	}
+	if got := api.stageTimeout(StageResult); got != 0 {
+		t.Fatalf("default result timeout=%s", got)
+	}
+	api.SetStageTimeout(StageResult, 30*time.Millisecond)
+	ctx := api.withStage(context.Background(), StageResult)
+	start := time.Now()
+	err := api.doJSONWithRetry(ctx, 1, func() (*http.Request, error) {
+		return http.NewRequestWithContext(ctx, http.MethodGet, "http://x", nil)
+	}, nil)
+	if !errors.Is(err, context.DeadlineExceeded) || !isRetryableRequestErr(err) {
+		t.Fatalf("err=%v want retryable deadline exceeded", err)
+	}
+	if time.Since(start) > time.Second {
+		t.Fatalf("stage timeout not applied")
+	}
+	api.SetStageTimeout(StageResult, 0)
+	if got := api.stageTimeout(StageResult); got != 0 {
+		t.Fatalf("reset result timeout=%s", got)
+	}
+}
+
+func TestGenerateSendsIdempotencyKeyOnEveryAttempt(t *testing.T) {
+	var attempts atomic.Int64
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Idempotency-Key"); got != "idem-1" {
+			t.Errorf("Idempotency-Key=%q", got)
+		}
+		body, _ := io.ReadAll(r.Body)
+		if strings.Contains(string(body), "idem-1") {
+			t.Errorf("idempotency key must not leak into body: %s", body)
+		}
+		if attempts.Add(1) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		_, _ = io.WriteString(w, `{"job_id":"j1","status":"queued"}`)
+	}))
+	defer ts.Close()
+
+	api := New(ts.URL)
+	resp, err := api.Generate(context.Background(), "tk", GenerateReq{InputMarkdown: "x", IdempotencyKey: "idem-1"})
+	if err != nil {
+		t.Fatalf("Generate error: %v", err)
+	}
+	if resp.JobID != "j1" || attempts.Load() != 2 {
+		t.Fatalf("resp=%+v attempts=%d", resp, attempts.Load())
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	if IsRetryable(context.Canceled) {
+		t.Fatal("canceled should not be retryable")
+	}
+	if !IsRetryable(&httpStatusError{statusCode: 503, status: "503", body: "busy"}) {
+		t.Fatal("503 should be retryable")
+	}
+	if IsRetryable(&httpStatusError{statusCode: 400, status: "400", body: "bad"}) {
+		t.Fatal("400 should not be retryable")
+	}
+}
+
+func TestErrorClassification(t *testing.T) {
+	cases := []struct {
+		err    error
+		target error
+		want   bool
+	}{
+		{&httpStatusError{statusCode: 401}, ErrUnauthorized, true},
+		{&httpStatusError{statusCode: 403}, ErrUnauthorized, true},
+		{&httpStatusError{statusCode: 402}, ErrQuotaExceeded, true},
+		{&httpStatusError{statusCode: 429, body: `{"error":"quota_exceeded"}`}, ErrQuotaExceeded, true},
+		{&httpStatusError{statusCode: 429, body: `{"error":{"code":"quota_exceeded"}}`}, ErrRetryable, false},
+		{&httpStatusError{statusCode: 429, body: `{"error":"rate_limited"}`}, ErrRetryable, true},
+		{&httpStatusError{statusCode: 404, path: "/v1/jobs/j1/result"}, ErrJobNotFound, true},
+		{&httpStatusError{statusCode: 404, path: "/v1/quota"}, ErrJobNotFound, false},
+		{fmt.Errorf("读取结果失败: %w", &httpStatusError{statusCode: 503}), ErrRetryable, true},
+	}
+	for i, c := range cases {
+		if got := errors.Is(c.err, c.target); got != c.want {
+			t.Fatalf("case %d: errors.Is(%v, %v)=%v want %v", i, c.err, c.target, got, c.want)
+		}
+	}
+	if code := ErrorCode(&httpStatusError{statusCode: 400, body: `{"code":"bad_input"}`}); code != "bad_input" {
+		t.Fatalf("code=%q", code)
+	}
+	if StatusCode(fmt.Errorf("x: %w", &httpStatusError{statusCode: 418})) != 418 || StatusCode(io.EOF) != 0 {
+		t.Fatal("unexpected StatusCode")
+	}
+	if !IsCanceled(fmt.Errorf("x: %w", context.DeadlineExceeded)) || IsCanceled(io.EOF) {
+		t.Fatal("unexpected IsCanceled")
+	}
+}
+
+func TestQuota(t *testing.T) {
+	supported := true
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/quota" || r.Header.Get("Authorization") != "Bearer at" {
+			t.Errorf("unexpected request: %s %s", r.URL.Path, r.Header.Get("Authorization"))
+		}
+		if !supported {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = io.WriteString(w, `{"tenant_id":"demo","credits_remaining":12.5,"credits_limit":100}`)
+	}))
+	defer ts.Close()
+
+	api := New(ts.URL)
+	q, err := api.Quota(context.Background(), "at")
+	if err != nil {
+		t.Fatalf("Quota error: %v", err)
+	}
+	if q.CreditsRemaining != 12.5 || q.CreditsLimit != 100 {
+		t.Fatalf("unexpected quota: %+v", q)
+	}
+	supported = false
+	if _, err := api.Quota(context.Background(), "at"); !errors.Is(err, ErrQuotaUnsupported) {
+		t.Fatalf("err=%v, want ErrQuotaUnsupported", err)
+	}
+}
+
+func TestTenantInfo(t *testing.T) {
+	supported := true
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/tenant" || r.Header.Get("Authorization") != "Bearer at" {
+			t.Errorf("unexpected request: %s %s", r.URL.Path, r.Header.Get("Authorization"))
+		}
+		if !supported {
+			w.WriteHeader(http.StatusNotImplemented)
+			return
+		}
+		_, _ = io.WriteString(w, `{"tenant_id":"demo","plan":"pro","max_concurrent_jobs":8,"allowed_candidate_counts":[1,3],"rules_channel":"stable"}`)
+	}))
+	defer ts.Close()
+
+	api := New(ts.URL)
+	info, err := api.TenantInfo(context.Background(), "at")
+	if err != nil {
+		t.Fatalf("TenantInfo error: %v", err)
+	}
+	if info.Plan != "pro" || info.MaxConcurrentJobs != 8 || len(info.AllowedCandidateCounts) != 2 || info.RulesChannel != "stable" {
+		t.Fatalf("unexpected info: %+v", info)
+	}
+	supported = false
+	if _, err := api.TenantInfo(context.Background(), "at"); !errors.Is(err, ErrTenantInfoUnsupported) {
+		t.Fatalf("err=%v, want ErrTenantInfoUnsupported", err)
+	}
+}
+
+func TestUsageAdd(t *testing.T) {
+	total := Usage{InputTokens: 100, OutputTokens: 20, Credits: 1}.Add(Usage{TotalTokens: 50, Credits: 0.5})
+	if total.Tokens() != 170 || total.InputTokens != 100 || total.Credits != 1.5 {
+		t.Fatalf("unexpected usage: %+v", total)
+	}
+}
+
+func TestDiagnoseAuthError(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	cases := []struct {
+		name string
+		err  error
+		want AuthFailure
+	}{
+		{"invalid", &httpStatusError{statusCode: 401, status: "401", body: `{"error":"invalid_key"}`}, AuthFailureInvalidKey},
+		{"expired", &httpStatusError{statusCode: 401, status: "401", body: `{"error":"key_expired"}`}, AuthFailureExpiredKey},
+		{"skew body", &httpStatusError{statusCode: 401, status: "401", body: "clock skew too large"}, AuthFailureClockSkew},
+		{"skew date", &httpStatusError{statusCode: 401, status: "401", body: "unauthorized", date: now.Add(10 * time.Minute)}, AuthFailureClockSkew},
+		{"server", &httpStatusError{statusCode: 503, status: "503", body: "busy"}, AuthFailureServer},
+		{"network", errors.New("dial tcp 127.0.0.1:1: connect: connection refused"), AuthFailureNetwork},
+		{"unknown", &httpStatusError{statusCode: 400, status: "400", body: "bad"}, AuthFailureUnknown},
+	}
+	for _, tc := range cases {
+		if got := DiagnoseAuthError(tc.err, now); got.Failure != tc.want {
+			t.Fatalf("%s: got %+v, want %s", tc.name, got, tc.want)
+		}
+	}
+	d := DiagnoseAuthError(&httpStatusError{statusCode: 401, status: "401", body: `{"message":"key revoked"}`, date: now.Add(-time.Minute)}, now)
+	if d.Message != "key revoked" || d.ClockSkew != -time.Minute {
+		t.Fatalf("unexpected diagnosis: %+v", d)
+	}
+}
@@ -0,0 +1,96 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// 可用 errors.Is 判断的错误分类；HTTP 状态错误按状态码、请求路径与响应体中的错误码归类。
+var (
+	// ErrUnauthorized 表示 KEY 或访问令牌无效、过期（401/403）。
+	ErrUnauthorized = errors.New("unauthorized")
+	// ErrQuotaExceeded 表示租户额度已用完（402，或错误码含 quota 的 429）。
+	ErrQuotaExceeded = errors.New("quota_exceeded")
+	// ErrJobNotFound 表示服务端找不到任务（/v1/jobs/ 下的 404），通常已过期清理。
+	ErrJobNotFound = errors.New("job_not_found")
+	// ErrRetryable 表示 408/425/429/5xx 等稍后重试有望成功的状态。
+	ErrRetryable = errors.New("retryable")
+)
+
+// Is 让 httpStatusError 匹配上面的错误分类。
+func (e *httpStatusError) Is(target error) bool {
+	switch target {
+	case ErrUnauthorized:
+		return e.statusCode == http.StatusUnauthorized || e.statusCode == http.StatusForbidden
+	case ErrQuotaExceeded:
+		return e.isQuotaExceeded()
+	case ErrJobNotFound:
+		return e.statusCode == http.StatusNotFound && strings.Contains(e.path, "/v1/jobs/")
+	case ErrRetryable:
+		if e.isQuotaExceeded() {
+			return false
+		}
+		switch e.statusCode {
+		case 408, 425, 429, 500, 502, 503, 504:
+			return true
+		}
+	}
+	return false
+}
+
+func (e *httpStatusError) isQuotaExceeded() bool {
+	switch e.statusCode {
+	case http.StatusPaymentRequired:
+		return true
+	case http.StatusTooManyRequests:
+		return strings.Contains(strings.ToLower(ErrorCode(e)), "quota")
+	}
+	return false
+}
+
+// ErrorCode 返回服务端响应体中的错误码（{"code":...} 或字符串形式的 {"error":...}）；
+// 不是 HTTP 状态错误或没有错误码时返回空字符串。
+func ErrorCode(err error) string {
+	var statusErr *httpStatusError
+	if !errors.As(err, &statusErr) {
+		return ""
+	}
+	var payload struct {
+		Code  string          `json:"code"`
+		Error json.RawMessage `json:"error"`
+	}
+	if json.Unmarshal([]byte(statusErr.body), &payload) != nil {
+		return ""
+	}
+	if payload.Code != "" {
+		return payload.Code
+	}
+	var code string
+	if json.Unmarshal(payload.Error, &code) == nil {
+		return code
+	}
+	var nested struct {
+		Code string `json:"code"`
+	}
+	if json.Unmarshal(payload.Error, &nested) == nil {
+		return nested.Code
+	}
+	return ""
+}
+
+// StatusCode 返回 HTTP 状态错误的状态码；其他错误返回 0。
+func StatusCode(err error) int {
+	var statusErr *httpStatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.statusCode
+	}
+	return 0
+}
+
+// IsCanceled 判断错误是否由上下文取消或超时引起。
+func IsCanceled(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
@@ -0,0 +1,65 @@
+package client
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// redacted 替换被隐去的凭据。
+const redacted = "[REDACTED]"
+
+var (
+	bearerPattern = regexp.MustCompile(`(?i)(bearer\s+)[A-Za-z0-9._~+/=-]+`)
+	// 兼容嵌在另一层 JSON 字符串中、引号被转义的情况。
+	secretFieldPattern = regexp.MustCompile(`(?i)(\\?"(?:access_token|refresh_token|id_token|token|authorization|syl_key|api_key|secret)\\?"\s*:\s*\\?")[^"\\]*(\\?")`)
+)
+
+// RedactText 隐去文本中的 Bearer 令牌、JSON 中的令牌类字段以及 secrets 中列出的原文。
+func RedactText(s string, secrets ...string) string {
+	if s == "" {
+		return s
+	}
+	for _, secret := range secrets {
+		if len(secret) >= 4 {
+			s = strings.ReplaceAll(s, secret, redacted)
+		}
+	}
+	s = bearerPattern.ReplaceAllString(s, "${1}"+redacted)
+	return secretFieldPattern.ReplaceAllString(s, "${1}"+redacted+"${2}")
+}
+
+// secretSet 记录本次会话出现过的 KEY 与访问令牌，供追踪事件脱敏。
+type secretSet struct {
+	mu     sync.RWMutex
+	values []string
+}
+
+func (s *secretSet) add(v string) {
+	v = strings.TrimSpace(v)
+	if v == "" {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, existing := range s.values {
+		if existing == v {
+			return
+		}
+	}
+	s.values = append(s.values, v)
+}
+
+func (s *secretSet) redact(text string) string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return RedactText(text, s.values...)
+}
+
+func (s *secretSet) redactTrace(ev TraceEvent) TraceEvent {
+	ev.URL = s.redact(ev.URL)
+	ev.Request = s.redact(ev.Request)
+	ev.Response = s.redact(ev.Response)
+	ev.Error = s.redact(ev.Error)
+	return ev
+}
@@ -0,0 +1,66 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// Stage 是可单独设置超时的请求阶段。
+type Stage string
+
+const (
+	StageExchange Stage = "exchange"
+	StageSubmit   Stage = "submit"
+	StageResult   Stage = "result"
+)
+
+// Stages 为全部可配置阶段，按请求先后排列。
+var Stages = []Stage{StageExchange, StageSubmit, StageResult}
+
+// defaultStageTimeouts 为未配置时各阶段单次请求的超时；没有列出的阶段沿用 http.Client 的 120s。
+var defaultStageTimeouts = map[Stage]time.Duration{
+	StageExchange: 20 * time.Second,
+}
+
+type stageTimeoutKey struct{}
+
+// SetStageTimeout 设置某阶段单次请求（每次重试各自计时）的超时；d<=0 恢复默认。
+func (a *API) SetStageTimeout(stage Stage, d time.Duration) {
+	if a.stageTimeouts == nil {
+		a.stageTimeouts = map[Stage]time.Duration{}
+	}
+	if d <= 0 {
+		delete(a.stageTimeouts, stage)
+		return
+	}
+	a.stageTimeouts[stage] = d
+}
+
+func (a *API) stageTimeout(stage Stage) time.Duration {
+	if d, ok := a.stageTimeouts[stage]; ok {
+		return d
+	}
+	return defaultStageTimeouts[stage]
+}
+
+// withStage 在 ctx 中记下本次调用的阶段超时，供 doJSONWithRetry 按次套用。
+func (a *API) withStage(ctx context.Context, stage Stage) context.Context {
+	if d := a.stageTimeout(stage); d > 0 {
+		return context.WithValue(ctx, stageTimeoutKey{}, d)
+	}
+	return ctx
+}
+
+// attemptRequest 为单次请求套上阶段超时；设置了阶段超时的请求改用不带整体超时的 http.Client，
+// 使阶段超时可以长于默认的 120s。
+func (a *API) attemptRequest(req *http.Request) (*http.Request, *http.Client, context.CancelFunc) {
+	d, ok := req.Context().Value(stageTimeoutKey{}).(time.Duration)
+	if !ok || d <= 0 {
+		return req, a.http, func() {}
+	}
+	ctx, cancel := context.WithTimeout(req.Context(), d)
+	hc := *a.http
+	hc.Timeout = 0
+	return req.WithContext(ctx), &hc, cancel
+}
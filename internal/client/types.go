@@ -6,10 +6,31 @@ type ExchangeResp struct {
 	TenantID    string `json:"tenant_id"`
 }
 
+// ProductAttributes 为随任务提交的结构化商品属性，全部为空时不出现在请求中，兼容不支持的服务端。
+type ProductAttributes struct {
+	Brand          string `json:"brand,omitempty"`
+	Category       string `json:"category,omitempty"`
+	TargetAudience string `json:"target_audience,omitempty"`
+	// KeyFeatures 为需要在五点与描述中突出的卖点。
+	KeyFeatures []string `json:"key_features,omitempty"`
+}
+
 type GenerateReq struct {
 	InputMarkdown  string `json:"input_markdown"`
 	InputFilename  string `json:"input_filename,omitempty"`
 	CandidateCount int    `json:"candidate_count,omitempty"`
+	// Sections 非空时只重新生成这些分节（title|bullets|description），需服务端支持按节生成。
+	Sections []string `json:"sections,omitempty"`
+	// Keywords 为必须融入输出的 SEO 关键词。
+	Keywords []string `json:"keywords,omitempty"`
+	// Marketplace 为目标站点（us|de|fr|jp），服务端据此选择输出语言对。
+	Marketplace string `json:"marketplace,omitempty"`
+	// SearchTerms 为 true 时请求服务端额外生成后台搜索词（backend keywords）。
+	SearchTerms bool `json:"search_terms,omitempty"`
+	// ProductAttributes 的字段平铺在请求顶层（brand、category 等）。
+	ProductAttributes
+	// IdempotencyKey 通过 Idempotency-Key 请求头发送，保证重试不会重复建任务。
+	IdempotencyKey string `json:"-"`
 }
 
 type GenerateResp struct {
@@ -37,6 +58,72 @@ type ResultResp struct {
 	CNMarkdown       string   `json:"cn_markdown"`
 	ValidationReport []string `json:"validation_report"`
 	TimingMS         int64    `json:"timing_ms"`
+	// Usage 为服务端返回的用量信息；旧版本服务端不返回时为 nil。
+	Usage *Usage `json:"usage,omitempty"`
+	// Languages 为 ENMarkdown、CNMarkdown 两份内容实际的语言代码（如 ["en","de"]），
+	// 决定产物后缀；旧版本服务端不返回时按 en/cn 处理。
+	Languages []string `json:"languages,omitempty"`
+	// Sections 为按节生成时各分节的结果；整份生成时为空。
+	Sections []SectionResult `json:"sections,omitempty"`
+	// SearchTerms 为请求了后台搜索词时服务端返回的搜索词，空格分隔。
+	SearchTerms string `json:"search_terms,omitempty"`
+}
+
+type SectionResult struct {
+	Section    string `json:"section"`
+	ENMarkdown string `json:"en_markdown"`
+	CNMarkdown string `json:"cn_markdown"`
+}
+
+type Usage struct {
+	InputTokens  int64   `json:"input_tokens"`
+	OutputTokens int64   `json:"output_tokens"`
+	TotalTokens  int64   `json:"total_tokens"`
+	Credits      float64 `json:"credits"`
+}
+
+// Tokens 返回总 token 数；服务端未给 total_tokens 时按输入加输出计算。
+func (u Usage) Tokens() int64 {
+	if u.TotalTokens > 0 {
+		return u.TotalTokens
+	}
+	return u.InputTokens + u.OutputTokens
+}
+
+// Add 累加两份用量。
+func (u Usage) Add(o Usage) Usage {
+	return Usage{
+		InputTokens:  u.InputTokens + o.InputTokens,
+		OutputTokens: u.OutputTokens + o.OutputTokens,
+		TotalTokens:  u.Tokens() + o.Tokens(),
+		Credits:      u.Credits + o.Credits,
+	}
+}
+
+type QuotaResp struct {
+	TenantID         string  `json:"tenant_id"`
+	CreditsRemaining float64 `json:"credits_remaining"`
+	CreditsLimit     float64 `json:"credits_limit,omitempty"`
+	TokensRemaining  int64   `json:"tokens_remaining,omitempty"`
+	ResetAt          string  `json:"reset_at,omitempty"`
+}
+
+// TenantInfoResp 为服务端对当前租户施加的配置与限制；未设置的限制为零值。
+type TenantInfoResp struct {
+	TenantID string `json:"tenant_id"`
+	Name     string `json:"name,omitempty"`
+	Plan     string `json:"plan,omitempty"`
+	// MaxConcurrentJobs 为同时执行的任务数上限，MaxQueuedJobs 为排队任务数上限。
+	MaxConcurrentJobs int `json:"max_concurrent_jobs,omitempty"`
+	MaxQueuedJobs     int `json:"max_queued_jobs,omitempty"`
+	// SubmitRatePerMinute 为每分钟可提交的任务数上限。
+	SubmitRatePerMinute int `json:"submit_rate_per_minute,omitempty"`
+	// AllowedCandidateCounts 为单次请求允许的候选数（candidate_count）。
+	AllowedCandidateCounts []int  `json:"allowed_candidate_counts,omitempty"`
+	RulesChannel           string `json:"rules_channel,omitempty"`
+	RulesVersion           string `json:"rules_version,omitempty"`
+	// Marketplaces 为租户可用的目标站点。
+	Marketplaces []string `json:"marketplaces,omitempty"`
 }
 
 type JobTraceItem struct {
@@ -0,0 +1,46 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"strings"
+
+	"syl-listing-pro/internal/i18n"
+	"syl-listing-pro/internal/util"
+)
+
+// LoadBannedWords 读取禁用词表：每行一个词或短语，# 开头为注释，重复项只保留一次。
+// path 为空时读取 ~/.syl-listing-pro/banned_words.txt，该文件不存在视为未配置；
+// 显式指定的文件不存在则报错。
+func LoadBannedWords(path string) ([]string, error) {
+	explicit := path != ""
+	if !explicit {
+		p, err := util.DefaultBannedWordsPath()
+		if err != nil {
+			return nil, err
+		}
+		path = p
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		if !explicit && errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, i18n.Errorf("读取禁用词表失败: %w", err)
+	}
+	seen := map[string]bool{}
+	var out []string
+	for _, raw := range strings.Split(string(b), "\n") {
+		word := strings.TrimSpace(strings.TrimPrefix(raw, "\ufeff"))
+		if word == "" || strings.HasPrefix(word, "#") {
+			continue
+		}
+		key := strings.ToLower(word)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		out = append(out, word)
+	}
+	return out, nil
+}
@@ -0,0 +1,37 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestLoadBannedWords(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	words, err := LoadBannedWords("")
+	if err != nil || words != nil {
+		t.Fatalf("missing default list should be empty, got %v, %v", words, err)
+	}
+	if _, err := LoadBannedWords(filepath.Join(home, "nope.txt")); err == nil {
+		t.Fatal("expected error for missing explicit list")
+	}
+
+	dir := filepath.Join(home, ".syl-listing-pro")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	content := "# 品牌词\nYETI\n\nbest seller\nyeti\n最佳\n"
+	if err := os.WriteFile(filepath.Join(dir, "banned_words.txt"), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	words, err = LoadBannedWords("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"YETI", "best seller", "最佳"}; !reflect.DeepEqual(words, want) {
+		t.Fatalf("words=%v want=%v", words, want)
+	}
+}
@@ -0,0 +1,31 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"strings"
+)
+
+// flagDefaultPrefix 为配置文件中参数默认值的前缀：SYL_DEFAULT_OUT=listings 等同于默认传入 --out listings。
+const flagDefaultPrefix = "SYL_DEFAULT_"
+
+// LoadFlagDefaults 从配置文件读取 SYL_DEFAULT_<参数名> 形式的参数默认值，返回参数名（小写、连字符）到取值的映射。
+// 配置文件不存在时返回空映射。
+func LoadFlagDefaults() (map[string]string, error) {
+	values, err := loadEnvFile()
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	out := map[string]string{}
+	for k, v := range values {
+		name, ok := strings.CutPrefix(k, flagDefaultPrefix)
+		if !ok || name == "" {
+			continue
+		}
+		out[strings.ReplaceAll(strings.ToLower(name), "_", "-")] = v
+	}
+	return out, nil
+}
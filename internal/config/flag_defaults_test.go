@@ -0,0 +1,38 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"syl-listing-pro/internal/util"
+)
+
+func TestLoadFlagDefaults(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("SYL_LISTING_KEY", "")
+	t.Setenv("SYL_KEY_PROFILE", "")
+	got, err := LoadFlagDefaults()
+	if err != nil || len(got) != 0 {
+		t.Fatalf("missing config: got=%v err=%v", got, err)
+	}
+
+	p := filepath.Join(t.TempDir(), "team.env")
+	content := "SYL_LISTING_KEY=abc\nSYL_DEFAULT_OUT=\"out/team\"\nSYL_DEFAULT_RETRY_FAILED=2\nSYL_DEFAULT_=x\n"
+	if err := os.WriteFile(p, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	util.SetEnvPath(p)
+	defer util.SetEnvPath("")
+	got, err = LoadFlagDefaults()
+	if err != nil {
+		t.Fatalf("LoadFlagDefaults error: %v", err)
+	}
+	if len(got) != 2 || got["out"] != "out/team" || got["retry-failed"] != "2" {
+		t.Fatalf("unexpected defaults: %v", got)
+	}
+	key, err := LoadSYLListingKey()
+	if err != nil || key != "abc" {
+		t.Fatalf("key from --config file: %q, %v", key, err)
+	}
+}
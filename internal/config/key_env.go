@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"syl-listing-pro/internal/i18n"
 	"syl-listing-pro/internal/util"
 )
 
@@ -15,17 +16,23 @@ const sylKeyEnvName = "SYL_LISTING_KEY"
 var ErrSYLKeyNotConfigured = errors.New("syl_listing_key_not_configured")
 
 func LoadSYLListingKey() (string, error) {
+	return LoadSYLListingKeyProfile(DefaultKeyProfile)
+}
+
+// loadEnvFile 读取 ~/.syl-listing-pro/.env 中的全部键值；文件不存在时返回 os.ErrNotExist。
+func loadEnvFile() (map[string]string, error) {
 	p, err := util.DefaultEnvPath()
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	b, err := os.ReadFile(p)
 	if err != nil {
 		if errors.Is(err, os.ErrNotExist) {
-			return "", ErrSYLKeyNotConfigured
+			return nil, err
 		}
-		return "", fmt.Errorf("读取 .env 失败: %w", err)
+		return nil, i18n.Errorf("读取 .env 失败: %w", err)
 	}
+	values := map[string]string{}
 	for _, raw := range strings.Split(string(b), "\n") {
 		line := strings.TrimSpace(raw)
 		if line == "" || strings.HasPrefix(line, "#") {
@@ -35,36 +42,37 @@ func LoadSYLListingKey() (string, error) {
 		if !ok {
 			continue
 		}
-		if strings.TrimSpace(k) != sylKeyEnvName {
+		key := strings.TrimSpace(k)
+		if _, seen := values[key]; seen {
 			continue
 		}
-		value := strings.TrimSpace(v)
-		value = strings.Trim(value, `"'`)
-		if value == "" {
-			return "", ErrSYLKeyNotConfigured
-		}
-		return value, nil
+		values[key] = strings.Trim(strings.TrimSpace(v), `"'`)
 	}
-	return "", ErrSYLKeyNotConfigured
+	return values, nil
 }
 
 func SaveSYLListingKey(key string) error {
+	return saveEnvValue(sylKeyEnvName, key)
+}
+
+// saveEnvValue 在 .env 中写入 name=value：已有同名行则原地替换，否则追加到末尾。
+func saveEnvValue(name, value string) error {
 	p, err := util.DefaultEnvPath()
 	if err != nil {
 		return err
 	}
 	if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
-		return fmt.Errorf("创建配置目录失败: %w", err)
+		return i18n.Errorf("创建配置目录失败: %w", err)
 	}
 
-	line := fmt.Sprintf("%s=%s", sylKeyEnvName, key)
+	line := fmt.Sprintf("%s=%s", name, value)
 
 	b, err := os.ReadFile(p)
 	if err != nil {
 		if errors.Is(err, os.ErrNotExist) {
 			return os.WriteFile(p, []byte(line+"\n"), 0o644)
 		}
-		return fmt.Errorf("读取 .env 失败: %w", err)
+		return i18n.Errorf("读取 .env 失败: %w", err)
 	}
 
 	lines := strings.Split(string(b), "\n")
@@ -78,7 +86,7 @@ func SaveSYLListingKey(key string) error {
 		if !ok {
 			continue
 		}
-		if strings.TrimSpace(k) == sylKeyEnvName {
+		if strings.TrimSpace(k) == name {
 			lines[i] = line
 			replaced = true
 		}
@@ -94,7 +102,45 @@ func SaveSYLListingKey(key string) error {
 		content += "\n"
 	}
 	if err := os.WriteFile(p, []byte(content), 0o644); err != nil {
-		return fmt.Errorf("写 .env 失败: %w", err)
+		return i18n.Errorf("写 .env 失败: %w", err)
 	}
 	return nil
 }
+
+// sensitiveEnvMarkers 出现在键名中的片段视为敏感配置，导出诊断信息时隐去取值。
+var sensitiveEnvMarkers = []string{"KEY", "SECRET", "TOKEN", "PASSWORD"}
+
+// IsSensitiveEnvName 判断配置项是否为 KEY、密钥、令牌一类不能外发的值。
+func IsSensitiveEnvName(name string) bool {
+	upper := strings.ToUpper(name)
+	if upper == activeProfileEnv {
+		return false
+	}
+	for _, m := range sensitiveEnvMarkers {
+		if strings.Contains(upper, m) {
+			return true
+		}
+	}
+	return false
+}
+
+// LoadSanitizedEnv 返回 .env 中的配置，敏感项取值替换为 [REDACTED]；secrets 为被隐去的原值，
+// 供调用方在其他文本中一并隐去。.env 不存在时返回空。
+func LoadSanitizedEnv() (values map[string]string, secrets []string, err error) {
+	raw, err := loadEnvFile()
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return map[string]string{}, nil, nil
+		}
+		return nil, nil, err
+	}
+	values = make(map[string]string, len(raw))
+	for k, v := range raw {
+		if IsSensitiveEnvName(k) && v != "" {
+			secrets = append(secrets, v)
+			v = "[REDACTED]"
+		}
+		values[k] = v
+	}
+	return values, secrets, nil
+}
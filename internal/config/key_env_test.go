@@ -125,3 +125,110 @@ func TestSaveSYLListingKey_AppendWhenNoExistingKey(t *testing.T) {
 		t.Fatalf("unexpected env content: %q", s)
 	}
 }
+
+func TestLoadPublishConfig(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	if _, err := LoadPublishConfig(); !errors.Is(err, ErrPublishNotConfigured) {
+		t.Fatalf("err=%v, want ErrPublishNotConfigured", err)
+	}
+	dir := filepath.Join(home, ".syl-listing-pro")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	env := "SYL_LISTING_KEY=k\nSYL_PUBLISH_PROVIDER=oss\nSYL_PUBLISH_BUCKET=\"bkt\"\nSYL_PUBLISH_ACCESS_KEY_ID=id\nSYL_PUBLISH_SECRET_ACCESS_KEY=secret\nSYL_PUBLISH_PATH_STYLE=true\n"
+	if err := os.WriteFile(filepath.Join(dir, ".env"), []byte(env), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := LoadPublishConfig()
+	if err != nil {
+		t.Fatalf("LoadPublishConfig error: %v", err)
+	}
+	if cfg.Provider != "oss" || cfg.Bucket != "bkt" || cfg.AccessKeyID != "id" || cfg.SecretAccessKey != "secret" || !cfg.PathStyle {
+		t.Fatalf("unexpected cfg: %+v", cfg)
+	}
+}
+
+func TestKeyProfiles(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	if p, err := LoadActiveKeyProfile(); err != nil || p != DefaultKeyProfile {
+		t.Fatalf("active=%q err=%v", p, err)
+	}
+	if err := SaveSYLListingKey("k0"); err != nil {
+		t.Fatal(err)
+	}
+	if err := SaveSYLListingKeyProfile("clientA", "kA"); err != nil {
+		t.Fatal(err)
+	}
+	if err := SaveSYLListingKeyProfile("bad-name", "x"); !errors.Is(err, ErrInvalidKeyProfile) {
+		t.Fatalf("err=%v, want ErrInvalidKeyProfile", err)
+	}
+	if got, err := LoadSYLListingKeyProfile("clientA"); err != nil || got != "kA" {
+		t.Fatalf("got=%q err=%v", got, err)
+	}
+	if got, err := LoadSYLListingKeyProfile(DefaultKeyProfile); err != nil || got != "k0" {
+		t.Fatalf("got=%q err=%v", got, err)
+	}
+	if _, err := LoadSYLListingKeyProfile("clientB"); !errors.Is(err, ErrSYLKeyNotConfigured) {
+		t.Fatalf("err=%v, want ErrSYLKeyNotConfigured", err)
+	}
+	if err := SaveActiveKeyProfile("clientA"); err != nil {
+		t.Fatal(err)
+	}
+	if p, err := LoadActiveKeyProfile(); err != nil || p != "clientA" {
+		t.Fatalf("active=%q err=%v", p, err)
+	}
+	profiles, err := ListKeyProfiles()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(profiles, ",") != "clientA,default" {
+		t.Fatalf("profiles=%v", profiles)
+	}
+}
+
+func TestLoadSYLListingKey_ProcessEnvTakesPrecedence(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	if err := SaveSYLListingKey("from-file"); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("SYL_LISTING_KEY", " from-env ")
+	t.Setenv("SYL_LISTING_KEY_clientA", "env-a")
+	t.Setenv("SYL_KEY_PROFILE", "clientA")
+	if got, err := LoadSYLListingKey(); err != nil || got != "from-env" {
+		t.Fatalf("got=%q err=%v", got, err)
+	}
+	if got, err := LoadSYLListingKeyProfile("clientA"); err != nil || got != "env-a" {
+		t.Fatalf("got=%q err=%v", got, err)
+	}
+	if p, err := LoadActiveKeyProfile(); err != nil || p != "clientA" {
+		t.Fatalf("active=%q err=%v", p, err)
+	}
+}
+
+func TestLoadSYLListingKey_SecretFile(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	if err := SaveSYLListingKey("from-file"); err != nil {
+		t.Fatal(err)
+	}
+	secret := filepath.Join(t.TempDir(), "syl_key")
+	if err := os.WriteFile(secret, []byte("from-secret\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("SYL_LISTING_KEY_FILE", secret)
+	if got, err := LoadSYLListingKey(); err != nil || got != "from-secret" {
+		t.Fatalf("got=%q err=%v", got, err)
+	}
+	t.Setenv("SYL_LISTING_KEY", "from-env")
+	if got, err := LoadSYLListingKey(); err != nil || got != "from-env" {
+		t.Fatalf("got=%q err=%v", got, err)
+	}
+	t.Setenv("SYL_LISTING_KEY", "")
+	t.Setenv("SYL_LISTING_KEY_FILE", filepath.Join(t.TempDir(), "missing"))
+	if _, err := LoadSYLListingKey(); err == nil || !strings.Contains(err.Error(), "读取 KEY 文件失败") {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if err := ValidateKeyProfile("prod_FILE"); !errors.Is(err, ErrInvalidKeyProfile) {
+		t.Fatalf("err=%v, want ErrInvalidKeyProfile", err)
+	}
+}
@@ -0,0 +1,159 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"syl-listing-pro/internal/i18n"
+)
+
+// DefaultKeyProfile 是未命名 KEY（SYL_LISTING_KEY）对应的配置名。
+const DefaultKeyProfile = "default"
+
+const (
+	keyProfileEnvPrefix = sylKeyEnvName + "_"
+	activeProfileEnv    = "SYL_KEY_PROFILE"
+	// keyFileEnvSuffix 拼在 KEY 环境变量名后，指向挂载的密钥文件，例如 SYL_LISTING_KEY_FILE。
+	keyFileEnvSuffix = "_FILE"
+)
+
+var keyProfileNamePattern = regexp.MustCompile(`^[A-Za-z0-9_]+$`)
+
+// ErrInvalidKeyProfile 表示配置名含有字母、数字、下划线以外的字符，或以 _FILE 结尾。
+var ErrInvalidKeyProfile = errors.New("invalid_key_profile")
+
+// ValidateKeyProfile 校验配置名；空字符串视为 default。
+func ValidateKeyProfile(profile string) error {
+	if profile == "" || isValidKeyProfileName(profile) {
+		return nil
+	}
+	return fmt.Errorf("%w: %s", ErrInvalidKeyProfile, profile)
+}
+
+// isValidKeyProfileName 排除 FILE 及 *_FILE，避免与密钥文件变量重名。
+func isValidKeyProfileName(name string) bool {
+	if name == "FILE" || strings.HasSuffix(name, keyFileEnvSuffix) {
+		return false
+	}
+	return keyProfileNamePattern.MatchString(name)
+}
+
+// keyProfileEnvName 返回配置在 .env 中的键名：default 为 SYL_LISTING_KEY，
+// 其余为 SYL_LISTING_KEY_<profile>。
+func keyProfileEnvName(profile string) string {
+	if profile == "" || profile == DefaultKeyProfile {
+		return sylKeyEnvName
+	}
+	return keyProfileEnvPrefix + profile
+}
+
+// LoadSYLListingKeyProfile 读取指定配置的 KEY，依次查找：
+//  1. 同名进程环境变量，例如 SYL_LISTING_KEY；
+//  2. <同名>_FILE 指向的密钥文件，例如 SYL_LISTING_KEY_FILE=/run/secrets/syl_key；
+//  3. ~/.syl-listing-pro/.env。
+//
+// 前两种便于 CI 与容器注入密钥而不落盘。
+func LoadSYLListingKeyProfile(profile string) (string, error) {
+	if err := ValidateKeyProfile(profile); err != nil {
+		return "", err
+	}
+	name := keyProfileEnvName(profile)
+	if value := strings.TrimSpace(os.Getenv(name)); value != "" {
+		return value, nil
+	}
+	if path := strings.TrimSpace(os.Getenv(name + keyFileEnvSuffix)); path != "" {
+		return readKeyFile(path)
+	}
+	values, err := loadEnvFile()
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", ErrSYLKeyNotConfigured
+		}
+		return "", err
+	}
+	value := values[keyProfileEnvName(profile)]
+	if value == "" {
+		return "", ErrSYLKeyNotConfigured
+	}
+	return value, nil
+}
+
+func readKeyFile(path string) (string, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return "", i18n.Errorf("读取 KEY 文件失败: %w", err)
+	}
+	value := strings.TrimSpace(string(b))
+	if value == "" {
+		return "", i18n.Errorf("KEY 文件为空: %s", path)
+	}
+	return value, nil
+}
+
+// SaveSYLListingKeyProfile 保存指定配置的 KEY。
+func SaveSYLListingKeyProfile(profile, key string) error {
+	if err := ValidateKeyProfile(profile); err != nil {
+		return err
+	}
+	return saveEnvValue(keyProfileEnvName(profile), key)
+}
+
+// LoadActiveKeyProfile 返回当前配置：SYL_KEY_PROFILE 环境变量优先，其次为 use 命令
+// 选中的配置，都没有时为 default。
+func LoadActiveKeyProfile() (string, error) {
+	if profile := strings.TrimSpace(os.Getenv(activeProfileEnv)); profile != "" {
+		return profile, nil
+	}
+	values, err := loadEnvFile()
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return DefaultKeyProfile, nil
+		}
+		return "", err
+	}
+	if profile := values[activeProfileEnv]; profile != "" {
+		return profile, nil
+	}
+	return DefaultKeyProfile, nil
+}
+
+// SaveActiveKeyProfile 记录默认使用的配置。
+func SaveActiveKeyProfile(profile string) error {
+	if err := ValidateKeyProfile(profile); err != nil {
+		return err
+	}
+	if profile == "" {
+		profile = DefaultKeyProfile
+	}
+	return saveEnvValue(activeProfileEnv, profile)
+}
+
+// ListKeyProfiles 按名称排序返回已保存 KEY 的全部配置名。
+func ListKeyProfiles() ([]string, error) {
+	values, err := loadEnvFile()
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var out []string
+	for k, v := range values {
+		if v == "" {
+			continue
+		}
+		if k == sylKeyEnvName {
+			out = append(out, DefaultKeyProfile)
+			continue
+		}
+		if name, ok := strings.CutPrefix(k, keyProfileEnvPrefix); ok && isValidKeyProfileName(name) {
+			out = append(out, name)
+		}
+	}
+	sort.Strings(out)
+	return out, nil
+}
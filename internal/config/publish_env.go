@@ -0,0 +1,40 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"strings"
+
+	"syl-listing-pro/internal/publish"
+)
+
+// ErrPublishNotConfigured 表示 .env 中没有配置对象存储上传。
+var ErrPublishNotConfigured = errors.New("publish_not_configured")
+
+// LoadPublishConfig 从 .env 读取上传配置：
+// SYL_PUBLISH_PROVIDER（s3|oss|gcs）、SYL_PUBLISH_BUCKET、SYL_PUBLISH_ACCESS_KEY_ID、
+// SYL_PUBLISH_SECRET_ACCESS_KEY，可选 SYL_PUBLISH_REGION、SYL_PUBLISH_ENDPOINT、
+// SYL_PUBLISH_PREFIX、SYL_PUBLISH_PATH_STYLE。
+func LoadPublishConfig() (publish.Config, error) {
+	values, err := loadEnvFile()
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return publish.Config{}, ErrPublishNotConfigured
+		}
+		return publish.Config{}, err
+	}
+	if values["SYL_PUBLISH_PROVIDER"] == "" {
+		return publish.Config{}, ErrPublishNotConfigured
+	}
+	pathStyle := strings.ToLower(values["SYL_PUBLISH_PATH_STYLE"])
+	return publish.Config{
+		Provider:        values["SYL_PUBLISH_PROVIDER"],
+		Endpoint:        values["SYL_PUBLISH_ENDPOINT"],
+		Region:          values["SYL_PUBLISH_REGION"],
+		Bucket:          values["SYL_PUBLISH_BUCKET"],
+		Prefix:          values["SYL_PUBLISH_PREFIX"],
+		AccessKeyID:     values["SYL_PUBLISH_ACCESS_KEY_ID"],
+		SecretAccessKey: values["SYL_PUBLISH_SECRET_ACCESS_KEY"],
+		PathStyle:       pathStyle == "1" || pathStyle == "true",
+	}, nil
+}
@@ -0,0 +1,48 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"syl-listing-pro/internal/i18n"
+)
+
+// Route 把匹配 Pattern 的需求文件交给 Profile 对应的 KEY 配置生成。
+type Route struct {
+	Pattern string
+	Profile string
+}
+
+// LoadRoutes 读取路由文件：每行“<路径或通配符> <KEY 配置名>”，# 开头为注释，按先后顺序匹配。
+// 通配符语法同 filepath.Match，以 / 结尾的模式匹配该目录下的全部文件。
+func LoadRoutes(path string) ([]Route, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, i18n.Errorf("读取路由文件失败: %w", err)
+	}
+	var routes []Route
+	for i, raw := range strings.Split(strings.TrimPrefix(string(b), "\ufeff"), "\n") {
+		line := strings.TrimSpace(raw)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			return nil, i18n.Errorf("路由文件第 %d 行格式应为“<路径或通配符> <KEY 配置名>”: %s", i+1, line)
+		}
+		profile := fields[len(fields)-1]
+		pattern := strings.TrimSpace(strings.TrimSuffix(line, profile))
+		if err := ValidateKeyProfile(profile); err != nil {
+			return nil, i18n.Errorf("路由文件第 %d 行: %w", i+1, err)
+		}
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return nil, i18n.Errorf("路由文件第 %d 行通配符无效: %s", i+1, pattern)
+		}
+		routes = append(routes, Route{Pattern: pattern, Profile: profile})
+	}
+	if len(routes) == 0 {
+		return nil, i18n.Errorf("路由文件没有任何规则: %s", path)
+	}
+	return routes, nil
+}
@@ -0,0 +1,45 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLoadRoutes(t *testing.T) {
+	dir := t.TempDir()
+	p := filepath.Join(dir, "routes.txt")
+	content := "\ufeff# 租户路由\nbrand-a/ tenant_a\n*_b.md tenant_b\n\n/abs/my dir/*.md tenant_c\n"
+	if err := os.WriteFile(p, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	got, err := LoadRoutes(p)
+	if err != nil {
+		t.Fatalf("LoadRoutes error: %v", err)
+	}
+	want := []Route{{"brand-a/", "tenant_a"}, {"*_b.md", "tenant_b"}, {"/abs/my dir/*.md", "tenant_c"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got=%v want=%v", got, want)
+	}
+
+	for content, wantErr := range map[string]string{
+		"onlyone\n":         "格式应为",
+		"*.md bad-name\n":   "路由文件第 1 行",
+		"[ tenant\n":        "通配符无效",
+		"# only comments\n": "没有任何规则",
+	} {
+		if err := os.WriteFile(p, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		_, err := LoadRoutes(p)
+		if err == nil || !strings.Contains(err.Error(), wantErr) {
+			t.Fatalf("content %q: err=%v want %q", content, err, wantErr)
+		}
+	}
+	if _, err := LoadRoutes(filepath.Join(dir, "missing.txt")); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("missing file err=%v", err)
+	}
+}
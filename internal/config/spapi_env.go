@@ -0,0 +1,32 @@
+package config
+
+import (
+	"errors"
+	"os"
+
+	"syl-listing-pro/internal/amazon"
+)
+
+// LoadSPAPIConfig 从 .env 读取 SP-API 凭据：SYL_SPAPI_CLIENT_ID、SYL_SPAPI_CLIENT_SECRET、
+// SYL_SPAPI_REFRESH_TOKEN，可选 SYL_SPAPI_ENDPOINT（默认北美区）、SYL_SPAPI_MARKETPLACE_ID（默认美国站）。
+// 未配置时返回 amazon.ErrSPAPINotConfigured。
+func LoadSPAPIConfig() (amazon.SPAPIConfig, error) {
+	values, err := loadEnvFile()
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return amazon.SPAPIConfig{}, amazon.ErrSPAPINotConfigured
+		}
+		return amazon.SPAPIConfig{}, err
+	}
+	cfg := amazon.SPAPIConfig{
+		ClientID:      values["SYL_SPAPI_CLIENT_ID"],
+		ClientSecret:  values["SYL_SPAPI_CLIENT_SECRET"],
+		RefreshToken:  values["SYL_SPAPI_REFRESH_TOKEN"],
+		Endpoint:      values["SYL_SPAPI_ENDPOINT"],
+		MarketplaceID: values["SYL_SPAPI_MARKETPLACE_ID"],
+	}
+	if cfg.ClientID == "" || cfg.ClientSecret == "" || cfg.RefreshToken == "" {
+		return amazon.SPAPIConfig{}, amazon.ErrSPAPINotConfigured
+	}
+	return cfg, nil
+}
@@ -0,0 +1,71 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"strings"
+
+	"syl-listing-pro/internal/i18n"
+	"syl-listing-pro/internal/util"
+)
+
+// systemDictionaryPaths 为未指定词典时依次查找的系统英文词典（hunspell .dic 或单词表）。
+var systemDictionaryPaths = []string{
+	"/usr/share/hunspell/en_US.dic",
+	"/usr/share/myspell/en_US.dic",
+	"/usr/share/dict/american-english",
+	"/usr/share/dict/words",
+}
+
+// LoadSpellDictionary 读取英文拼写词典并合并 ~/.syl-listing-pro/dictionary.txt 中的用户词（品牌词等），
+// 返回小写单词集合。path 为空时查找系统词典，都不存在时返回 nil 表示无法检查；
+// 显式指定的词典不存在则报错。支持 hunspell .dic（首行词数、“词/标记”）与每行一词的单词表。
+func LoadSpellDictionary(path string) (map[string]struct{}, error) {
+	explicit := path != ""
+	var b []byte
+	var err error
+	if explicit {
+		if b, err = os.ReadFile(path); err != nil {
+			return nil, i18n.Errorf("读取拼写词典失败: %w", err)
+		}
+	} else {
+		for _, p := range systemDictionaryPaths {
+			if b, err = os.ReadFile(p); err == nil {
+				break
+			}
+		}
+		if b == nil {
+			return nil, nil
+		}
+	}
+	dict := map[string]struct{}{}
+	addDictionaryWords(dict, string(b))
+
+	userPath, err := util.DefaultUserDictionaryPath()
+	if err != nil {
+		return nil, err
+	}
+	ub, err := os.ReadFile(userPath)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, i18n.Errorf("读取用户词典失败: %w", err)
+	}
+	addDictionaryWords(dict, string(ub))
+	return dict, nil
+}
+
+func addDictionaryWords(dict map[string]struct{}, content string) {
+	for i, raw := range strings.Split(strings.TrimPrefix(content, "\ufeff"), "\n") {
+		line := strings.TrimSpace(raw)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		// hunspell .dic 首行是词条数。
+		if i == 0 && strings.Trim(line, "0123456789") == "" {
+			continue
+		}
+		word, _, _ := strings.Cut(line, "/")
+		for _, w := range strings.Fields(word) {
+			dict[strings.ToLower(w)] = struct{}{}
+		}
+	}
+}
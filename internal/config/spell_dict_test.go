@@ -0,0 +1,38 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadSpellDictionary(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	dic := filepath.Join(t.TempDir(), "en_US.dic")
+	if err := os.WriteFile(dic, []byte("3\nbottle/MS\nKeep/SG\ncold\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	userDict := filepath.Join(home, ".syl-listing-pro", "dictionary.txt")
+	if err := os.MkdirAll(filepath.Dir(userDict), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(userDict, []byte("# 品牌词\nSYLsteel\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	dict, err := LoadSpellDictionary(dic)
+	if err != nil {
+		t.Fatalf("LoadSpellDictionary error: %v", err)
+	}
+	for _, w := range []string{"bottle", "keep", "cold", "sylsteel"} {
+		if _, ok := dict[w]; !ok {
+			t.Fatalf("missing %q in %v", w, dict)
+		}
+	}
+	if _, ok := dict["3"]; ok || len(dict) != 4 {
+		t.Fatalf("unexpected entries: %v", dict)
+	}
+	if _, err := LoadSpellDictionary(filepath.Join(t.TempDir(), "missing.dic")); err == nil {
+		t.Fatalf("explicit missing dictionary should fail")
+	}
+}
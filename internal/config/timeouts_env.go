@@ -0,0 +1,37 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"time"
+
+	"syl-listing-pro/internal/client"
+	"syl-listing-pro/internal/i18n"
+)
+
+// LoadStageTimeouts 从 .env 读取各请求阶段的超时：SYL_TIMEOUT_EXCHANGE、SYL_TIMEOUT_SUBMIT、
+// SYL_TIMEOUT_RESULT，取值为 Go 时长格式（如 15s、3m）。未配置的阶段不出现在结果中。
+func LoadStageTimeouts() (map[client.Stage]time.Duration, error) {
+	values, err := loadEnvFile()
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	out := map[client.Stage]time.Duration{}
+	for _, stage := range client.Stages {
+		name := "SYL_TIMEOUT_" + strings.ToUpper(string(stage))
+		raw := strings.TrimSpace(values[name])
+		if raw == "" {
+			continue
+		}
+		d, err := time.ParseDuration(raw)
+		if err != nil || d <= 0 {
+			return nil, i18n.Errorf("%s 不是有效时长: %s", name, raw)
+		}
+		out[stage] = d
+	}
+	return out, nil
+}
@@ -0,0 +1,193 @@
+package config
+
+import (
+	"errors"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"syl-listing-pro/internal/client"
+	"syl-listing-pro/internal/i18n"
+	"syl-listing-pro/internal/publish"
+	"syl-listing-pro/internal/util"
+)
+
+// 超时配置允许的取值范围。
+const (
+	minStageTimeout = time.Second
+	maxStageTimeout = time.Hour
+)
+
+// Problem 为配置检查发现的一个问题；Line 为 .env 中的行号，0 表示与具体行无关。
+type Problem struct {
+	Line    int
+	Key     string
+	Message string
+}
+
+func (p Problem) String() string {
+	switch {
+	case p.Line > 0 && p.Key != "":
+		return i18n.T("第 %d 行 %s：%s", p.Line, p.Key, p.Message)
+	case p.Line > 0:
+		return i18n.T("第 %d 行：%s", p.Line, p.Message)
+	case p.Key != "":
+		return i18n.T("%s：%s", p.Key, p.Message)
+	}
+	return p.Message
+}
+
+// envOnlyKeys 只从进程环境变量读取，写在 .env 中不会生效。
+var envOnlyKeys = map[string]bool{
+	util.StateDirEnvName:     true,
+	"SYL_LISTING_WORKER_URL": true,
+	"SYL_LISTING_KEY_FILE":   true,
+}
+
+// knownEnvKeys 为 .env 中可识别的固定键名；SYL_LISTING_KEY_<配置名> 与 SYL_DEFAULT_<参数名> 另行校验。
+var knownEnvKeys = map[string]bool{
+	sylKeyEnvName: true, activeProfileEnv: true,
+	"SYL_TIMEOUT_EXCHANGE": true, "SYL_TIMEOUT_SUBMIT": true, "SYL_TIMEOUT_RESULT": true,
+	"SYL_PUBLISH_PROVIDER": true, "SYL_PUBLISH_BUCKET": true, "SYL_PUBLISH_ACCESS_KEY_ID": true,
+	"SYL_PUBLISH_SECRET_ACCESS_KEY": true, "SYL_PUBLISH_REGION": true, "SYL_PUBLISH_ENDPOINT": true,
+	"SYL_PUBLISH_PREFIX": true, "SYL_PUBLISH_PATH_STYLE": true,
+	"SYL_SPAPI_CLIENT_ID": true, "SYL_SPAPI_CLIENT_SECRET": true, "SYL_SPAPI_REFRESH_TOKEN": true,
+	"SYL_SPAPI_ENDPOINT": true, "SYL_SPAPI_MARKETPLACE_ID": true,
+}
+
+// ValidateEnvFile 严格检查配置文件并一次列出全部问题：无法解析的行、重复与未知的键、
+// 超时范围、URL 格式、上传与 SP-API 配置是否完整、默认参数是否存在（flags 为全部参数名，为空时不检查）。
+// 返回配置文件路径；文件不存在时 exists 为 false，不算问题。
+func ValidateEnvFile(flags map[string]bool) (path string, exists bool, problems []Problem, err error) {
+	path, err = util.DefaultEnvPath()
+	if err != nil {
+		return "", false, nil, err
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return path, false, nil, nil
+		}
+		return path, false, nil, i18n.Errorf("读取 .env 失败: %w", err)
+	}
+	values := map[string]string{}
+	lines := map[string]int{}
+	add := func(line int, key, format string, args ...any) {
+		problems = append(problems, Problem{Line: line, Key: key, Message: i18n.T(format, args...)})
+	}
+	for i, raw := range strings.Split(strings.ReplaceAll(string(b), "\r\n", "\n"), "\n") {
+		n := i + 1
+		line := strings.TrimSpace(strings.TrimPrefix(raw, "\ufeff"))
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		k, v, ok := strings.Cut(line, "=")
+		key := strings.TrimSpace(k)
+		if !ok || key == "" {
+			add(n, "", "无法解析，应为 KEY=VALUE")
+			continue
+		}
+		if first, seen := lines[key]; seen {
+			add(n, key, "与第 %d 行重复，只有第一处生效", first)
+			continue
+		}
+		lines[key] = n
+		values[key] = strings.Trim(strings.TrimSpace(v), `"'`)
+		switch {
+		case envOnlyKeys[key]:
+			add(n, key, "只能通过进程环境变量设置，写在配置文件中不生效")
+		case knownEnvKeys[key]:
+		case strings.HasPrefix(key, keyProfileEnvPrefix):
+			if name := strings.TrimPrefix(key, keyProfileEnvPrefix); !isValidKeyProfileName(name) {
+				add(n, key, "配置名 %s 无效：只能包含字母、数字和下划线，且不能以 _FILE 结尾", name)
+			}
+		case strings.HasPrefix(key, flagDefaultPrefix):
+			name := strings.ReplaceAll(strings.ToLower(strings.TrimPrefix(key, flagDefaultPrefix)), "_", "-")
+			if len(flags) > 0 && !flags[name] {
+				add(n, key, "没有参数 --%s", name)
+			}
+		default:
+			add(n, key, "未知配置项")
+		}
+	}
+
+	if p := values[activeProfileEnv]; p != "" {
+		if !isValidKeyProfileName(p) {
+			add(lines[activeProfileEnv], activeProfileEnv, "配置名 %s 无效", p)
+		} else if p != DefaultKeyProfile && values[keyProfileEnvName(p)] == "" && os.Getenv(keyProfileEnvName(p)) == "" {
+			add(lines[activeProfileEnv], activeProfileEnv, "选中的配置 %s 没有对应的 %s", p, keyProfileEnvName(p))
+		}
+	}
+	for _, stage := range client.Stages {
+		key := "SYL_TIMEOUT_" + strings.ToUpper(string(stage))
+		raw, ok := values[key]
+		if !ok {
+			continue
+		}
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			add(lines[key], key, "不是有效时长（如 15s、3m）：%s", raw)
+		} else if d < minStageTimeout || d > maxStageTimeout {
+			add(lines[key], key, "超出范围 %s～%s：%s", minStageTimeout, maxStageTimeout, raw)
+		}
+	}
+	for _, key := range []string{"SYL_PUBLISH_ENDPOINT", "SYL_SPAPI_ENDPOINT"} {
+		if raw := values[key]; raw != "" {
+			if err := ValidateURL(raw); err != nil {
+				add(lines[key], key, "%v", err)
+			}
+		}
+	}
+	if anyPrefixed(values, "SYL_PUBLISH_") {
+		switch strings.ToLower(values["SYL_PUBLISH_PROVIDER"]) {
+		case publish.ProviderS3, publish.ProviderOSS, publish.ProviderGCS:
+		case "":
+			add(0, "SYL_PUBLISH_PROVIDER", "已配置上传参数但缺少上传服务（s3、oss、gcs）")
+		default:
+			add(lines["SYL_PUBLISH_PROVIDER"], "SYL_PUBLISH_PROVIDER", "仅支持 s3、oss、gcs：%s", values["SYL_PUBLISH_PROVIDER"])
+		}
+		for _, key := range []string{"SYL_PUBLISH_BUCKET", "SYL_PUBLISH_ACCESS_KEY_ID", "SYL_PUBLISH_SECRET_ACCESS_KEY"} {
+			if values[key] == "" {
+				add(0, key, "已配置上传但缺少该项")
+			}
+		}
+		if raw, ok := values["SYL_PUBLISH_PATH_STYLE"]; ok {
+			switch strings.ToLower(raw) {
+			case "", "0", "1", "true", "false":
+			default:
+				add(lines["SYL_PUBLISH_PATH_STYLE"], "SYL_PUBLISH_PATH_STYLE", "应为 true 或 false：%s", raw)
+			}
+		}
+	}
+	if anyPrefixed(values, "SYL_SPAPI_") {
+		for _, key := range []string{"SYL_SPAPI_CLIENT_ID", "SYL_SPAPI_CLIENT_SECRET", "SYL_SPAPI_REFRESH_TOKEN"} {
+			if values[key] == "" {
+				add(0, key, "已配置 SP-API 但缺少该项")
+			}
+		}
+	}
+	sort.SliceStable(problems, func(i, j int) bool {
+		return problems[i].Line != 0 && (problems[j].Line == 0 || problems[i].Line < problems[j].Line)
+	})
+	return path, true, problems, nil
+}
+
+// ValidateURL 检查 URL 是否为带主机名的 http/https 地址。
+func ValidateURL(raw string) error {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return i18n.Errorf("不是有效的 http(s) URL：%s", raw)
+	}
+	return nil
+}
+
+func anyPrefixed(values map[string]string, prefix string) bool {
+	for k, v := range values {
+		if strings.HasPrefix(k, prefix) && v != "" {
+			return true
+		}
+	}
+	return false
+}
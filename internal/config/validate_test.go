@@ -0,0 +1,89 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"syl-listing-pro/internal/util"
+)
+
+func writeValidateEnv(t *testing.T, content string) {
+	t.Helper()
+	p := filepath.Join(t.TempDir(), ".env")
+	if err := os.WriteFile(p, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	util.SetEnvPath(p)
+	t.Cleanup(func() { util.SetEnvPath("") })
+}
+
+func TestValidateEnvFile_Clean(t *testing.T) {
+	writeValidateEnv(t, "# team\nSYL_LISTING_KEY=abc\nSYL_LISTING_KEY_shop2=def\nSYL_KEY_PROFILE=shop2\nSYL_TIMEOUT_SUBMIT=90s\nSYL_DEFAULT_OUT=out\n")
+	_, exists, problems, err := ValidateEnvFile(map[string]bool{"out": true})
+	if err != nil || !exists {
+		t.Fatalf("exists=%v err=%v", exists, err)
+	}
+	if len(problems) != 0 {
+		t.Fatalf("unexpected problems: %v", problems)
+	}
+}
+
+func TestValidateEnvFile_ReportsAllProblems(t *testing.T) {
+	writeValidateEnv(t, strings.Join([]string{
+		"SYL_LISTING_KEY=abc",
+		"not a pair",
+		"SYL_LISTING_KEY=dup",
+		"SYL_TIMEOUT_RESULT=2h",
+		"SYL_TIMEOUT_SUBMIT=fast",
+		"SYL_PUBLISH_ENDPOINT=oss-cn-hangzhou.aliyuncs.com",
+		"SYL_PUBLISH_BUCKET=b",
+		"SYL_LISTING_WORKER_URL=https://worker.example.test",
+		"SYL_DEFAULT_NO_SUCH_FLAG=1",
+		"SYL_LISTING_KEY_bad-name=x",
+		"SYL_KEY_PROFILE=missing",
+		"SYL_SPAPI_CLIENT_ID=id",
+		"SYL_TYPO=1",
+	}, "\n"))
+	_, _, problems, err := ValidateEnvFile(map[string]bool{"out": true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var text []string
+	for _, p := range problems {
+		text = append(text, p.String())
+	}
+	joined := strings.Join(text, "\n")
+	for _, want := range []string{
+		"第 2 行：无法解析",
+		"第 3 行 SYL_LISTING_KEY：与第 1 行重复",
+		"第 4 行 SYL_TIMEOUT_RESULT：超出范围",
+		"第 5 行 SYL_TIMEOUT_SUBMIT：不是有效时长",
+		"第 6 行 SYL_PUBLISH_ENDPOINT：不是有效的 http(s) URL",
+		"第 8 行 SYL_LISTING_WORKER_URL：只能通过进程环境变量设置",
+		"第 9 行 SYL_DEFAULT_NO_SUCH_FLAG：没有参数 --no-such-flag",
+		"第 10 行 SYL_LISTING_KEY_bad-name：配置名 bad-name 无效",
+		"第 11 行 SYL_KEY_PROFILE：选中的配置 missing 没有对应的 SYL_LISTING_KEY_missing",
+		"第 13 行 SYL_TYPO：未知配置项",
+		"SYL_PUBLISH_PROVIDER：已配置上传参数但缺少上传服务",
+		"SYL_PUBLISH_SECRET_ACCESS_KEY：已配置上传但缺少该项",
+		"SYL_SPAPI_REFRESH_TOKEN：已配置 SP-API 但缺少该项",
+	} {
+		if !strings.Contains(joined, want) {
+			t.Fatalf("missing problem %q in:\n%s", want, joined)
+		}
+	}
+	if !strings.HasPrefix(text[0], "第 2 行") || strings.HasPrefix(text[len(text)-1], "第") {
+		t.Fatalf("problems not ordered by line:\n%s", joined)
+	}
+}
+
+func TestValidateEnvFile_Missing(t *testing.T) {
+	util.SetEnvPath(filepath.Join(t.TempDir(), "none.env"))
+	defer util.SetEnvPath("")
+	_, exists, problems, err := ValidateEnvFile(nil)
+	if err != nil || exists || len(problems) != 0 {
+		t.Fatalf("exists=%v problems=%v err=%v", exists, problems, err)
+	}
+}
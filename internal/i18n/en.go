@@ -0,0 +1,600 @@
+package i18n
+
+var catalogs = map[Lang]map[string]string{
+	EN: en,
+}
+
+var en = map[string]string{
+	// 生成流程
+	"检测到中断，开始取消已提交任务（%d），再次中断可立即退出":         "Interrupted, cancelling submitted jobs (%d); interrupt again to exit immediately",
+	"运行已达 --timeout %s，开始取消已提交任务（%d）":       "Run reached --timeout %s, cancelling submitted jobs (%d)",
+	"运行超时（--timeout %s）：成功 %d，失败 %d，未完成 %d": "Run timed out (--timeout %s): %d succeeded, %d failed, %d unfinished",
+	"运行超过 --timeout %s，未完成的任务已取消":           "run exceeded --timeout %s, unfinished jobs were cancelled",
+	"--timeout 不能为负数：%s":                    "--timeout must not be negative: %s",
+	"配置文件：%s":                               "Config file: %s",
+	"配置文件：%s（不存在，使用默认配置）":                   "Config file: %s (missing, using defaults)",
+	"密钥文件不可读：%v":                            "Key file is not readable: %v",
+	"目录不可写：%s：%v":                           "Directory is not writable: %s: %v",
+	"配置检查通过":                                "Config check passed",
+	"[问题] %s":                               "[problem] %s",
+	"发现 %d 个配置问题":                           "Found %d config problems",
+	"%s 不是目录":                               "%s is not a directory",
+	"租户：%s":                                 "Tenant: %s",
+	"KEY 配置：%s":                             "KEY profile: %s",
+	"KEY：%s":                                "KEY: %s",
+	"服务端：%s":                                "Server: %s",
+	"令牌有效期至：%s（%s 后过期）":                     "Token valid until: %s (expires in %s)",
+	"规则版本：%s（最近一次运行）":                       "Rules version: %s (latest run)",
+	"本地目录：%s":                               "Local directory: %s",
+	"✓ KEY 有效：租户 %s（KEY 配置 %s）":             "✓ KEY is valid: tenant %s (KEY profile %s)",
+	"  服务端说明：%s":                            "  Server message: %s",
+	"  建议：%s":                               "  Suggestion: %s",
+	"认证检查未通过：%s":                            "Auth check failed: %s",
+	"KEY 无效或已被吊销":                           "KEY is invalid or revoked",
+	"确认 KEY 是否正确，重新执行 syl-listing-pro set key <SYL_LISTING_KEY>": "Check the KEY and run syl-listing-pro set key <SYL_LISTING_KEY> again",
+	"KEY 已过期":                           "KEY has expired",
+	"联系管理员续期或更换 KEY":                    "Ask your administrator to renew or replace the KEY",
+	"本机时间与服务端相差 %s":                     "Local clock differs from the server by %s",
+	"校准系统时间（开启 NTP 自动同步）后重试":            "Correct the system clock (enable NTP sync) and retry",
+	"本机时间与服务端不一致":                       "Local clock does not match the server",
+	"无法连接服务端 %s":                        "Cannot reach server %s",
+	"检查网络、代理设置或 SYL_LISTING_WORKER_URL": "Check the network, proxy settings or SYL_LISTING_WORKER_URL",
+	"服务端暂时不可用（HTTP %d）":                 "Server is temporarily unavailable (HTTP %d)",
+	"稍后重试":                              "Retry later",
+	"认证失败（HTTP %d）":                     "Authentication failed (HTTP %d)",
+	"使用 --verbose 查看完整请求与响应":            "Use --verbose to see the full request and response",
+	"认证失败":                              "Authentication failed",
+	"smoke 只接受一个需求文件：%s":                "smoke accepts a single requirement file: %s",
+	"换取令牌":                              "Token exchange",
+	"租户 %s":                             "tenant %s",
+	"提交任务":                              "Submit job",
+	"生成":                                "Generate",
+	"任务状态 %s：%s":                        "job status %s: %s",
+	"规则版本 %s":                           "rules version %s",
+	"读取结果":                              "Fetch result",
+	"结果为空":                              "result is empty",
+	"%d 字符":                             "%d characters",
+	"Word 转换":                           "Word conversion",
+	"[失败] %s（%s）：%v":                    "[fail] %s (%s): %v",
+	"冒烟测试在“%s”阶段失败":                     "Smoke test failed at stage \"%s\"",
+	"[通过] %s（%s）：%s":                    "[pass] %s (%s): %s",
+	"[通过] %s（%s）":                       "[pass] %s (%s)",
+	"全部 %d 个阶段通过，总耗时 %s":                "All %d stages passed in %s",
+	"未找到 listing 产物（文件名形如 xxx_en.md）":   "No listing outputs found (file names like xxx_en.md)",
+	"[通过] %s":                           "[pass] %s",
+	"共检查 %d 个文件，%d 个有问题":                "Checked %d files, %d with problems",
+	"%d 个文件未通过检查":                       "%d files failed the check",
+	"读取 %s 失败: %w":                      "failed to read %s: %w",
+	"缺少分节：%s":                           "missing section: %s",
+	"第 %d 条长度不满足约束：%s":                  "bullet %d length out of range: %s",
+	"服务端暂不支持查询租户信息":                     "The server does not support tenant info yet",
+	"%s（%s）":                            "%s (%s)",
+	"套餐：%s":                             "Plan: %s",
+	"并发任务上限：%d":                         "Max concurrent jobs: %d",
+	"排队任务上限：%d":                         "Max queued jobs: %d",
+	"提交速率上限：%d/min":                     "Max submit rate: %d/min",
+	"允许的候选数：%s":                         "Allowed candidate counts: %s",
+	"规则通道：%s":                           "Rules channel: %s",
+	"规则版本：%s":                           "Rules version: %s",
+	"可用站点：%s":                           "Marketplaces: %s",
+	"注意：页面中的 ASIN 为 %s，与指定的 %s 不一致":                                                                                   "Note: the page ASIN %s differs from the requested %s",
+	"未配置 SP-API 凭据（.env 中 SYL_SPAPI_CLIENT_ID、SYL_SPAPI_CLIENT_SECRET、SYL_SPAPI_REFRESH_TOKEN），可改用 --from <商品页.html>": "SP-API credentials are not configured (SYL_SPAPI_CLIENT_ID, SYL_SPAPI_CLIENT_SECRET, SYL_SPAPI_REFRESH_TOKEN in .env); use --from <product-page.html> instead",
+	"需求文件已存在：%s（可加 --force 覆盖）":                                                                                       "Requirement file already exists: %s (add --force to overwrite)",
+	"写需求文件失败: %w":              "failed to write requirement file: %w",
+	"已导入 %s：标题%s，五点 %d 条，描述%s": "Imported %s: title %s, %d bullets, description %s",
+	"需求文件：%s（填写“改写要求”后即可生成）":   "Requirement file: %s (fill in the rewrite requirements, then generate)",
+	"读取商品页失败: %w":              "failed to read product page: %w",
+	"无":                        "missing",
+	"有":                        "present",
+	"--route 不能与 --confirm 同时使用：各租户会同时在终端询问确认":    "--route cannot be combined with --confirm: every tenant would prompt on the terminal at once",
+	"运行中输入 p 并回车可暂停/恢复提交新任务":                      "Type p and press Enter while running to pause/resume submitting new jobs",
+	"已暂停提交新任务，已提交的任务继续跟踪；再次输入 p 回车或发送 SIGUSR1 恢复": "Paused submitting new jobs; submitted jobs keep being tracked. Type p and Enter again or send SIGUSR1 to resume",
+	"已恢复提交新任务":                    "Resumed submitting new jobs",
+	"--pick 不能与 --auto-pick 同时使用": "--pick cannot be combined with --auto-pick",
+	"候选 %d：%.1f":                  "candidate %d: %.1f",
+	"保存最佳候选失败：%v":                 "failed to save the best candidate: %v",
+	"%s 候选评分：%s":                  "%s candidate scores: %s",
+	"已自动选定候选 %d（%.1f 分）：%s":       "auto-selected candidate %d (score %.1f): %s",
+	"读取报告失败：%s：%v":                "failed to read report %s: %v",
+	"配置 SYL_DEFAULT_%s 无效：%v":     "Invalid config SYL_DEFAULT_%s: %v",
+	"--append 仅支持 .md 文件：%s":      "--append only supports .md files: %s",
+	"%s 追加到总目录失败：%v":              "%s Failed to append to catalog: %v",
+	"%s 已追加到总目录：%s":               "%s Appended to catalog: %s",
+	"运行记录不可用，跳过导出":                "Run record unavailable, skipping export",
+	"导出失败：%v":                     "Export failed: %v",
+	"没有成功的 listing，跳过导出":          "No successful listings, skipping export",
+	"eBay 标题超过 80 个字符已截断：%s":      "eBay titles longer than 80 characters were truncated: %s",
+	"导出 %s 失败：%v":                 "Export %s failed: %v",
+	"已导出 %s（%d 个商品）：%s":           "Exported %s (%d products): %s",
+	"预计：%d 个任务（暂无历史运行记录，无法估算耗时与用量）":                "Estimate: %d tasks (no run history yet, cannot estimate duration or usage)",
+	"预计：%d 个任务，约 %s，约 %.0f credits（依据最近 %d 个成功任务）": "Estimate: %d tasks, ~%s, ~%.0f credits (based on the last %d successful tasks)",
+	"预计：%d 个任务，约 %s（依据最近 %d 个成功任务）":                "Estimate: %d tasks, ~%s (based on the last %d successful tasks)",
+	"剩余额度：%.2f credits": "Remaining quota: %.2f credits",
+	"预计用量超过剩余额度，部分任务可能因额度不足失败": "Estimated usage exceeds the remaining quota; some tasks may fail for lack of credits",
+	"额度查询失败：%v": "Quota lookup failed: %v",
+	"--confirm 需要在终端中确认（标准输入不是终端）": "--confirm needs confirmation in a terminal (stdin is not a terminal)",
+	"确认提交 %d 个任务？[y/N]：":           "Submit %d tasks? [y/N]: ",
+	"未确认，已取消运行":                    "Not confirmed, run cancelled",
+	"服务端繁忙（%s），并发降至 %d":            "Server busy (%s), concurrency lowered to %d",
+	"服务端压力缓解，并发升至 %d":              "Server load eased, concurrency raised to %d",
+	"排队 %s": "queued %s",
+	"--submit-rate 格式应为 N/sec、N/min 或 N/hour，例如 10/min：%s": "--submit-rate must be N/sec, N/min or N/hour, e.g. 10/min: %s",
+	"--submit-window 格式应为 HH:MM-HH:MM，例如 00:00-06:00：%s":   "--submit-window must be HH:MM-HH:MM, e.g. 00:00-06:00: %s",
+	"%s 不在提交时段（--submit-window）内，等待至 %s 再提交":               "%s outside the submit window (--submit-window), waiting until %s",
+	"失败报告写入失败：%v":                                          "Failed to write failure report: %v",
+	"失败报告已写入（%d 个任务）：%s":                                   "Failure report written (%d tasks): %s",
+	"成功":    "OK",
+	"已取消":   "Cancelled",
+	"失败":    "Failed",
+	"任务":    "Task",
+	"状态":    "Status",
+	"耗时":    "Duration",
+	"产物/错误": "Output/Error",
+	"未完成":   "Unfinished",
+	"--route 暂不支持 --events-fd / --events-file": "--route does not support --events-fd / --events-file yet",
+	"--events-fd 与 --events-file 不能同时使用":       "--events-fd and --events-file cannot be used together",
+	"--events-fd 须大于 2（0、1、2 为标准输入输出）：%d":      "--events-fd must be greater than 2 (0, 1 and 2 are the standard streams): %d",
+	"打开事件文件失败: %w":                             "failed to open events file: %w",
+	"写标准输出失败: %w":                              "failed to write to stdout: %w",
+	"--stdout 只支持 en|cn|both: %s":              "--stdout only supports en|cn|both: %s",
+	"--stdout 不能与 --resume-last、requeue 同时使用":  "--stdout cannot be combined with --resume-last or requeue",
+	"--stdout 不能与 -n 大于 1、--pick、--auto-pick、--copy、--open、--combined、--search-terms、--format、--append 同时使用": "--stdout cannot be combined with -n greater than 1, --pick, --auto-pick, --copy, --open, --combined, --search-terms, --format or --append",
+	"--stdout 只用于单个需求文件，当前为 %d 个":                                                                            "--stdout only works with a single requirement file, got %d",
+	"%s Word 文档属性写入失败：%v":                                                                                    "%s failed to write Word document properties: %v",
+	"合并文档属性写入失败：%v":                                                                                          "Failed to write combined document properties: %v",
+	"--combined 仅支持 .md 或 .docx 文件：%s":                                                                       "--combined only supports .md or .docx files: %s",
+	"运行记录不可用，跳过合并文档":                                                                                         "Run record unavailable, skipping combined document",
+	"合并文档失败：%v":                                                                                              "Failed to write combined document: %v",
+	"没有成功的 listing，跳过合并文档":                                                                                   "No successful listings, skipping combined document",
+	"合并文档已写入（%d 个 listing）：%s":                                                                               "Combined document written (%d listings): %s",
+	"Listing 汇总": "Listings",
+	"目录":         "Contents",
+	"--normalize 不能同时使用 straight-quotes 与 curly-quotes":                              "--normalize cannot combine straight-quotes and curly-quotes",
+	"--normalize 只支持 %s: %s":                                                         "--normalize only supports %s: %s",
+	"%s 服务端未返回后台搜索词（可能不支持）":                                                          "%s server returned no backend search terms (possibly unsupported)",
+	"%s 后台搜索词超过 %d 字节，已舍弃：%s":                                                        "%s backend search terms exceed %d bytes, dropped: %s",
+	"写后台搜索词失败: %w":                                                                   "failed to write backend search terms: %w",
+	"%s 后台搜索词已写入（%d 字节）：%s":                                                          "%s backend search terms written (%d bytes): %s",
+	"关键词覆盖：%d 个任务缺失关键词，可考虑重新生成，详见同名 .json 报告":                                        "Keyword coverage: %d tasks are missing keywords, consider regenerating; see the matching .json reports",
+	"%s 关键词覆盖：%d/%d（%s）":                                                             "%s keyword coverage: %d/%d (%s)",
+	"%s 缺失关键词：%s":                                                                    "%s missing keywords: %s",
+	"未找到英文词典，跳过拼写检查（可用 --spell-dict 指定 hunspell .dic 或单词表）":                          "No English dictionary found, skipping spellcheck (use --spell-dict to point at a hunspell .dic or word list)",
+	"拼写检查：%d 个任务有疑似拼写错误，详见同名 .json 报告":                                               "Spellcheck: %d tasks have possible misspellings, see the matching .json reports",
+	"%s 疑似拼写错误：%s":                                                                   "%s possible misspellings: %s",
+	"--route 不能与 --resume-last、requeue、--record 同时使用":                                "--route cannot be combined with --resume-last, requeue or --record",
+	"--route 不能与 --pick、--auto-pick、--copy、--open、--combined、--format、--stdout 同时使用": "--route cannot be combined with --pick, --auto-pick, --copy, --open, --combined, --format or --stdout",
+	"路由：KEY 配置 %s 处理 %d 个需求文件，输出到 %s":                                                "Route: key profile %s handles %d requirement files, output to %s",
+	"KEY 配置 %s：%w": "key profile %s: %w",
+	"内部错误（panic）：%v（崩溃报告写入失败：%v）":      "internal error (panic): %v (failed to write crash report: %v)",
+	"内部错误（panic）：%v，崩溃报告：%s":           "internal error (panic): %v, crash report: %s",
+	"诊断包已生成：%s":                        "Diagnostics bundle written: %s",
+	"KEY 与令牌已隐去，提交前仍建议打开检查一遍":          "Keys and tokens are redacted; please still review the bundle before sending it",
+	"--pprof 监听失败：%v":                  "--pprof failed to listen: %v",
+	"pprof 已开启：http://%s/debug/pprof/": "pprof enabled: http://%s/debug/pprof/",
+	"创建 CPU 剖析文件失败：%v":                 "Failed to create CPU profile: %v",
+	"开启 CPU 剖析失败：%v":                   "Failed to start CPU profile: %v",
+	"写内存剖析失败：%v":                       "Failed to write memory profile: %v",
+	"--record 与 --replay 不能同时使用":       "--record and --replay cannot be used together",
+	"回放模式：API 请求由录制文件应答：%s":            "Replay mode: API requests are answered from the recording: %s",
+	"写录制文件失败：%v":                       "Failed to write recording: %v",
+	"API 交互已录制到：%s":                    "API interactions recorded to: %s",
+	"运行记录目录":                           "run records directory",
+	"日志目录":                             "log directory",
+	"%s不可写：%s：%v":                      "%s is not writable: %s: %v",
+	"%s所在磁盘空间不足：%s 仅剩 %s，至少需要 %s":      "not enough disk space for %s: %s has %s free, need at least %s",
+	"--%s-timeout 不能为负数：%s":            "--%s-timeout must not be negative: %s",
+	"再次中断，立即退出；已提交的任务可能仍在服务端运行，可用 history 查看本次运行": "Interrupted again, exiting now; submitted jobs may still be running on the server, see history for this run",
+	"%s 取消失败：%v":            "%s cancel failed: %v",
+	"%s 已取消（job_id=%s）":     "%s cancelled (job_id=%s)",
+	"%s 已提交取消请求（job_id=%s）": "%s cancel requested (job_id=%s)",
+	"取消完成：成功 %d，失败 %d":      "Cancel finished: %d succeeded, %d failed",
+	"%s 已取消":                "%s cancelled",
+	"%s 生成失败：%v":            "%s generation failed: %v",
+	"%s 生成失败：%s":            "%s generation failed: %s",
+	"第 %d/%d 轮重试：%d 个任务因可重试错误失败，重新处理":                          "Retry pass %d/%d: retrying %d tasks that failed with retryable errors",
+	"运行记录写入失败：%v":                                              "Failed to write run record: %v",
+	"各任务日志写入：%s":                                               "Per-task logs: %s",
+	"%s 任务日志创建失败：%v":                                           "%s failed to create task log: %v",
+	"取消等待超时，已退出":                                               "Timed out waiting for cancellation, exiting",
+	"任务完成：成功 %d，失败 %d，总耗时 %s":                                  "Done: %d succeeded, %d failed, total %s",
+	"本次用量：%s":                                                  "Run usage: %s",
+	"EN/CN 一致性：%d 个任务存在不一致，详见同名 .json 报告":                      "EN/CN consistency: %d tasks have mismatches, see the matching .json reports",
+	"合规检查：%d 个任务命中禁用词，详见同名 .json 报告":                           "Compliance: %d tasks contain banned words, see the matching .json reports",
+	"可执行 syl-listing-pro requeue %s 重新生成失败任务":                  "Run syl-listing-pro requeue %s to regenerate failed tasks",
+	"KEY 无效或已过期，可执行 syl-listing-pro auth check 查看原因":           "The KEY is invalid or expired, run syl-listing-pro auth check for details",
+	"额度已用完，可执行 syl-listing-pro quota 查看剩余额度":                   "Quota exhausted, run syl-listing-pro quota to check the remaining balance",
+	"服务端已找不到该任务（可能已过期清理），可用 requeue 重新生成":                      "The job no longer exists on the server (likely expired), use requeue to regenerate",
+	"--copy 只支持 en|cn: %s":                                     "--copy only supports en|cn: %s",
+	"未找到剪贴板工具（wl-copy、xclip 或 xsel）":                           "no clipboard tool found (wl-copy, xclip or xsel)",
+	"--copy / --open 只用于单个需求文件且生成成功的运行，已跳过":                    "--copy / --open only apply to a successful single-file run, skipped",
+	"复制到剪贴板失败：%v":                                              "Copy to clipboard failed: %v",
+	"已复制到剪贴板：%s":                                               "Copied to clipboard: %s",
+	"没有可打开的 Word 文件，已跳过 --open":                                "No Word file to open, skipped --open",
+	"打开文件失败：%v":                                                "Open file failed: %v",
+	"已打开：%s":                                                   "Opened: %s",
+	"存在失败任务":                                                   "some tasks failed",
+	"%s 继续跟踪已提交任务（job_id=%s）":                                  "%s resuming submitted job (job_id=%s)",
+	"%s 生成失败：SSE 超时":                                           "%s generation failed: SSE timed out",
+	"SSE 超时: %w":                                               "SSE timed out: %w",
+	"%s 过程流式接收失败：%v":                                           "%s trace stream failed: %v",
+	"%s 生成失败：读取结果失败: %v":                                       "%s generation failed: reading result failed: %v",
+	"读取结果失败: %w":                                               "reading result failed: %w",
+	"%s EN/CN 不一致：%s：%s":                                       "%s EN/CN mismatch: %s: %s",
+	"%s 校验报告：%s":                                               "%s validation report: %s",
+	"%s %s 字符数：%s":                                             "%s %s character counts: %s",
+	"%s 命中禁用词：%s":                                              "%s banned words found: %s",
+	"%s 生成失败：命中禁用词（--strict-compliance）":                       "%s generation failed: banned words found (--strict-compliance)",
+	"命中禁用词：%s":                                                 "banned words found: %s",
+	"%s 用量：%s":                                                 "%s usage: %s",
+	"%s 生成已取消":                                                 "%s generation cancelled",
+	"任务已被取消":                                                   "job was cancelled",
+	"%s 生成失败：SSE 未返回终态":                                        "%s generation failed: SSE ended without a final status",
+	"SSE 未返回终态":                                                "SSE ended without a final status",
+	"%s 输出已存在，跳过写入：%s":                                         "%s output exists, skipped: %s",
+	"输出文件名失败: %w":                                              "output file name failed: %w",
+	"写 %s 失败: %w":                                              "writing %s failed: %w",
+	"%s %s 已写入：%s":                                             "%s %s written: %s",
+	"%s Word 转换失败: %w":                                         "%s Word conversion failed: %w",
+	"%s %s Word 已写入：%s":                                        "%s %s Word written: %s",
+	"尚未配置 KEY，需要执行\nsyl-listing-pro set key <SYL_LISTING_KEY>": "KEY is not configured, run\nsyl-listing-pro set key <SYL_LISTING_KEY>",
+
+	// 运行计划
+	"--resume-last 会沿用上次运行的输入，不能再指定输入文件":                                           "--resume-last reuses the previous run's inputs; do not pass input files",
+	"requeue 会沿用原运行的输入，不能再指定输入文件":                                                  "requeue reuses the original run's inputs; do not pass input files",
+	"增量模式：%s 内容未变化，已跳过":                                                            "Incremental: %s unchanged, skipped",
+	"增量模式：没有新增或变更的需求文件":                                                            "Incremental: no new or changed input files",
+	"内容去重：%d 个任务与已有输入内容相同，将复用其结果":                                                  "Dedup: %d tasks share content with other inputs and will reuse their results",
+	"没有可续跑的运行记录":                                                                   "no run to resume",
+	"最近一次运行 %s 已全部完成，无需续跑":                                                         "Latest run %s is already complete, nothing to resume",
+	"续跑 %s：已完成 %d，待处理 %d":                                                          "Resuming %s: %d done, %d pending",
+	"运行 %s 没有失败任务，无需重新生成":                                                          "Run %s has no failed tasks, nothing to regenerate",
+	"重新生成运行 %s 的失败任务：%d 个":                                                         "Regenerating %[2]d failed tasks of run %[1]s",
+	"运行编号无效: %q":                                                                   "invalid run ID: %q",
+	"未找到运行记录: %s":                                                                  "run record not found: %s",
+	"读取输入失败: %w":                                                                   "reading input failed: %w",
+	"运行记录创建失败，本次运行不支持续跑：%v":                                                        "Failed to create run record, this run cannot be resumed: %v",
+	"运行记录写入失败（%s）：%v":                                                              "Failed to write run record (%s): %v",
+	"处理记录写入失败：%v":                                                                  "Failed to write ledger: %v",
+	"运行 %s 使用的是 KEY 配置 %s，当前为 %s；请加 --key-profile %s":                              "run %s used key profile %s, current is %s; add --key-profile %s",
+	"KEY 配置名只能包含字母、数字和下划线: %s":                                                     "key profile names may only contain letters, digits and underscores: %s",
+	"KEY 配置 %s 尚未配置 KEY，需要执行\nsyl-listing-pro set key --name %s <SYL_LISTING_KEY>": "key profile %s has no KEY, run\nsyl-listing-pro set key --name %s <SYL_LISTING_KEY>",
+	"已切换到 KEY 配置：%s":                                                               "Switched to key profile: %s",
+	"--sections 只支持 %s: %s":                                                        "--sections only supports %s: %s",
+	"%s 已按节重新生成：%s":                                                                "%s Regenerated sections: %s",
+	"%s 服务端不支持按节生成，返回的是完整 listing":                                                 "%s The server does not support per-section generation and returned a full listing",
+	"--marketplace 仅支持 %s：%s":                                                      "--marketplace only supports %s: %s",
+	"标准输入不是终端，跳过候选选择":                                                              "Stdin is not a terminal, skipping candidate selection",
+	"保存最终稿失败：%v":                                                                   "Failed to save the final listing: %v",
+	"已选定候选 %d：%s":                                                                  "Selected candidate %d: %s",
+	"需求文件：%s":                                                                      "Input: %s",
+	"首条五点：%s":                                                                      "First bullet: %s",
+	"字符数：标题 %d / 五点 %d / 描述 %d":                                                    "Chars: title %d / bullets %d / description %d",
+	"选择最终稿 [1-%d，回车跳过]：":                                                           "Pick the final listing [1-%d, Enter to skip]: ",
+	"无效选择：%s":                                                                      "Invalid choice: %s",
+
+	// 命令帮助
+	"生成双语 listing（新架构 CLI）": "Generate bilingual listings",
+	"生成 listing": "Generate listings",
+	"重新生成某次运行中失败的任务":            "Regenerate the failed tasks of a run",
+	"把已生成的 Markdown 转为 Word":    "Convert generated Markdown to Word",
+	"按分节比较两份生成的 listing":        "Compare two generated listings section by section",
+	"列出本地运行记录":                  "List local run records",
+	"显示某次运行的任务明细":               "Show the tasks of a run",
+	"按天或按周汇总生成情况":               "Summarize generations by day or week",
+	"查询剩余额度":                    "Show remaining quota",
+	"检查运行环境":                    "Check the local environment",
+	"输出 NDJSON 详细日志":            "Write detailed NDJSON logs",
+	"日志文件路径":                    "Log file path",
+	"输出目录":                      "Output directory",
+	"每个需求文件生成候选数量":              "Number of candidates per input file",
+	"显示版本信息":                    "Show version",
+	"界面语言：zh|en（默认按 LANG 环境变量）": "UI language: zh|en (defaults from LANG)",
+	"普通日志每行前加本地时间（HH:MM:SS）":    "Prefix log lines with local time (HH:MM:SS)",
+	"从标准输入读取 KEY，避免出现在 shell 历史和进程列表中": "Read the KEY from stdin to keep it out of shell history and process lists",
+	"显示当前 KEY 对应的租户与令牌信息":              "Show the tenant and token for the current KEY",
+	"认证相关检查": "Authentication checks",
+	"检查 KEY 能否换取令牌，并给出失败原因":                                "Check that the KEY can obtain a token and explain failures",
+	"切换默认使用的 KEY 配置":                                       "Switch the default key profile",
+	"本次使用的 KEY 配置（默认取 use 选中的配置）":                          "Key profile for this run (defaults to the one selected by use)",
+	"只重新生成指定分节：title,bullets,description（需服务端支持）":          "Regenerate only these sections: title,bullets,description (requires server support)",
+	"候选数大于 1 时，运行结束后在终端比较候选并选定最终稿（复制为 _final 文件）":          "With more than one candidate, compare them after the run and pick the final one (copied to _final files)",
+	"必须融入输出的 SEO 关键词，逗号分隔；与需求文件 frontmatter 的 keywords 合并": "SEO keywords that must appear in the output, comma-separated; merged with keywords from the input frontmatter",
+	"目标站点：us|de|fr|jp，决定输出语言对（默认由服务端决定）":                   "Target marketplace: us|de|fr|jp, selects the output language pair (server default if empty)",
+	"保存为指定名称的 KEY 配置（默认 default）":                          "Save under the named key profile (default: default)",
+	"使用 --stdin 时不要再在命令行传入 KEY":                            "do not pass KEY on the command line together with --stdin",
+	"读取标准输入失败: %w":                                         "reading stdin failed: %w",
+	"标准输入中没有 KEY":                                          "no KEY found on stdin",
+	"跳过 %d 个任务：输出已存在（--on-conflict skip）":                  "Skipped %d tasks: outputs already exist (--on-conflict skip)",
+	"已跳过":           "skipped",
+	"已存在：%s":        "exists: %s",
+	"任务失败，未返回错误信息":  "task failed without an error message",
+	"旧的失败报告删除失败：%v": "failed to remove the old failure report: %v",
+
+	// 命令简介与参数说明
+	"配置文件工具": "Config file tools",
+	"检查配置文件与本地状态目录，一次列出全部问题":                 "Check the config file and local state directory and list every problem at once",
+	"Word 中需要高亮的关键词，逗号分隔":                    "Keywords to highlight in Word, comma-separated",
+	"排查问题用的辅助命令":                             "Troubleshooting helpers",
+	"打包版本、脱敏配置、最近运行记录与日志，便于提交工单":             "Bundle the version, redacted config, recent runs and logs for a support ticket",
+	"只显示最近一段时间的运行，例如 7d、12h":                 "Only show runs within this period, e.g. 7d, 12h",
+	"只显示带有该运行标签的运行":                          "Only show runs with this run label",
+	"把亚马逊上已有的 listing 转为需求 Markdown 骨架，用于改写": "Turn an existing Amazon listing into a requirement Markdown skeleton for rewriting",
+	"从商品详情页 HTML 文件读取（- 为标准输入），不使用 SP-API":   "Read from a product page HTML file (- for stdin) instead of SP-API",
+	"覆盖已存在的 <ASIN>.md":                       "Overwrite an existing <ASIN>.md",
+	"配置文件路径（默认 ~/.syl-listing-pro/.env）；KEY 等配置从该文件读写，其中 SYL_DEFAULT_<参数名> 作为参数默认值，命令行优先": "Config file path (default ~/.syl-listing-pro/.env); KEY and other settings are read from and written to it, SYL_DEFAULT_<flag name> sets flag defaults, the command line wins",
+	"标准输出格式：text|json（json 把每条日志输出为 NDJSON，不需要 --verbose）":                                "Stdout format: text|json (json writes every log line as NDJSON without --verbose)",
+	"把本次运行的 API 请求与响应（已隐去 KEY 与令牌）录制到该 JSON 文件，便于反馈问题":                                    "Record this run's API requests and responses (KEY and tokens redacted) to this JSON file for bug reports",
+	"用 --record 录制的文件应答 API 请求，离线复现问题（不访问服务端）":                                            "Answer API requests from a --record file to reproduce problems offline (no server access)",
+	"每个任务的日志与 NDJSON 事件另写到该目录下 <run_id>/<文件名>_<序号>.log|.ndjson":                           "Also write each task's log and NDJSON events to <run_id>/<file name>_<index>.log|.ndjson under this directory",
+	"--verbose 日志中每个 HTTP 请求/响应体最多保留的字节数，0 表示不限制":                                         "Maximum bytes kept per HTTP request/response body in --verbose logs, 0 for no limit",
+	"--verbose 下同类高频追踪事件每 N 条记录 1 条（错误与警告总是记录）":                                           "With --verbose, keep 1 of every N frequent trace events of the same kind (errors and warnings are always kept)",
+	"内容相同的需求文件只提交一次，结果复用到各自输出":                                                            "Submit identical input files only once and reuse the result for each output",
+	"增量模式：跳过输出目录处理记录中内容未变化的需求文件":                                                          "Incremental mode: skip input files whose content is unchanged in the output directory's ledger",
+	"续跑最近一次运行：跳过已完成任务，重新接入已提交任务":                                                          "Resume the latest run: skip finished tasks and reattach to submitted jobs",
+	"整次运行的期限，例如 45m；到期取消已提交任务并输出已完成部分的汇总（默认不限制）":                                          "Deadline for the whole run, e.g. 45m; when reached, submitted jobs are cancelled and a summary of finished work is printed (no limit by default)",
+	"KEY 换取访问令牌的单次请求超时（默认 20s，或 .env 中 SYL_TIMEOUT_EXCHANGE）":                             "Per-request timeout for exchanging the KEY for a token (default 20s, or SYL_TIMEOUT_EXCHANGE in .env)",
+	"提交生成任务的单次请求超时（默认 120s，或 .env 中 SYL_TIMEOUT_SUBMIT）":                                  "Per-request timeout for submitting jobs (default 120s, or SYL_TIMEOUT_SUBMIT in .env)",
+	"拉取任务结果的单次请求超时（默认 120s，或 .env 中 SYL_TIMEOUT_RESULT）":                                  "Per-request timeout for fetching job results (default 120s, or SYL_TIMEOUT_RESULT in .env)",
+	"任务数达到 20 个时，先输出耗时、用量估算与剩余额度，在终端确认后再提交":                                               "With 20 or more tasks, print time, usage and remaining quota estimates and ask for confirmation before submitting",
+	"新任务提交速率上限，如 10/min（单位 sec|min|hour），均匀间隔提交以免超出租户配额":                                  "Submission rate limit for new jobs, e.g. 10/min (units sec|min|hour); submissions are spaced evenly to stay within the tenant quota",
+	"只在每天该时段内提交新任务，如 00:00-06:00（本地时间，可跨午夜）；时段外等待":                                        "Only submit new jobs within this daily window, e.g. 00:00-06:00 (local time, may cross midnight); wait outside it",
+	"首轮结束后对超时、5xx、网络抖动等可重试失败再补跑的轮数":                                                       "Extra passes after the first one for retryable failures such as timeouts, 5xx and network errors",
+	"跳过 Word 转换，只写 Markdown": "Skip Word conversion and only write Markdown",
+	"使用固定文件名，已存在时的处理：overwrite|skip|suffix（默认随机后缀命名）":                                   "Use fixed file names and handle existing files with overwrite|skip|suffix (random suffix names by default)",
+	"输出目录组织方式：flat|per-input|per-date":                                                  "Output directory layout: flat|per-input|per-date",
+	"Markdown 产物编码：utf8|utf8bom（部分 Windows 工具需要 BOM）":                                   "Markdown output encoding: utf8|utf8bom (some Windows tools need a BOM)",
+	"Markdown 产物换行符：lf|crlf":                                                            "Markdown output line endings: lf|crlf",
+	"每个任务成功后把主稿追加到已有的 Markdown 总目录（每个商品一个二级标题，写入时加文件锁）":                                 "After each successful task, append the main draft to an existing Markdown catalog (one level-2 heading per product, written under a file lock)",
+	"运行结束后额外导出批量导入文件，逗号分隔：shopify,ebay（写为 <输出目录>/<格式>_<run_id>.csv）":                    "After the run, also export bulk import files, comma-separated: shopify,ebay (written as <output dir>/<format>_<run_id>.csv)",
+	"在 Markdown 产物第一行写入来源注释 <!-- syl: job=... rules=... generated=... -->":              "Write a provenance comment <!-- syl: job=... rules=... generated=... --> on the first line of Markdown outputs",
+	"运行结束后把全部成功的 listing 合并为一份带目录的文档（.md 或 .docx）":                                      "After the run, combine all successful listings into one document with a table of contents (.md or .docx)",
+	"任务成功后把产物上传到 .env 中配置的对象存储（S3/OSS/GCS）":                                             "After each successful task, upload outputs to the object storage configured in .env (S3/OSS/GCS)",
+	"任务成功后执行的外部处理器命令，结果 JSON 写入其标准输入（可重复）":                                              "External processor command run after each successful task, with the result JSON on its stdin (repeatable)",
+	"逐节比较 EN/CN（分节、列表项、数值、高亮词），不一致写入汇总与同名 .json 报告":                                     "Compare EN/CN section by section (sections, list items, numbers, highlighted words) and report mismatches in the summary and a .json report of the same name",
+	"禁用词表文件，每行一个词（默认 ~/.syl-listing-pro/banned_words.txt，不存在则不检查）":                      "Banned words file, one word per line (default ~/.syl-listing-pro/banned_words.txt, skipped if missing)",
+	"命中禁用词的任务记为失败（产物仍会写出）":                                                              "Mark tasks with banned words as failed (outputs are still written)",
+	"对主稿英文做拼写检查，疑似拼错的词写入汇总与同名 .json 报告（品牌词加到 ~/.syl-listing-pro/dictionary.txt）":        "Spell-check the English main draft and report suspect words in the summary and a .json report of the same name (add brand words to ~/.syl-listing-pro/dictionary.txt)",
+	"英文词典：hunspell .dic 或每行一词的单词表（默认查找系统词典）":                                            "English dictionary: a hunspell .dic or a one-word-per-line list (system dictionary by default)",
+	"运行结束后在输出目录生成 report_<run_id>.html 运行报告":                                            "After the run, write a report_<run_id>.html run report to the output directory",
+	"彩色输出：auto|always|never（auto 遵循 NO_COLOR 并在非终端时关闭）":                                 "Colored output: auto|always|never (auto honors NO_COLOR and turns off when not a terminal)",
+	"写出前规范化 Markdown，逗号分隔：blank-lines,bullets,straight-quotes|curly-quotes,strip-emoji": "Normalize Markdown before writing, comma-separated: blank-lines,bullets,straight-quotes|curly-quotes,strip-emoji",
+	"额外生成后台搜索词，按 Amazon 249 字节上限写为 <文件名>_search_terms.txt（需服务端支持）":                      "Also generate backend search terms, written to <file name>_search_terms.txt within Amazon's 249-byte limit (requires server support)",
+	"随任务提交的品牌名；需求文件 frontmatter 的 brand 优先":                                             "Brand name sent with each job; brand in the input frontmatter wins",
+	"随任务提交的商品类目；需求文件 frontmatter 的 category 优先":                                         "Product category sent with each job; category in the input frontmatter wins",
+	"随任务提交的目标人群；需求文件 frontmatter 的 target_audience 优先":                                  "Target audience sent with each job; target_audience in the input frontmatter wins",
+	"随任务提交的核心卖点，逗号分隔；与需求文件 frontmatter 的 key_features 合并":                               "Key features sent with each job, comma-separated; merged with key_features from the input frontmatter",
+	"候选数大于 1 时，运行结束后按约束符合度、关键词覆盖、长度均衡给候选评分，把最高分复制为 _best 文件":                            "With more than one candidate, score candidates after the run by constraint compliance, keyword coverage and length balance, and copy the best to _best files",
+	"运行标签（如营销活动名），记录在运行记录与附带文件中，可在 history / stats 中用 --label 筛选":                       "Run label (e.g. a campaign name), stored in run records and sidecar files; filter with --label in history / stats",
+	"向该文件描述符输出带版本的 NDJSON 事件流（如 3），供 GUI 与包装脚本使用":                                       "Write a versioned NDJSON event stream to this file descriptor (e.g. 3) for GUIs and wrapper scripts",
+	"把带版本的 NDJSON 事件流写入该文件；与 --events-fd 二选一":                                           "Write the versioned NDJSON event stream to this file; mutually exclusive with --events-fd",
+	"单个需求文件时把结果 Markdown 输出到标准输出而不写文件：en|cn|both（日志改写到标准错误）":                            "With a single input file, print the result Markdown to stdout instead of writing files: en|cn|both (logs go to stderr)",
+	"单个需求文件生成成功后把 Markdown 复制到剪贴板：en|cn":                                                "With a single input file, copy the Markdown to the clipboard after success: en|cn",
+	"单个需求文件生成成功后用默认程序打开主稿 Word":                                                         "With a single input file, open the main Word draft in the default app after success",
+	"路由文件：每行“<路径或通配符> <KEY 配置名>”，按租户并发生成，产物写到 <输出目录>/<KEY 配置名>":                         "Routes file: one \"<path or glob> <key profile>\" per line; generates per tenant concurrently and writes outputs to <output dir>/<key profile>",
+	"在该地址提供 /debug/pprof/ 用于排查性能问题，例如 127.0.0.1:6060":                                   "Serve /debug/pprof/ on this address for performance debugging, e.g. 127.0.0.1:6060",
+	"把整个运行的 CPU 剖析写到该文件":                                                                "Write a CPU profile of the whole run to this file",
+	"退出前把堆内存剖析写到该文件":                                                                    "Write a heap profile to this file before exiting",
+	"设置配置":               "Set configuration",
+	"设置 SYL_LISTING_KEY": "Set SYL_LISTING_KEY",
+	"用内置小需求走一遍完整链路，逐阶段输出耗时": "Run a small built-in requirement through the full pipeline and print per-stage timings",
+	"汇总周期：day|week":       "Summary period: day|week",
+	"只统计最近一段时间的运行，例如 30d": "Only count runs within this period, e.g. 30d",
+	"只统计带有该运行标签的运行":       "Only count runs with this run label",
+	"租户相关查询":              "Tenant queries",
+	"查看服务端对当前租户的配置与限制（套餐、并发、候选数、规则通道）": "Show the server's settings and limits for the current tenant (plan, concurrency, candidates, rules channel)",
+	"检查已生成的 listing：必需分节、禁用词与长度规则":     "Check generated listings: required sections, banned words and length rules",
+
+	// 运行日志、报告与错误
+	"syl-listing-pro 版本：%s（commit: %s，构建时间: %s）": "syl-listing-pro version: %s (commit: %s, built: %s)",
+	"SP-API 授权失败: %w":                              "SP-API authorization failed: %w",
+	"SP-API 授权失败: 未返回 access_token":                "SP-API authorization failed: no access_token returned",
+	"读取商品 %s 失败: %w":                               "failed to read product %s: %w",
+	"%s 转换失败：%v":                                   "%s conversion failed: %v",
+	"%s 转换失败：不是 markdown 文件":                       "%s conversion failed: not a markdown file",
+	"Word 已写入：%s":                                  "Word written: %s",
+	"存在转换失败的文件":                                    "some files failed to convert",
+	"读取失败：%v\n":                                    "read failed: %v\n",
+	"系统：%s/%s\n":                                   "System: %s/%s\n",
+	"服务端：%s\n":                                     "Server: %s\n",
+	"生成时间：%s\n":                                    "Generated at: %s\n",
+	"Go：%s\n":                                      "Go: %s\n",
+	"\n# 环境变量":                                     "\n# Environment variables",
+	"执行 syl-listing-pro set key <SYL_LISTING_KEY>": "Run syl-listing-pro set key <SYL_LISTING_KEY>",
+	"先配置 KEY":                                      "Configure the KEY first",
+	"未配置 KEY，跳过":                                   "KEY not configured, skipped",
+	"检查网络、代理设置与 KEY 是否有效；可通过 SYL_LISTING_WORKER_URL 指定服务端": "Check the network, proxy settings and whether the KEY is valid; set the server with SYL_LISTING_WORKER_URL",
+	"%s（租户 %s）":            "%s (tenant %s)",
+	"PATH 中未找到 syl-md2doc": "syl-md2doc not found in PATH",
+	"执行失败: %w: %s":         "execution failed: %w: %s",
+	"清理磁盘或用 -o 换到空间充足的目录":  "Free up disk space or use -o to pick a directory with enough space",
+	"%s 仅剩 %s":             "%s has only %s left",
+	"%s（可用 %s）":            "%s (%s free)",
+	"[失败] %s：%v\n":         "[FAIL] %s: %v\n",
+	"       建议：%s\n":       "       Hint: %s\n",
+	"[通过] %s：%s\n":         "[PASS] %s: %s\n",
+	"[通过] %s\n":            "[PASS] %s\n",
+	"%d 项检查未通过":            "%d checks failed",
+	"KEY 已配置":              "KEY configured",
+	"服务端可连接":               "Server reachable",
+	"syl-md2doc 可用":        "syl-md2doc available",
+	"安装 syl-md2doc 并确保其在 PATH 中；只需要 Markdown 时可加 --skip-docx": "Install syl-md2doc and make sure it is in PATH; add --skip-docx if you only need Markdown",
+	"输出目录可写":                                  "Output directory writable",
+	"用 -o 指定有写权限的目录":                          "Use -o to pick a writable directory",
+	"syl-md2doc 执行失败: %w: %s":                 "syl-md2doc failed: %w: %s",
+	"syl-md2doc 未返回输出路径且目标文件不存在: %s":          "syl-md2doc returned no output path and the target file does not exist: %s",
+	"Word 输出文件名不一致，重命名失败: got=%s want=%s: %w": "Word output file name mismatch, rename failed: got=%s want=%s: %w",
+	"写 Word 属性失败: %w":                         "failed to write Word properties: %w",
+	"读取 Word 文件失败: %w":                        "failed to read Word file: %w",
+	"任务已加入队列 %s":                              "Job queued %s",
+	"任务已加入队列":                                 "Job queued",
+	"规则已加载 %s | worker %s":                    "Rules loaded %s | worker %s",
+	"规则已加载 %s":                                "Rules loaded %s",
+	"%s完成%s":                                  "%s done%s",
+	"%s已生成%s":                                 "%s generated%s",
+	"%s逐句生成（第%d/%d句）完成%s":                     "%s sentence %d/%d generated%s",
+	"%s逐句生成完成%s":                              "%s sentences generated%s",
+	"%s逐句校验失败（第%d/%d句）：%s":                    "%s sentence %d/%d failed validation: %s",
+	"%s逐句校验失败：%s":                             "%s sentence validation failed: %s",
+	"%s%s生成完成%s":                              "%s%s generated%s",
+	"%s 候选评分：%s，已选 #%d%s":                     "%s candidate scores: %s, picked #%d%s",
+	"%s 候选评分：%s%s":                            "%s candidate scores: %s%s",
+	"任务重试计划：第 %d/%d 次失败，准备第 %d 次（等待由队列退避控制）：%s": "Job retry plan: attempt %d/%d failed, preparing attempt %d (wait set by queue backoff): %s",
+	"执行完成%s":                   "Execution done%s",
+	"执行失败：%s":                  "Execution failed: %s",
+	"取消请求已提交":                  "Cancel request submitted",
+	"任务已取消":                    "Job cancelled",
+	"生成阶段完成%s":                 "Generation stage done%s",
+	"%s规则校验失败：%s":              "%s rule validation failed: %s",
+	"%s修复完成":                   "%s repaired",
+	"%s失败：%s":                  "%s failed: %s",
+	"%s失败":                     "%s failed",
+	"%s：%s":                    "%s: %s",
+	"任务步骤":                     "Job step",
+	"%s一致性修复（第%d轮）":            "%s consistency repair (round %d)",
+	"%s翻译":                     "%s translation",
+	"%s整段修复":                   "%s full repair",
+	"标题":                       "Title",
+	"五点描述":                     "Bullet points",
+	"产品描述":                     "Description",
+	"步骤":                       "Step",
+	"事件":                       "Event",
+	" [候选#%d] ":                " [candidate #%d] ",
+	"#%d失败(%s)":                "#%d failed (%s)",
+	"未知错误":                     "unknown error",
+	"第%s条长度不足: %d<%d":          "item %s too short: %d<%d",
+	"第%s条长度超限: %d>%d":          "item %s too long: %d>%d",
+	"长度不足: %d<%d":              "too short: %d<%d",
+	"长度超限: %d>%d":              "too long: %d>%d",
+	"关键词顺序错误: 第%s个 %s":         "keyword order wrong: #%s %s",
+	"1条":                       "1 item",
+	"%d条":                      "%d items",
+	"%s；...（其余%d条）":            "%s; ... (%d more)",
+	"tokens %d（输入 %d / 输出 %d）": "tokens %d (input %d / output %d)",
+	"，credits %.2f":            ", credits %.2f",
+	"第%s条长度不满足约束: %s":          "item %s violates length constraints: %s",
+	"长度不满足约束: %s":              "violates length constraints: %s",
+	"%d < [%d[%d,%d]%d] 低于下限":  "%d < [%d[%d,%d]%d] below the minimum",
+	"[%d[%d,%d]%d] < %d 高于上限":  "[%d[%d,%d]%d] < %d above the maximum",
+	"--since 格式无效: %s":         "invalid --since: %s",
+	"没有运行记录":                   "No run records",
+	"%s  开始 %s  耗时 %s  任务 %d：成功 %d，失败 %d，取消 %d，未完成 %d": "%s  started %s  took %s  tasks %d: %d succeeded, %d failed, %d cancelled, %d unfinished",
+	"  标签 %s": "  label %s",
+	"运行编号：%s\n开始：%s\n结束：%s\n输出目录：%s\n": "Run ID: %s\nStarted: %s\nFinished: %s\nOutput directory: %s\n",
+	"标签：%s\n":                          "Label: %s\n",
+	"\n[%s] %s\n  输入：%s\n":             "\n[%s] %s\n  Input: %s\n",
+	"  耗时：%s\n":                        "  Duration: %s\n",
+	"  用量：tokens %d，credits %.2f\n":    "  Usage: tokens %d, credits %.2f\n",
+	"  产物：%s\n":                        "  Output: %s\n",
+	"  最终稿：%s\n":                       "  Final: %s\n",
+	"  错误：%s\n":                        "  Error: %s\n",
+	"  job_id：%s\n":                    "  job_id: %s\n",
+	"（已选定）":                            " (selected)",
+	"--color 仅支持 auto、always、never：%s": "--color only supports auto, always, never: %s",
+	"--log-format 仅支持 text、json：%s":    "--log-format only supports text, json: %s",
+	"%s 处理器 %s 失败：%v":                  "%s processor %s failed: %v",
+	"尚未配置上传，需要在 ~/.syl-listing-pro/.env 中设置 SYL_PUBLISH_PROVIDER 等参数": "upload is not configured; set SYL_PUBLISH_PROVIDER and related settings in ~/.syl-listing-pro/.env",
+	"%s 上传失败：%s：%v":            "%s upload failed: %s: %v",
+	"%s 已上传：%s":                "%s uploaded: %s",
+	"服务端暂不支持额度查询":              "the server does not support quota queries yet",
+	"租户：%s\n":                  "Tenant: %s\n",
+	"剩余 credits：%.2f / %.2f\n": "Remaining credits: %.2f / %.2f\n",
+	"剩余 credits：%.2f\n":        "Remaining credits: %.2f\n",
+	"剩余 tokens：%d\n":           "Remaining tokens: %d\n",
+	"重置时间：%s\n":                "Resets at: %s\n",
+	"写报告失败: %w":                "failed to write report: %w",
+	"运行记录不可用，跳过 HTML 报告":       "Run record unavailable, skipping HTML report",
+	"HTML 报告写入失败：%v":           "Failed to write HTML report: %v",
+	"HTML 报告已写入：%s":            "HTML report written: %s",
+	"--by 仅支持 day、week：%s":     "--by only supports day, week: %s",
+	"周期        生成  成功  成功率":    "Period      Runs  OK    Rate",
+	"\n分节平均耗时：":                "\nAverage time per section:",
+	"  %s：%s（%d 次）\n":          "  %s: %s (%d times)\n",
+	"\n常见校验问题：":                "\nCommon validation problems:",
+	"  %d 次  %s\n":             "  %d times  %s\n",
+	"A：%s\nB：%s\n":             "A: %s\nB: %s\n",
+	"未开始录制":                    "recording has not started",
+	"创建录制目录失败: %w":             "failed to create recording directory: %w",
+	"写录制文件失败: %w":              "failed to write recording file: %w",
+	"读取录制文件失败: %w":             "failed to read recording file: %w",
+	"解析录制文件失败: %w":             "failed to parse recording file: %w",
+	"不支持的录制文件版本: %d":           "unsupported recording file version: %d",
+	"录制文件中没有匹配的请求: %s %s":      "no matching request in recording file: %s %s",
+	"解析 SSE trace 失败: %w":      "failed to parse SSE trace: %w",
+	"解析 SSE status 失败: %w":     "failed to parse SSE status: %w",
+	"解析响应失败: %w":               "failed to parse response: %w",
+	"读取禁用词表失败: %w":             "failed to read banned words file: %w",
+	"读取 .env 失败: %w":           "failed to read .env: %w",
+	"创建配置目录失败: %w":             "failed to create config directory: %w",
+	"写 .env 失败: %w":            "failed to write .env: %w",
+	"读取 KEY 文件失败: %w":          "failed to read KEY file: %w",
+	"KEY 文件为空: %s":             "KEY file is empty: %s",
+	"读取路由文件失败: %w":             "failed to read routes file: %w",
+	"路由文件第 %d 行格式应为“<路径或通配符> <KEY 配置名>”: %s": "routes file line %d should be \"<path or glob> <key profile>\": %s",
+	"路由文件第 %d 行: %w":          "routes file line %d: %w",
+	"路由文件第 %d 行通配符无效: %s":     "routes file line %d has an invalid glob: %s",
+	"路由文件没有任何规则: %s":          "routes file has no rules: %s",
+	"读取拼写词典失败: %w":            "failed to read spelling dictionary: %w",
+	"读取用户词典失败: %w":            "failed to read user dictionary: %w",
+	"%s 不是有效时长: %s":           "%s is not a valid duration: %s",
+	"第 %d 行 %s：%s":            "line %d %s: %s",
+	"第 %d 行：%s":               "line %d: %s",
+	"不是有效的 http(s) URL：%s":    "not a valid http(s) URL: %s",
+	"无法解析，应为 KEY=VALUE":       "cannot parse, expected KEY=VALUE",
+	"与第 %d 行重复，只有第一处生效":       "duplicates line %d, only the first one takes effect",
+	"只能通过进程环境变量设置，写在配置文件中不生效": "can only be set as a process environment variable, has no effect in the config file",
+	"配置名 %s 无效：只能包含字母、数字和下划线，且不能以 _FILE 结尾": "invalid profile name %s: only letters, digits and underscores, and must not end with _FILE",
+	"没有参数 --%s":                                   "there is no flag --%s",
+	"未知配置项":                                       "unknown setting",
+	"配置名 %s 无效":                                   "invalid profile name %s",
+	"选中的配置 %s 没有对应的 %s":                           "the selected profile %s has no matching %s",
+	"不是有效时长（如 15s、3m）：%s":                         "not a valid duration (e.g. 15s, 3m): %s",
+	"超出范围 %s～%s：%s":                               "out of range %s–%s: %s",
+	"已配置上传参数但缺少上传服务（s3、oss、gcs）":                  "upload settings are present but the upload provider (s3, oss, gcs) is missing",
+	"仅支持 s3、oss、gcs：%s":                           "only s3, oss, gcs are supported: %s",
+	"已配置上传但缺少该项":                                  "upload is configured but this setting is missing",
+	"应为 true 或 false：%s":                          "should be true or false: %s",
+	"已配置 SP-API 但缺少该项":                            "SP-API is configured but this setting is missing",
+	"未发现 markdown 输入文件":                           "no markdown input files found",
+	"读取处理记录失败: %w":                                "failed to read ledger: %w",
+	"解析处理记录失败: %s: %w":                            "failed to parse ledger: %s: %w",
+	"创建处理记录目录失败: %w":                              "failed to create ledger directory: %w",
+	"写处理记录失败: %w":                                 "failed to write ledger: %w",
+	"全文":                                          "Whole listing",
+	"分节数量不一致：EN %d，CN %d":                         "section count differs: EN %d, CN %d",
+	"第 %d 节":                                      "Section %d",
+	"分节类型不一致：EN %s，CN %s（%s）":                     "section kind differs: EN %s, CN %s (%s)",
+	"列表项数量不一致：EN %d，CN %d":                        "list item count differs: EN %d, CN %d",
+	"数值不一致：仅 EN [%s]，仅 CN [%s]":                   "numbers differ: EN only [%s], CN only [%s]",
+	"高亮词数量不一致：EN %d，CN %d":                        "highlighted word count differs: EN %d, CN %d",
+	"（无标题）":                                       "(untitled)",
+	"== %s：仅 A 存在（%d 字符）\n":                       "== %s: only in A (%d chars)\n",
+	"== %s：仅 B 存在（%d 字符）\n":                       "== %s: only in B (%d chars)\n",
+	"== %s：%d → %d 字符（%+d）\n":                     "== %s: %d → %d chars (%+d)\n",
+	"   相同":                                       "   identical",
+	"   %s（%d 字符）\n":                              "   %s (%d chars)\n",
+	"读取运行记录失败: %w":                                "failed to read run record: %w",
+	"解析运行记录失败: %s: %w":                            "failed to parse run record: %s: %w",
+	"读取运行记录目录失败: %w":                              "failed to read run records directory: %w",
+	"创建运行记录目录失败: %w":                              "failed to create run records directory: %w",
+	"运行记录中不存在任务: %s":                              "no such task in the run record: %s",
+	"写运行记录失败: %w":                                 "failed to write run record: %w",
+	"锁定 %s 失败：%w":                                 "failed to lock %s: %w",
+	"--on-conflict 仅支持 overwrite、skip、suffix：%s":  "--on-conflict only supports overwrite, skip, suffix: %s",
+	"生成唯一文件名失败":                                   "failed to generate a unique file name",
+	"未知冲突策略：%s":                                   "unknown conflict policy: %s",
+	"--out-encoding 仅支持 utf8、utf8bom：%s":          "--out-encoding only supports utf8, utf8bom: %s",
+	"--out-newlines 仅支持 lf、crlf：%s":               "--out-newlines only supports lf, crlf: %s",
+	"--format 仅支持 shopify、ebay：%s":                "--format only supports shopify, ebay: %s",
+	"--out-layout 仅支持 flat、per-input、per-date：%s": "--out-layout only supports flat, per-input, per-date: %s",
+	"随机长度必须大于0":                                   "random length must be greater than 0",
+	"处理器命令为空":                                     "processor command is empty",
+	"未配置上传 bucket":                                "upload bucket is not configured",
+	"未配置上传凭证":                                     "upload credentials are not configured",
+	"不支持的上传服务：%s（仅支持 s3、oss、gcs）":                 "unsupported upload provider: %s (only s3, oss, gcs)",
+	"上传 endpoint 无效：%s":                           "invalid upload endpoint: %s",
+	"读取待上传文件失败: %w":                               "failed to read file to upload: %w",
+	"上传失败: HTTP %d %s":                            "upload failed: HTTP %d %s",
+	"解析 %s 失败: %w":                                "failed to parse %s: %w",
+	"读取用户目录失败: %w":                                "failed to read home directory: %w",
+	"--lang 仅支持 zh、en：%s":                         "--lang only supports zh, en: %s",
+}
@@ -0,0 +1,90 @@
+// Package i18n 提供命令行输出的多语言支持。
+//
+// 消息以中文格式串作为键：T("任务完成：成功 %d", n) 在中文下原样格式化，
+// 在其他语言下查找对应译文，没有译文时回退为中文，因此可以逐步补充翻译。
+package i18n
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync/atomic"
+)
+
+type Lang string
+
+const (
+	ZH Lang = "zh"
+	EN Lang = "en"
+)
+
+var current atomic.Value
+
+func init() {
+	current.Store(ZH)
+}
+
+// Parse 解析 --lang 取值；空值表示按环境变量检测。
+func Parse(s string) (Lang, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "":
+		return Detect(), nil
+	case "zh", "zh-cn", "cn":
+		return ZH, nil
+	case "en", "en-us":
+		return EN, nil
+	}
+	return "", Errorf("--lang 仅支持 zh、en：%s", s)
+}
+
+// Detect 依次读取 LC_ALL、LC_MESSAGES、LANG，以 en 开头时使用英文，否则使用中文。
+func Detect() Lang {
+	for _, name := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		v := strings.ToLower(strings.TrimSpace(os.Getenv(name)))
+		if v == "" || v == "c" || v == "posix" {
+			continue
+		}
+		if strings.HasPrefix(v, "en") {
+			return EN
+		}
+		return ZH
+	}
+	return ZH
+}
+
+func Set(l Lang) {
+	current.Store(l)
+}
+
+func Current() Lang {
+	return current.Load().(Lang)
+}
+
+// T 翻译并格式化消息。
+func T(format string, args ...any) string {
+	if Current() != ZH {
+		if msg, ok := catalogs[Current()][format]; ok {
+			format = msg
+		}
+	}
+	if len(args) == 0 {
+		return format
+	}
+	return fmt.Sprintf(format, args...)
+}
+
+// Errorf 同 fmt.Errorf，但先翻译格式串；支持 %w。
+func Errorf(format string, args ...any) error {
+	if Current() != ZH {
+		if msg, ok := catalogs[Current()][format]; ok {
+			format = msg
+		}
+	}
+	return fmt.Errorf(format, args...)
+}
+
+// New 同 errors.New，但先翻译消息。
+func New(msg string) error {
+	return errors.New(T(msg))
+}
@@ -0,0 +1,215 @@
+package i18n
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"unicode"
+)
+
+func TestTAndErrorf(t *testing.T) {
+	defer Set(ZH)
+
+	Set(ZH)
+	if got := T("任务完成：成功 %d，失败 %d，总耗时 %s", 1, 0, "1s"); got != "任务完成：成功 1，失败 0，总耗时 1s" {
+		t.Fatalf("zh T=%q", got)
+	}
+	Set(EN)
+	if got := T("任务完成：成功 %d，失败 %d，总耗时 %s", 1, 0, "1s"); got != "Done: 1 succeeded, 0 failed, total 1s" {
+		t.Fatalf("en T=%q", got)
+	}
+	if got := T("没有翻译的消息 %d", 3); got != "没有翻译的消息 3" {
+		t.Fatalf("fallback T=%q", got)
+	}
+	base := errors.New("boom")
+	err := Errorf("读取结果失败: %w", base)
+	if !errors.Is(err, base) || !strings.HasPrefix(err.Error(), "reading result failed") {
+		t.Fatalf("en Errorf=%v", err)
+	}
+	if New("存在失败任务").Error() != "some tasks failed" {
+		t.Fatal("en New not translated")
+	}
+}
+
+func TestParseAndDetect(t *testing.T) {
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_MESSAGES", "")
+	t.Setenv("LANG", "en_US.UTF-8")
+	if l, err := Parse(""); err != nil || l != EN {
+		t.Fatalf("Parse(\"\")=%v,%v want en", l, err)
+	}
+	t.Setenv("LANG", "zh_CN.UTF-8")
+	if Detect() != ZH {
+		t.Fatal("expected zh from LANG")
+	}
+	t.Setenv("LANG", "C")
+	if Detect() != ZH {
+		t.Fatal("expected zh default")
+	}
+	if l, _ := Parse("EN"); l != EN {
+		t.Fatal("Parse(EN) != en")
+	}
+	if _, err := Parse("fr"); err == nil {
+		t.Fatal("expected unsupported lang error")
+	}
+}
+
+func TestCatalogFormatsMatch(t *testing.T) {
+	for zh, en := range en {
+		if strings.Count(zh, "%") != strings.Count(en, "%") {
+			t.Fatalf("verb count mismatch: %q -> %q", zh, en)
+		}
+	}
+}
+
+// outputFuncs 为直接输出或构造用户可见消息的函数；传给它们的中文字面量必须先经过 T / Errorf / New。
+var outputFuncs = map[string]bool{
+	"fmt.Errorf": true, "errors.New": true, "fmt.Sprintf": true, "fmt.Sprint": true,
+	"fmt.Fprintf": true, "fmt.Fprintln": true, "fmt.Fprint": true, "fmt.Printf": true, "fmt.Println": true,
+	"Info": true, "WriteString": true,
+}
+
+// untranslatedFiles 中的中文是发给服务端或写入需求文件的内容，不是界面消息。
+var untranslatedFiles = map[string]bool{
+	// import-asin 生成的需求文件骨架，服务端按中文模板识别。
+	"internal/amazon/amazon.go": true,
+}
+
+// TestCatalogCoversSource 扫描源码：T / Errorf / New 的中文格式串都要有英文译文，
+// 输出函数与 return 语句中不能直接出现未翻译的中文字面量，命令简介与参数说明也要有译文。
+func TestCatalogCoversSource(t *testing.T) {
+	root := filepath.Join("..", "..")
+	fset := token.NewFileSet()
+	var problems []string
+	report := func(pos token.Pos, format string, args ...any) {
+		problems = append(problems, fset.Position(pos).String()+": "+fmt.Sprintf(format, args...))
+	}
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(root, path)
+		rel = filepath.ToSlash(rel)
+		if d.IsDir() {
+			if rel == "internal/i18n" || (path != root && strings.HasPrefix(d.Name(), ".")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") || untranslatedFiles[rel] {
+			return nil
+		}
+		f, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			return err
+		}
+		checkFile(f, strings.HasPrefix(rel, "cmd/"), report)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(problems) > 0 {
+		t.Fatalf("%d messages missing from the catalog:\n%s", len(problems), strings.Join(problems, "\n"))
+	}
+}
+
+func checkFile(f *ast.File, isCmd bool, report func(token.Pos, string, ...any)) {
+	// 命令简介：cobra.Command{Short: "..."}。
+	ast.Inspect(f, func(n ast.Node) bool {
+		kv, ok := n.(*ast.KeyValueExpr)
+		if !ok || !isCmd {
+			return true
+		}
+		if key, ok := kv.Key.(*ast.Ident); ok && key.Name == "Short" {
+			if s, ok := hanLiteral(kv.Value); ok {
+				if _, found := en[s]; !found {
+					report(kv.Pos(), "Short %q has no translation", s)
+				}
+			}
+		}
+		return true
+	})
+	for _, decl := range f.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Body == nil {
+			continue
+		}
+		ast.Inspect(fn.Body, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.ReturnStmt:
+				for _, r := range n.Results {
+					if s, ok := hanLiteral(r); ok {
+						report(r.Pos(), "returns untranslated %q", s)
+					}
+				}
+			case *ast.CallExpr:
+				name := callName(n.Fun)
+				switch {
+				case name == "i18n.T" || name == "i18n.Errorf" || name == "i18n.New":
+					if len(n.Args) > 0 {
+						if s, ok := hanLiteral(n.Args[0]); ok {
+							if _, found := en[s]; !found {
+								report(n.Pos(), "%s(%q) has no translation", name, s)
+							}
+						}
+					}
+					return true
+				case isCmd && len(n.Args) > 0 && (strings.HasSuffix(name, "Var") || strings.HasSuffix(name, "VarP")):
+					// 参数说明在 cmd 的 localizeHelp 中统一翻译。
+					if s, ok := hanLiteral(n.Args[len(n.Args)-1]); ok {
+						if _, found := en[s]; !found {
+							report(n.Pos(), "flag usage %q has no translation", s)
+						}
+					}
+					return true
+				case outputFuncs[name] || outputFuncs[strings.TrimPrefix(name, "?.")]:
+					for _, a := range n.Args {
+						if s, ok := hanLiteral(a); ok {
+							report(a.Pos(), "%s with untranslated %q", name, s)
+						}
+					}
+				}
+			}
+			return true
+		})
+	}
+}
+
+func callName(fun ast.Expr) string {
+	switch fn := fun.(type) {
+	case *ast.SelectorExpr:
+		if x, ok := fn.X.(*ast.Ident); ok && (x.Name == "fmt" || x.Name == "errors" || x.Name == "i18n") {
+			return x.Name + "." + fn.Sel.Name
+		}
+		return "?." + fn.Sel.Name
+	case *ast.Ident:
+		return fn.Name
+	}
+	return ""
+}
+
+// hanLiteral 返回含中文的字符串字面量。
+func hanLiteral(e ast.Expr) (string, bool) {
+	lit, ok := e.(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return "", false
+	}
+	s, err := strconv.Unquote(lit.Value)
+	if err != nil {
+		return "", false
+	}
+	for _, r := range s {
+		if unicode.Is(unicode.Han, r) {
+			return s, true
+		}
+	}
+	return "", false
+}
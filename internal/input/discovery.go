@@ -1,11 +1,14 @@
 package input
 
 import (
-	"fmt"
+	"crypto/sha256"
+	"encoding/hex"
 	"os"
 	"path/filepath"
 	"regexp"
 	"strings"
+
+	"syl-listing-pro/internal/i18n"
 )
 
 type RequirementFile struct {
@@ -13,11 +16,84 @@ type RequirementFile struct {
 	Content string
 }
 
-var generatedOutputMarkdownPattern = regexp.MustCompile(`(?i)_[a-z0-9]{4}_((en)|(cn))\.(md|markdown)$`)
+// ContentHash 返回需求内容的 SHA-256（hex），用于识别内容完全相同的输入。
+func (f RequirementFile) ContentHash() string {
+	sum := sha256.Sum256([]byte(f.Content))
+	return hex.EncodeToString(sum[:])
+}
+
+// outputLangPattern 与 output.NewLangs 接受的语言代码一致：服务端返回的任意 2~3 个字母。
+const outputLangPattern = `[a-z]{2,3}`
+
+// langSuffixMarkdownPattern 拆出 <stem>_<语言>.md 的 stem 与语言，stem 即产物的 <base>[_<id>]，
+// 与附带文件 <stem>.json 同名。
+var langSuffixMarkdownPattern = regexp.MustCompile(`(?i)^(.+)_(` + outputLangPattern + `)\.(md|markdown)$`)
+
+// commonOutputLangs 为本工具常写出的语言后缀；没有附带文件时，只有两个后缀都在其中的成对文件才视为产物，
+// 避免把 desk_lamp_led.md、mat_red.md / mat_tan.md 这类普通文件名误判为产物。
+var commonOutputLangs = map[string]bool{"en": true, "cn": true, "zh": true, "de": true, "fr": true, "ja": true, "jp": true, "es": true, "it": true}
+
+// outputPairs 缓存每个目录的文件名信息，用于识别本工具写出的产物。
+type outputPairs map[string]*dirOutputs
+
+type dirOutputs struct {
+	// langs 按 stem 汇总语言后缀。
+	langs map[string]map[string]bool
+	// sidecars 为目录中 JSON 文件的 stem。
+	sidecars map[string]bool
+}
+
+// isGeneratedOutput 判断 path 是否为本工具写出的产物，需要有实际证据：同目录下存在 <stem>.json 附带文件，
+// 或者语言后缀为常见产物语言且存在 stem 相同、另一常见语言后缀的 Markdown（如 foo_en.md 与 foo_cn.md）。
+func (p outputPairs) isGeneratedOutput(path string) bool {
+	m := langSuffixMarkdownPattern.FindStringSubmatch(filepath.Base(path))
+	if m == nil {
+		return false
+	}
+	dir := filepath.Dir(path)
+	d, ok := p[dir]
+	if !ok {
+		d = &dirOutputs{langs: map[string]map[string]bool{}, sidecars: map[string]bool{}}
+		entries, _ := os.ReadDir(dir)
+		for _, e := range entries {
+			if e.IsDir() {
+				continue
+			}
+			name := strings.ToLower(e.Name())
+			if strings.HasSuffix(name, ".json") {
+				d.sidecars[strings.TrimSuffix(name, ".json")] = true
+				continue
+			}
+			sm := langSuffixMarkdownPattern.FindStringSubmatch(name)
+			if sm == nil {
+				continue
+			}
+			if d.langs[sm[1]] == nil {
+				d.langs[sm[1]] = map[string]bool{}
+			}
+			d.langs[sm[1]][sm[2]] = true
+		}
+		p[dir] = d
+	}
+	stem, lang := strings.ToLower(m[1]), strings.ToLower(m[2])
+	if d.sidecars[stem] {
+		return true
+	}
+	if !commonOutputLangs[lang] {
+		return false
+	}
+	for other := range d.langs[stem] {
+		if other != lang && commonOutputLangs[other] {
+			return true
+		}
+	}
+	return false
+}
 
 func Discover(inputs []string) ([]RequirementFile, error) {
 	var out []RequirementFile
 	seen := map[string]struct{}{}
+	pairs := outputPairs{}
 	for _, in := range inputs {
 		info, err := os.Stat(in)
 		if err != nil {
@@ -34,7 +110,7 @@ func Discover(inputs []string) ([]RequirementFile, error) {
 					}
 					return nil
 				}
-				if !shouldIncludeMarkdownFile(path, d.Name()) {
+				if !shouldIncludeMarkdownFile(path, d.Name()) || pairs.isGeneratedOutput(path) {
 					return nil
 				}
 				return appendRequirementFile(path, seen, &out)
@@ -44,6 +120,7 @@ func Discover(inputs []string) ([]RequirementFile, error) {
 			}
 			continue
 		}
+		// 命令行上明确指定的文件不做产物识别，始终作为输入。
 		if !shouldIncludeMarkdownFile(in, filepath.Base(in)) {
 			continue
 		}
@@ -52,7 +129,7 @@ func Discover(inputs []string) ([]RequirementFile, error) {
 		}
 	}
 	if len(out) == 0 {
-		return nil, fmt.Errorf("未发现 markdown 输入文件")
+		return nil, i18n.Errorf("未发现 markdown 输入文件")
 	}
 	return out, nil
 }
@@ -102,8 +179,5 @@ func shouldIncludeMarkdownFile(path string, name string) bool {
 	if strings.HasPrefix(base, ".") {
 		return false
 	}
-	if !isMarkdownFile(path) {
-		return false
-	}
-	return !generatedOutputMarkdownPattern.MatchString(strings.ToLower(base))
+	return isMarkdownFile(path)
 }
@@ -3,6 +3,7 @@ package input
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -88,6 +89,12 @@ func TestDiscover_SkipsHiddenNodeModulesAndGeneratedOutputs(t *testing.T) {
 	if err := os.WriteFile(filepath.Join(dir, "input_1234_cn.markdown"), []byte("# generated cn"), 0o644); err != nil {
 		t.Fatal(err)
 	}
+	if err := os.WriteFile(filepath.Join(dir, "input_final_en.md"), []byte("# final en"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "input_final_cn.md"), []byte("# final cn"), 0o644); err != nil {
+		t.Fatal(err)
+	}
 
 	items, err := Discover([]string{dir})
 	if err != nil {
@@ -100,3 +107,77 @@ func TestDiscover_SkipsHiddenNodeModulesAndGeneratedOutputs(t *testing.T) {
 		t.Fatalf("got=%q want=%q", items[0].Path, keep)
 	}
 }
+
+func TestDiscover_SkipsFixedNameOutputPairs(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{
+		"foo.md", "shoes_en.md",
+		"foo_en.md", "foo_cn.md",
+		"foo_2_en.md", "foo_2_cn.md",
+		"foo_1-2_en.md", "foo_1-2_cn.md",
+		"foo-3_de.md", "foo-3_en.md",
+		"x_ab12_es.md", "x_ab12_it.md", "z_best_pt.md", "z_best.json",
+		"bar_pt.md", "bar_ko.md", "bar.json",
+		"mat_red.md", "mat_tan.md",
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("# "+name), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	items, err := Discover([]string{dir})
+	if err != nil {
+		t.Fatalf("Discover error: %v", err)
+	}
+	var got []string
+	for _, it := range items {
+		got = append(got, filepath.Base(it.Path))
+	}
+	if strings.Join(got, ",") != "foo.md,mat_red.md,mat_tan.md,shoes_en.md" {
+		t.Fatalf("unexpected inputs: %v", got)
+	}
+	explicit, err := Discover([]string{filepath.Join(dir, "foo_2_en.md")})
+	if err != nil || len(explicit) != 1 {
+		t.Fatalf("explicit file should always be an input: %v, %v", explicit, err)
+	}
+}
+
+func TestDiscover_KeepsInputsThatLookLikeOutputs(t *testing.T) {
+	dir := t.TempDir()
+	names := []string{
+		"desk_lamp_led.md", "yoga_mats_set.md", "shoe_best_fit.md",
+		"bag_ab12_en.md", "bag_final_cn.md", "lamp_red.md", "lamp_tan.md",
+	}
+	for _, name := range names {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("# "+name), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	items, err := Discover([]string{dir})
+	if err != nil {
+		t.Fatalf("Discover error: %v", err)
+	}
+	if len(items) != len(names) {
+		t.Fatalf("len=%d want=%d: %+v", len(items), len(names), items)
+	}
+	for _, name := range []string{"desk_lamp_led.md", "yoga_mats_set.md", "shoe_best_fit.md"} {
+		items, err := Discover([]string{filepath.Join(dir, name)})
+		if err != nil || len(items) != 1 {
+			t.Fatalf("%s: items=%v err=%v", name, items, err)
+		}
+	}
+}
+
+func TestRequirementFileContentHash(t *testing.T) {
+	a := RequirementFile{Path: "a.md", Content: "same"}
+	b := RequirementFile{Path: "b.md", Content: "same"}
+	c := RequirementFile{Path: "a.md", Content: "other"}
+	if a.ContentHash() != b.ContentHash() {
+		t.Fatalf("same content should share hash")
+	}
+	if a.ContentHash() == c.ContentHash() {
+		t.Fatalf("different content should differ")
+	}
+	if len(a.ContentHash()) != 64 {
+		t.Fatalf("hash len=%d", len(a.ContentHash()))
+	}
+}
@@ -0,0 +1,50 @@
+package input
+
+import (
+	"strings"
+)
+
+// SplitFrontmatter 拆出开头 --- 包围的 key: value 块，返回小写键的取值与剩余正文。
+// 没有 frontmatter，或块内存在非 key: value 行时原样返回正文，避免误删需求内容。
+func SplitFrontmatter(content string) (map[string]string, string) {
+	text := strings.TrimPrefix(content, "\ufeff")
+	if !strings.HasPrefix(text, "---\n") && !strings.HasPrefix(text, "---\r\n") {
+		return nil, content
+	}
+	lines := strings.SplitAfter(text, "\n")
+	values := map[string]string{}
+	for i := 1; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+		if line == "---" {
+			return values, strings.Join(lines[i+1:], "")
+		}
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		k, v, ok := strings.Cut(line, ":")
+		if !ok || strings.TrimSpace(k) == "" {
+			return nil, content
+		}
+		values[strings.ToLower(strings.TrimSpace(k))] = strings.TrimSpace(v)
+	}
+	return nil, content
+}
+
+// Frontmatter 返回需求文件的 frontmatter 与去掉 frontmatter 后的正文。
+func (f RequirementFile) Frontmatter() (map[string]string, string) {
+	return SplitFrontmatter(f.Content)
+}
+
+// SplitList 解析 "a, b" 或 "[a, b]" 形式的列表值，去掉空项与引号。
+func SplitList(v string) []string {
+	v = strings.TrimSpace(v)
+	v = strings.TrimSuffix(strings.TrimPrefix(v, "["), "]")
+	var out []string
+	for _, item := range strings.Split(v, ",") {
+		item = strings.Trim(strings.TrimSpace(item), `"'`)
+		if item != "" {
+			out = append(out, item)
+		}
+	}
+	return out
+}
@@ -0,0 +1,18 @@
+package input
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSplitFrontmatter(t *testing.T) {
+	fm, body := SplitFrontmatter("---\nkeywords: [\"a\", b]\n---\n#MARK\ncontent\n")
+	if body != "#MARK\ncontent\n" || strings.Join(SplitList(fm["keywords"]), "|") != "a|b" {
+		t.Fatalf("fm=%v body=%q", fm, body)
+	}
+	for _, in := range []string{"#MARK\ncontent", "---\nnot a pair\n---\nbody", "---\nkeywords: a\nno end"} {
+		if fm, body := SplitFrontmatter(in); fm != nil || body != in {
+			t.Fatalf("%q: fm=%v body=%q", in, fm, body)
+		}
+	}
+}
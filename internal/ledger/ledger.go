@@ -0,0 +1,107 @@
+package ledger
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"syl-listing-pro/internal/i18n"
+)
+
+// FileName 是增量模式写在输出目录下的处理记录文件名。
+const FileName = ".syl-listing-ledger.json"
+
+type Entry struct {
+	InputPath   string   `json:"input_path"`
+	Outputs     []string `json:"outputs"`
+	GeneratedAt string   `json:"generated_at"`
+}
+
+// Ledger 按需求文件路径与内容哈希记录已处理的输入与对应产物。
+type Ledger struct {
+	path    string
+	mu      sync.Mutex
+	entries map[string]Entry
+}
+
+type ledgerFile struct {
+	Entries map[string]Entry `json:"entries"`
+}
+
+func Load(dir string) (*Ledger, error) {
+	l := &Ledger{
+		path:    filepath.Join(dir, FileName),
+		entries: make(map[string]Entry),
+	}
+	b, err := os.ReadFile(l.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return l, nil
+		}
+		return nil, i18n.Errorf("读取处理记录失败: %w", err)
+	}
+	var f ledgerFile
+	if err := json.Unmarshal(b, &f); err != nil {
+		return nil, i18n.Errorf("解析处理记录失败: %s: %w", l.path, err)
+	}
+	for key, e := range f.Entries {
+		// 旧版记录只以内容哈希为键，按记录中的输入路径换成新键。
+		if !strings.Contains(key, "#") && e.InputPath != "" {
+			key = Key(e.InputPath, key)
+		}
+		l.entries[key] = e
+	}
+	return l, nil
+}
+
+// Key 返回处理记录的键：清理后的输入绝对路径加内容哈希。新路径下的同样内容（如复制的模板）不会被当作已处理。
+func Key(inputPath, hash string) string {
+	abs, err := filepath.Abs(inputPath)
+	if err != nil {
+		abs = filepath.Clean(inputPath)
+	}
+	return abs + "#" + hash
+}
+
+func (l *Ledger) Path() string {
+	return l.path
+}
+
+// Lookup 按 Key 返回的键查找处理记录。
+func (l *Ledger) Lookup(key string) (Entry, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	e, ok := l.entries[key]
+	return e, ok
+}
+
+func (l *Ledger) Record(key string, e Entry) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.entries[key] = e
+}
+
+// Save 先写临时文件再重命名，避免中途崩溃留下半截记录。
+func (l *Ledger) Save() error {
+	l.mu.Lock()
+	b, err := json.MarshalIndent(ledgerFile{Entries: l.entries}, "", "  ")
+	l.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(l.path), 0o755); err != nil {
+		return i18n.Errorf("创建处理记录目录失败: %w", err)
+	}
+	tmp := l.path + ".tmp"
+	if err := os.WriteFile(tmp, append(b, '\n'), 0o644); err != nil {
+		return i18n.Errorf("写处理记录失败: %w", err)
+	}
+	if err := os.Rename(tmp, l.path); err != nil {
+		_ = os.Remove(tmp)
+		return i18n.Errorf("写处理记录失败: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package ledger
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoad_MissingFileIsEmpty(t *testing.T) {
+	l, err := Load(t.TempDir())
+	if err != nil {
+		t.Fatalf("Load error: %v", err)
+	}
+	if _, ok := l.Lookup("abc"); ok {
+		t.Fatal("expected empty ledger")
+	}
+}
+
+func TestRecordSaveLoadRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	l, err := Load(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	l.Record(Key("a.md", "h1"), Entry{InputPath: "a.md", Outputs: []string{"a_x_en.md"}, GeneratedAt: "2026-01-01T00:00:00Z"})
+	if err := l.Save(); err != nil {
+		t.Fatalf("Save error: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, FileName+".tmp")); !os.IsNotExist(err) {
+		t.Fatalf("tmp file should be renamed away, err=%v", err)
+	}
+
+	again, err := Load(dir)
+	if err != nil {
+		t.Fatalf("reload error: %v", err)
+	}
+	e, ok := again.Lookup(Key("a.md", "h1"))
+	if !ok || e.InputPath != "a.md" || len(e.Outputs) != 1 {
+		t.Fatalf("unexpected entry: %+v ok=%v", e, ok)
+	}
+	if _, ok := again.Lookup(Key("copy/a.md", "h1")); ok {
+		t.Fatal("same content at a new path should not be treated as processed")
+	}
+}
+
+func TestLoad_RekeysLegacyHashEntries(t *testing.T) {
+	dir := t.TempDir()
+	legacy := `{"entries":{"h1":{"input_path":"a.md","outputs":["a_x_en.md"]}}}`
+	if err := os.WriteFile(filepath.Join(dir, FileName), []byte(legacy), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	l, err := Load(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := l.Lookup(Key("a.md", "h1")); !ok {
+		t.Fatal("legacy entry should be keyed by its input path")
+	}
+	if _, ok := l.Lookup(Key("b.md", "h1")); ok {
+		t.Fatal("legacy entry should not match other paths")
+	}
+}
+
+func TestLoad_CorruptedFile(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, FileName), []byte("{"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	_, err := Load(dir)
+	if err == nil || !strings.Contains(err.Error(), "解析处理记录失败") {
+		t.Fatalf("unexpected err: %v", err)
+	}
+}
@@ -0,0 +1,62 @@
+package listing
+
+import (
+	"strings"
+	"unicode"
+)
+
+// FindBannedWords 返回 md 中出现的禁用词，按词表顺序、每个词只报一次。
+// 比较忽略大小写；含字母数字边界的词（如英文）要求整词匹配，避免 "cure" 误中 "secure"，
+// 中文等无词边界的文字按子串匹配。
+func FindBannedWords(md string, words []string) []string {
+	text := strings.ToLower(md)
+	var hits []string
+	for _, w := range words {
+		needle := strings.ToLower(strings.TrimSpace(w))
+		if needle != "" && containsWord(text, needle) {
+			hits = append(hits, w)
+		}
+	}
+	return hits
+}
+
+func containsWord(text, needle string) bool {
+	for start := 0; ; {
+		i := strings.Index(text[start:], needle)
+		if i < 0 {
+			return false
+		}
+		i += start
+		end := i + len(needle)
+		if boundaryOK(text[:i], needle, true) && boundaryOK(text[end:], needle, false) {
+			return true
+		}
+		start = i + 1
+	}
+}
+
+// boundaryOK 判断匹配处相邻字符是否构成词边界；needle 两端不是 ASCII 字母数字时不做要求。
+func boundaryOK(rest, needle string, before bool) bool {
+	var edge, neighbour rune
+	if before {
+		edge = []rune(needle)[0]
+		if r := []rune(rest); len(r) > 0 {
+			neighbour = r[len(r)-1]
+		}
+	} else {
+		r := []rune(needle)
+		edge = r[len(r)-1]
+		for _, c := range rest {
+			neighbour = c
+			break
+		}
+	}
+	if !isASCIIWordRune(edge) || neighbour == 0 {
+		return true
+	}
+	return !isASCIIWordRune(neighbour)
+}
+
+func isASCIIWordRune(r rune) bool {
+	return r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r))
+}
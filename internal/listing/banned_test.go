@@ -0,0 +1,18 @@
+package listing
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFindBannedWords(t *testing.T) {
+	md := "# Best Seller Bottle\nSecure lid, YETI-style.\n## 产品描述\n全网最佳水杯。"
+	words := []string{"best seller", "cure", "yeti", "最佳", "FDA"}
+	got := FindBannedWords(md, words)
+	if want := []string{"best seller", "yeti", "最佳"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("hits=%v want=%v", got, want)
+	}
+	if got := FindBannedWords(md, nil); got != nil {
+		t.Fatalf("empty list should yield no hits, got %v", got)
+	}
+}
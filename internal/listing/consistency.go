@@ -0,0 +1,91 @@
+package listing
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"syl-listing-pro/internal/i18n"
+)
+
+var (
+	numberPattern = regexp.MustCompile(`\d+(?:[.,]\d+)*`)
+	boldPattern   = regexp.MustCompile(`\*\*[^*]+\*\*|__[^_]+__`)
+)
+
+// Issue 是 EN/CN 之间的一处不一致。
+type Issue struct {
+	Section string `json:"section"`
+	Message string `json:"message"`
+}
+
+// ConsistencyReport 是 EN/CN 逐节比较的结果。
+type ConsistencyReport struct {
+	Consistent bool    `json:"consistent"`
+	Issues     []Issue `json:"issues,omitempty"`
+}
+
+// CheckConsistency 按分节顺序比较 EN 与 CN：分节数量、列表项数量、数值、高亮词数量。
+func CheckConsistency(en, cn Listing) ConsistencyReport {
+	var issues []Issue
+	if len(en.Sections) != len(cn.Sections) {
+		issues = append(issues, Issue{Section: i18n.T("全文"), Message: i18n.T("分节数量不一致：EN %d，CN %d", len(en.Sections), len(cn.Sections))})
+	}
+	n := len(en.Sections)
+	if len(cn.Sections) < n {
+		n = len(cn.Sections)
+	}
+	for i := 0; i < n; i++ {
+		e, c := en.Sections[i], cn.Sections[i]
+		name := e.Heading
+		if name == "" {
+			name = i18n.T("第 %d 节", i+1)
+		}
+		if e.Kind != KindOther && c.Kind != KindOther && e.Kind != c.Kind {
+			issues = append(issues, Issue{Section: name, Message: i18n.T("分节类型不一致：EN %s，CN %s（%s）", e.Kind, c.Kind, c.Heading)})
+			continue
+		}
+		if len(e.Items) != len(c.Items) {
+			issues = append(issues, Issue{Section: name, Message: i18n.T("列表项数量不一致：EN %d，CN %d", len(e.Items), len(c.Items))})
+		}
+		if onlyEN, onlyCN := diffMultiset(numbers(e.Body), numbers(c.Body)); len(onlyEN)+len(onlyCN) > 0 {
+			issues = append(issues, Issue{Section: name, Message: i18n.T("数值不一致：仅 EN [%s]，仅 CN [%s]", strings.Join(onlyEN, ", "), strings.Join(onlyCN, ", "))})
+		}
+		if be, bc := len(boldPattern.FindAllString(e.Body, -1)), len(boldPattern.FindAllString(c.Body, -1)); be != bc {
+			issues = append(issues, Issue{Section: name, Message: i18n.T("高亮词数量不一致：EN %d，CN %d", be, bc)})
+		}
+	}
+	return ConsistencyReport{Consistent: len(issues) == 0, Issues: issues}
+}
+
+func numbers(s string) []string {
+	raw := numberPattern.FindAllString(s, -1)
+	out := make([]string, 0, len(raw))
+	for _, n := range raw {
+		out = append(out, strings.ReplaceAll(n, ",", ""))
+	}
+	return out
+}
+
+// diffMultiset 返回只在 a 或只在 b 中出现（按次数计）的元素，结果已排序。
+func diffMultiset(a, b []string) ([]string, []string) {
+	count := map[string]int{}
+	for _, v := range a {
+		count[v]++
+	}
+	for _, v := range b {
+		count[v]--
+	}
+	var onlyA, onlyB []string
+	for v, c := range count {
+		for ; c > 0; c-- {
+			onlyA = append(onlyA, v)
+		}
+		for ; c < 0; c++ {
+			onlyB = append(onlyB, v)
+		}
+	}
+	sort.Strings(onlyA)
+	sort.Strings(onlyB)
+	return onlyA, onlyB
+}
@@ -0,0 +1,35 @@
+package listing
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCheckConsistency(t *testing.T) {
+	en := Parse("# Title\nBottle 32oz, 1,000 ml\n## Bullet Points\n- **Cold** 24 hours\n- Leak proof\n## Description\nGreat.")
+	cn := Parse("# 标题\n水杯 32oz，1000 毫升\n## 五点描述\n- **保冷** 24 小时\n- 防漏\n## 产品描述\n很好。")
+	if r := CheckConsistency(en, cn); !r.Consistent {
+		t.Fatalf("expected consistent, got %+v", r.Issues)
+	}
+
+	bad := Parse("# 标题\n水杯 40oz\n## 五点描述\n- 保冷 24 小时\n")
+	r := CheckConsistency(en, bad)
+	if r.Consistent {
+		t.Fatal("expected inconsistencies")
+	}
+	var msgs []string
+	for _, is := range r.Issues {
+		msgs = append(msgs, is.Section+":"+is.Message)
+	}
+	joined := strings.Join(msgs, "\n")
+	for _, want := range []string{
+		"全文:分节数量不一致：EN 3，CN 2",
+		"Title:数值不一致：仅 EN [1000, 32]，仅 CN [40]",
+		"Bullet Points:列表项数量不一致：EN 2，CN 1",
+		"Bullet Points:高亮词数量不一致：EN 1，CN 0",
+	} {
+		if !strings.Contains(joined, want) {
+			t.Fatalf("missing %q in:\n%s", want, joined)
+		}
+	}
+}
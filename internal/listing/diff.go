@@ -0,0 +1,145 @@
+package listing
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"syl-listing-pro/internal/i18n"
+)
+
+// SectionDiff 是两份 listing 中同一分节的比较结果。
+type SectionDiff struct {
+	Key     string
+	Heading string
+	InA     bool
+	InB     bool
+	CharsA  int
+	CharsB  int
+	// Lines 为按行比较的结果，前缀 "  " 相同、"- " 仅在 A、"+ " 仅在 B。
+	Lines []string
+}
+
+// Changed 表示该分节内容是否不同。
+func (d SectionDiff) Changed() bool {
+	if d.InA != d.InB {
+		return true
+	}
+	for _, l := range d.Lines {
+		if !strings.HasPrefix(l, "  ") {
+			return true
+		}
+	}
+	return false
+}
+
+// Diff 按分节对齐两份 listing：先按 A 的分节顺序，再追加只在 B 中出现的分节。
+func Diff(a, b Listing) []SectionDiff {
+	var out []SectionDiff
+	seen := map[string]bool{}
+	for _, s := range a.Sections {
+		key := s.Key()
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		d := SectionDiff{Key: key, Heading: s.Heading, InA: true, CharsA: s.Chars()}
+		other, ok := b.Section(key)
+		if ok {
+			d.InB = true
+			d.CharsB = other.Chars()
+		}
+		d.Lines = diffLines(bodyLines(s.Body), bodyLines(other.Body))
+		out = append(out, d)
+	}
+	for _, s := range b.Sections {
+		key := s.Key()
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		out = append(out, SectionDiff{Key: key, Heading: s.Heading, InB: true, CharsB: s.Chars(), Lines: diffLines(nil, bodyLines(s.Body))})
+	}
+	return out
+}
+
+// WriteDiff 以文本形式输出分节比较结果，附带每节字符数变化。
+func WriteDiff(w io.Writer, diffs []SectionDiff) {
+	for _, d := range diffs {
+		name := d.Heading
+		if name == "" {
+			name = i18n.T("（无标题）")
+		}
+		switch {
+		case !d.InB:
+			fmt.Fprint(w, i18n.T("== %s：仅 A 存在（%d 字符）\n", name, d.CharsA))
+		case !d.InA:
+			fmt.Fprint(w, i18n.T("== %s：仅 B 存在（%d 字符）\n", name, d.CharsB))
+		default:
+			fmt.Fprint(w, i18n.T("== %s：%d → %d 字符（%+d）\n", name, d.CharsA, d.CharsB, d.CharsB-d.CharsA))
+		}
+		if !d.Changed() {
+			fmt.Fprintln(w, i18n.T("   相同"))
+			continue
+		}
+		for _, l := range d.Lines {
+			if strings.HasPrefix(l, "  ") {
+				continue
+			}
+			fmt.Fprint(w, i18n.T("   %s（%d 字符）\n", l, utf8.RuneCountInString(l[2:])))
+		}
+	}
+}
+
+func bodyLines(body string) []string {
+	var out []string
+	for _, l := range strings.Split(body, "\n") {
+		if t := strings.TrimSpace(l); t != "" {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// diffLines 基于最长公共子序列逐行比较。
+func diffLines(a, b []string) []string {
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+	var out []string
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			out = append(out, "  "+a[i])
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			out = append(out, "- "+a[i])
+			i++
+		default:
+			out = append(out, "+ "+b[j])
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		out = append(out, "- "+a[i])
+	}
+	for ; j < len(b); j++ {
+		out = append(out, "+ "+b[j])
+	}
+	return out
+}
@@ -0,0 +1,76 @@
+package listing
+
+import "strings"
+
+// KeywordCoverage 记录一个关键词在标题、五点描述、详情描述中出现的次数。
+type KeywordCoverage struct {
+	Keyword     string `json:"keyword"`
+	Title       int    `json:"title"`
+	Bullets     int    `json:"bullets"`
+	Description int    `json:"description"`
+}
+
+// Total 返回关键词在三个分节中出现的总次数。
+func (c KeywordCoverage) Total() int {
+	return c.Title + c.Bullets + c.Description
+}
+
+// CheckKeywordCoverage 按 keywords 顺序统计每个关键词在 md 各分节中的出现次数。
+// 匹配规则与 FindBannedWords 相同：忽略大小写，英文按整词匹配。
+func CheckKeywordCoverage(md string, keywords []string) []KeywordCoverage {
+	if len(keywords) == 0 {
+		return nil
+	}
+	texts := map[string]string{}
+	for _, s := range Parse(md).Sections {
+		switch s.Kind {
+		case KindTitle, KindBullets, KindDescription:
+			texts[s.Kind] += "\n" + strings.ToLower(s.Body)
+		}
+	}
+	out := make([]KeywordCoverage, 0, len(keywords))
+	for _, k := range keywords {
+		needle := strings.ToLower(strings.TrimSpace(k))
+		if needle == "" {
+			continue
+		}
+		out = append(out, KeywordCoverage{
+			Keyword:     k,
+			Title:       countWord(texts[KindTitle], needle),
+			Bullets:     countWord(texts[KindBullets], needle),
+			Description: countWord(texts[KindDescription], needle),
+		})
+	}
+	return out
+}
+
+// MissingKeywords 返回 coverage 中一次都没有出现的关键词。
+func MissingKeywords(coverage []KeywordCoverage) []string {
+	var out []string
+	for _, c := range coverage {
+		if c.Total() == 0 {
+			out = append(out, c.Keyword)
+		}
+	}
+	return out
+}
+
+// countWord 统计 needle 在 text 中不重叠的整词出现次数；text 与 needle 均应已转为小写。
+func countWord(text, needle string) int {
+	n := 0
+	for start := 0; start < len(text); {
+		i := strings.Index(text[start:], needle)
+		if i < 0 {
+			break
+		}
+		i += start
+		end := i + len(needle)
+		if boundaryOK(text[:i], needle, true) && boundaryOK(text[end:], needle, false) {
+			n++
+			start = end
+			continue
+		}
+		start = i + 1
+	}
+	return n
+}
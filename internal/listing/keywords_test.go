@@ -0,0 +1,32 @@
+package listing
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCheckKeywordCoverage(t *testing.T) {
+	md := sampleA + "\nWater bottle for gym, hiking and water sports.\n"
+	got := CheckKeywordCoverage(md, []string{"Water Bottle", "leak proof", "bpa", "insulated", " "})
+	want := []KeywordCoverage{
+		{Keyword: "Water Bottle", Title: 1, Description: 1},
+		{Keyword: "leak proof", Bullets: 1},
+		{Keyword: "bpa", Bullets: 1},
+		{Keyword: "insulated"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("coverage=%+v want=%+v", got, want)
+	}
+	if missing := MissingKeywords(got); !reflect.DeepEqual(missing, []string{"insulated"}) {
+		t.Fatalf("missing=%v", missing)
+	}
+	if got := CheckKeywordCoverage(md, nil); got != nil {
+		t.Fatalf("no keywords should yield nil, got %v", got)
+	}
+}
+
+func TestCountWord(t *testing.T) {
+	if n := countWord("mat yoga mat, matte mat", "mat"); n != 3 {
+		t.Fatalf("n=%d", n)
+	}
+}
@@ -0,0 +1,128 @@
+// Package listing 解析生成的 listing Markdown，按标题、五点描述、详情描述等分节。
+package listing
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// 常见分节类型；无法识别的标题归为 KindOther，按标题文本区分。
+const (
+	KindTitle       = "title"
+	KindBullets     = "bullets"
+	KindDescription = "description"
+	KindOther       = "other"
+)
+
+type Section struct {
+	Heading string
+	Kind    string
+	// Body 为分节正文（不含标题行），首尾空白已去除。
+	Body string
+	// Items 为正文中的列表项（- / * / 1. 开头），不含列表符号。
+	Items []string
+}
+
+// Key 返回用于两份 listing 对齐的分节键。
+func (s Section) Key() string {
+	if s.Kind != KindOther {
+		return s.Kind
+	}
+	return strings.ToLower(s.Heading)
+}
+
+// Chars 返回正文的字符数（按 Unicode 字符计）。
+func (s Section) Chars() int {
+	return utf8.RuneCountInString(s.Body)
+}
+
+type Listing struct {
+	Sections []Section
+}
+
+// Section 返回第一个匹配 key 的分节。
+func (l Listing) Section(key string) (Section, bool) {
+	for _, s := range l.Sections {
+		if s.Key() == key {
+			return s, true
+		}
+	}
+	return Section{}, false
+}
+
+// Parse 以 # ~ ### 标题切分 Markdown；第一个标题之前的正文归入无标题分节。
+// 开头的 UTF-8 BOM 与来源注释（--provenance-header）会被忽略，CRLF 按 LF 处理。
+func Parse(md string) Listing {
+	md = StripProvenance(strings.ReplaceAll(strings.TrimPrefix(md, "\ufeff"), "\r\n", "\n"))
+	var out Listing
+	var cur *Section
+	var body []string
+	flush := func() {
+		if cur == nil {
+			if text := strings.TrimSpace(strings.Join(body, "\n")); text != "" {
+				cur = &Section{Kind: KindOther}
+			} else {
+				return
+			}
+		}
+		cur.Body = strings.TrimSpace(strings.Join(body, "\n"))
+		cur.Items = listItems(body)
+		out.Sections = append(out.Sections, *cur)
+	}
+	for _, line := range strings.Split(strings.ReplaceAll(md, "\r\n", "\n"), "\n") {
+		if heading, ok := parseHeading(line); ok {
+			flush()
+			cur = &Section{Heading: heading, Kind: classify(heading)}
+			body = body[:0]
+			continue
+		}
+		body = append(body, line)
+	}
+	flush()
+	return out
+}
+
+func parseHeading(line string) (string, bool) {
+	trimmed := strings.TrimSpace(line)
+	level := 0
+	for level < len(trimmed) && trimmed[level] == '#' {
+		level++
+	}
+	if level == 0 || level > 3 || level >= len(trimmed) || trimmed[level] != ' ' {
+		return "", false
+	}
+	return strings.TrimSpace(trimmed[level:]), true
+}
+
+func classify(heading string) string {
+	h := strings.ToLower(heading)
+	switch {
+	case strings.Contains(h, "bullet") || strings.Contains(h, "五点") || strings.Contains(h, "要点") || strings.Contains(h, "卖点"):
+		return KindBullets
+	case strings.Contains(h, "description") || strings.Contains(h, "描述") || strings.Contains(h, "详情"):
+		return KindDescription
+	case strings.Contains(h, "title") || strings.Contains(h, "标题"):
+		return KindTitle
+	}
+	return KindOther
+}
+
+func listItems(lines []string) []string {
+	var items []string
+	for _, line := range lines {
+		t := strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(t, "- "), strings.HasPrefix(t, "* "):
+			items = append(items, strings.TrimSpace(t[2:]))
+		default:
+			i := 0
+			for i < len(t) && t[i] >= '0' && t[i] <= '9' {
+				i++
+			}
+			if i > 0 && i+1 < len(t) && (t[i] == '.' || t[i] == ')') && t[i+1] == ' ' {
+				items = append(items, strings.TrimSpace(t[i+2:]))
+			}
+		}
+	}
+	return items
+}
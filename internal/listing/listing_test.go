@@ -0,0 +1,89 @@
+package listing
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+const sampleA = `# Title
+Stainless Steel Water Bottle 32oz
+
+## Bullet Points
+- Keeps drinks cold 24 hours
+- Leak proof lid
+- BPA free
+
+## Description
+A durable bottle for daily use.
+`
+
+const sampleB = `# Title
+Stainless Steel Water Bottle 40oz, Insulated
+
+## Bullet Points
+- Keeps drinks cold 24 hours
+1. Leak proof lid with handle
+2. BPA free
+
+## Search Terms
+bottle flask
+`
+
+func TestParse(t *testing.T) {
+	l := Parse(sampleA)
+	if len(l.Sections) != 3 {
+		t.Fatalf("sections=%d want=3", len(l.Sections))
+	}
+	bullets, ok := l.Section(KindBullets)
+	if !ok || len(bullets.Items) != 3 || bullets.Items[1] != "Leak proof lid" {
+		t.Fatalf("unexpected bullets: %+v", bullets)
+	}
+	title, _ := l.Section(KindTitle)
+	if title.Chars() != len("Stainless Steel Water Bottle 32oz") {
+		t.Fatalf("title chars=%d", title.Chars())
+	}
+	cn := Parse("# 标题\n中文标题\n## 五点描述\n1. 第一点\n2) 第二点\n## 产品描述\n描述")
+	if got := []string{cn.Sections[0].Kind, cn.Sections[1].Kind, cn.Sections[2].Kind}; strings.Join(got, ",") != "title,bullets,description" {
+		t.Fatalf("unexpected kinds: %v", got)
+	}
+	if items := cn.Sections[1].Items; len(items) != 2 || items[1] != "第二点" {
+		t.Fatalf("unexpected cn items: %v", items)
+	}
+	bom := Parse("\ufeff" + strings.ReplaceAll(sampleA, "\n", "\r\n"))
+	if len(bom.Sections) != 3 || bom.Sections[0].Kind != KindTitle || bom.Sections[0].Chars() != title.Chars() {
+		t.Fatalf("BOM/CRLF listing parsed as %+v", bom.Sections)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	diffs := Diff(Parse(sampleA), Parse(sampleB))
+	if len(diffs) != 4 {
+		t.Fatalf("diffs=%d want=4", len(diffs))
+	}
+	if diffs[0].Key != KindTitle || !diffs[0].Changed() || diffs[0].CharsB-diffs[0].CharsA != 11 {
+		t.Fatalf("unexpected title diff: %+v", diffs[0])
+	}
+	if diffs[2].Key != KindDescription || diffs[2].InB {
+		t.Fatalf("description should only be in A: %+v", diffs[2])
+	}
+	if diffs[3].Key != "search terms" || diffs[3].InA {
+		t.Fatalf("search terms should only be in B: %+v", diffs[3])
+	}
+
+	var buf bytes.Buffer
+	WriteDiff(&buf, diffs)
+	out := buf.String()
+	for _, want := range []string{"== Title：33 → 44 字符（+11）", "- - Leak proof lid", "+ 1. Leak proof lid with handle", "== Description：仅 A 存在", "== Search Terms：仅 B 存在"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("missing %q in:\n%s", want, out)
+		}
+	}
+
+	same := Diff(Parse(sampleA), Parse(sampleA))
+	for _, d := range same {
+		if d.Changed() {
+			t.Fatalf("identical listings reported change: %+v", d)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package listing
+
+import (
+	"strings"
+	"unicode"
+)
+
+// 引号规范方式。
+const (
+	QuotesStraight = "straight"
+	QuotesCurly    = "curly"
+)
+
+// NormalizeOptions 为 Markdown 规范化规则；零值表示不做任何修改。
+type NormalizeOptions struct {
+	// CollapseBlankLines 把连续多个空行压成一个，并去掉行尾空白与文末多余空行。
+	CollapseBlankLines bool
+	// Bullets 把 * + • · 等列表符号统一为 "-"。
+	Bullets bool
+	// Quotes 为 QuotesStraight 时把弯引号改为直引号，为 QuotesCurly 时反之；为空不处理。
+	Quotes string
+	// StripEmoji 去掉 emoji 及其变体选择符、零宽连接符。
+	StripEmoji bool
+}
+
+// Enabled 判断是否启用了任一规则。
+func (o NormalizeOptions) Enabled() bool {
+	return o != NormalizeOptions{}
+}
+
+// Normalize 按 opts 规范化 md；``` 代码块内只参与空行压缩，内容保持原样。
+func Normalize(md string, opts NormalizeOptions) string {
+	if !opts.Enabled() {
+		return md
+	}
+	lines := strings.Split(strings.ReplaceAll(md, "\r\n", "\n"), "\n")
+	out := make([]string, 0, len(lines))
+	inFence := false
+	blank := 0
+	for _, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inFence = !inFence
+		} else if !inFence {
+			if opts.StripEmoji {
+				line = stripEmoji(line)
+			}
+			if opts.Bullets {
+				line = normalizeBullet(line)
+			}
+			switch opts.Quotes {
+			case QuotesStraight:
+				line = straightQuotes.Replace(line)
+			case QuotesCurly:
+				line = curlQuotes(line)
+			}
+		}
+		if opts.CollapseBlankLines && !inFence {
+			line = strings.TrimRightFunc(line, unicode.IsSpace)
+			if line == "" {
+				blank++
+				if blank > 1 {
+					continue
+				}
+			} else {
+				blank = 0
+			}
+		}
+		out = append(out, line)
+	}
+	result := strings.Join(out, "\n")
+	if opts.CollapseBlankLines {
+		result = strings.TrimRight(result, "\n") + "\n"
+	}
+	return result
+}
+
+var straightQuotes = strings.NewReplacer("‘", "'", "’", "'", "‚", "'", "‛", "'", "“", `"`, "”", `"`, "„", `"`, "‟", `"`)
+
+// curlQuotes 把直引号改为弯引号：前面是行首、空白或左括号时为左引号，否则为右引号（含撇号）。
+func curlQuotes(line string) string {
+	if !strings.ContainsAny(line, `"'`) {
+		return line
+	}
+	var b strings.Builder
+	prev := ' '
+	for _, r := range line {
+		opening := unicode.IsSpace(prev) || strings.ContainsRune("([{<—–-", prev)
+		switch {
+		case r == '"' && opening:
+			b.WriteRune('“')
+		case r == '"':
+			b.WriteRune('”')
+		case r == '\'' && opening:
+			b.WriteRune('‘')
+		case r == '\'':
+			b.WriteRune('’')
+		default:
+			b.WriteRune(r)
+		}
+		prev = r
+	}
+	return b.String()
+}
+
+// normalizeBullet 把行首（保留缩进）的 * + • · ▪ ● 列表符号改为 "-"；* 与 + 后须有空格，以免误改强调语法。
+func normalizeBullet(line string) string {
+	body := strings.TrimLeft(line, " \t")
+	indent := line[:len(line)-len(body)]
+	for _, marker := range []string{"* ", "+ ", "•", "·", "▪", "●"} {
+		if strings.HasPrefix(body, marker) {
+			return indent + "- " + strings.TrimLeft(body[len(marker):], " ")
+		}
+	}
+	return line
+}
+
+// stripEmoji 去掉 line 中的 emoji；被去掉的 emoji 留下的多余空格一并收拢，保留行首缩进。
+func stripEmoji(line string) string {
+	if strings.IndexFunc(line, isEmoji) < 0 {
+		return line
+	}
+	body := strings.TrimLeft(line, " \t")
+	indent := line[:len(line)-len(body)]
+	body = strings.Map(func(r rune) rune {
+		if isEmoji(r) {
+			return -1
+		}
+		return r
+	}, body)
+	fields := strings.FieldsFunc(body, func(r rune) bool { return r == ' ' })
+	return indent + strings.Join(fields, " ")
+}
+
+func isEmoji(r rune) bool {
+	switch {
+	case r >= 0x1F000 && r <= 0x1FAFF: // 表情、符号与象形文字、交通地图、国旗等
+		return true
+	case r >= 0x2600 && r <= 0x27BF: // 杂项符号与装饰符号（☀ ✅ ✨）
+		return true
+	case r >= 0x2B00 && r <= 0x2BFF: // ⭐ ⬆ 等
+		return true
+	case r == 0xFE0F || r == 0x200D || r == 0x20E3: // 变体选择符、零宽连接符、组合键帽
+		return true
+	}
+	return false
+}
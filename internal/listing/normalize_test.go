@@ -0,0 +1,22 @@
+package listing
+
+import "testing"
+
+func TestNormalize(t *testing.T) {
+	md := "# Title  \n\n\n\nYoga Mat ✨ \"Pro\" Edition\n\n## Bullets\n* Non-slip 🧘‍♀️ surface\n• Doesn’t slip\n  + nested\n*not a bullet*\n\n\n```\n*  keep \"this\"\n\n\n```\n\n\n"
+	got := Normalize(md, NormalizeOptions{CollapseBlankLines: true, Bullets: true, Quotes: QuotesStraight, StripEmoji: true})
+	want := "# Title\n\nYoga Mat \"Pro\" Edition\n\n## Bullets\n- Non-slip surface\n- Doesn't slip\n  - nested\n*not a bullet*\n\n```\n*  keep \"this\"\n\n\n```\n"
+	if got != want {
+		t.Fatalf("got:\n%q\nwant:\n%q", got, want)
+	}
+	if got := Normalize(md, NormalizeOptions{}); got != md {
+		t.Fatal("zero options should not change input")
+	}
+}
+
+func TestNormalize_CurlyQuotes(t *testing.T) {
+	got := Normalize(`Say "hi" and it's ('ok')`, NormalizeOptions{Quotes: QuotesCurly})
+	if want := "Say “hi” and it’s (‘ok’)"; got != want {
+		t.Fatalf("got=%q want=%q", got, want)
+	}
+}
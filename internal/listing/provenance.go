@@ -0,0 +1,50 @@
+package listing
+
+import (
+	"strings"
+)
+
+// provenancePrefix 为来源注释的开头；注释写在产物 Markdown 第一行，渲染时不可见。
+const provenancePrefix = "<!-- syl:"
+
+// ProvenanceHeader 返回记录来源的 HTML 注释，如 <!-- syl: job=job_1 rules=v7 generated=2026-03-01T10:00:00Z -->；
+// 空字段不写，全部为空时返回空字符串。值中的空白替换为下划线，以免破坏 key=value 格式。
+func ProvenanceHeader(jobID, rulesVersion, generatedAt string) string {
+	var fields []string
+	for _, kv := range [][2]string{{"job", jobID}, {"rules", rulesVersion}, {"generated", generatedAt}} {
+		if v := strings.Join(strings.Fields(kv[1]), "_"); v != "" {
+			fields = append(fields, kv[0]+"="+strings.ReplaceAll(v, "--", "-"))
+		}
+	}
+	if len(fields) == 0 {
+		return ""
+	}
+	return provenancePrefix + " " + strings.Join(fields, " ") + " -->"
+}
+
+// ParseProvenance 解析 md 开头的来源注释，返回各字段；没有时返回 nil。
+func ParseProvenance(md string) map[string]string {
+	line, _, _ := strings.Cut(strings.TrimPrefix(md, "\ufeff"), "\n")
+	line = strings.TrimSpace(line)
+	if !strings.HasPrefix(line, provenancePrefix) || !strings.HasSuffix(line, "-->") {
+		return nil
+	}
+	out := map[string]string{}
+	for _, f := range strings.Fields(strings.TrimSuffix(strings.TrimPrefix(line, provenancePrefix), "-->")) {
+		if k, v, ok := strings.Cut(f, "="); ok {
+			out[k] = v
+		}
+	}
+	return out
+}
+
+// StripProvenance 去掉 md 开头的来源注释行（保留 BOM）；没有时原样返回。
+func StripProvenance(md string) string {
+	body := strings.TrimPrefix(md, "\ufeff")
+	bom := md[:len(md)-len(body)]
+	if ParseProvenance(body) == nil {
+		return md
+	}
+	_, rest, _ := strings.Cut(body, "\n")
+	return bom + rest
+}
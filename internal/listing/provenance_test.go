@@ -0,0 +1,35 @@
+package listing
+
+import "testing"
+
+func TestProvenanceHeaderRoundTrip(t *testing.T) {
+	h := ProvenanceHeader("job_1", "v7", "2026-03-01T10:00:00Z")
+	if h != "<!-- syl: job=job_1 rules=v7 generated=2026-03-01T10:00:00Z -->" {
+		t.Fatalf("header=%q", h)
+	}
+	got := ParseProvenance(h + "\n# Title\n")
+	if got["job"] != "job_1" || got["rules"] != "v7" || got["generated"] != "2026-03-01T10:00:00Z" {
+		t.Fatalf("parsed=%v", got)
+	}
+	if ProvenanceHeader("", "", "") != "" {
+		t.Fatal("empty fields should yield no header")
+	}
+	if h := ProvenanceHeader("a b-->", "", ""); h != "<!-- syl: job=a_b-> -->" {
+		t.Fatalf("unsafe value not escaped: %q", h)
+	}
+}
+
+func TestStripProvenance(t *testing.T) {
+	md := "\ufeff<!-- syl: job=j1 -->\n# Title\nbody"
+	if got := StripProvenance(md); got != "\ufeff# Title\nbody" {
+		t.Fatalf("stripped=%q", got)
+	}
+	plain := "<!-- other -->\n# Title"
+	if StripProvenance(plain) != plain {
+		t.Fatal("unrelated comments must be kept")
+	}
+	l := Parse("<!-- syl: job=j1 rules=v7 -->\n# Title\nMat")
+	if len(l.Sections) != 1 || l.Sections[0].Kind != KindTitle {
+		t.Fatalf("provenance comment should not form a section: %+v", l.Sections)
+	}
+}
@@ -0,0 +1,36 @@
+package listing
+
+import "strings"
+
+// SearchTermsByteBudget 为 Amazon 后台搜索词的字节上限（按 UTF-8 计，超过 249 字节整段不被索引）。
+const SearchTermsByteBudget = 249
+
+// FitSearchTerms 把搜索词整理为空格分隔的一行：逗号、分号按分隔符处理，不区分大小写去重，
+// 按先后顺序装入 budget 字节以内；装不下的词不截断，原样放入 dropped 返回。
+func FitSearchTerms(terms string, budget int) (fitted string, dropped []string) {
+	words := strings.FieldsFunc(terms, func(r rune) bool {
+		return r == ',' || r == ';' || r == '，' || r == '；' || r == '、' || strings.ContainsRune(" \t\r\n", r)
+	})
+	seen := map[string]bool{}
+	var b strings.Builder
+	for _, w := range words {
+		key := strings.ToLower(w)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		need := len(w)
+		if b.Len() > 0 {
+			need++
+		}
+		if b.Len()+need > budget {
+			dropped = append(dropped, w)
+			continue
+		}
+		if b.Len() > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(w)
+	}
+	return b.String(), dropped
+}
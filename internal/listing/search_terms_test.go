@@ -0,0 +1,22 @@
+package listing
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFitSearchTerms(t *testing.T) {
+	got, dropped := FitSearchTerms("yoga mat, Yoga; exercise\nmat pilates", 30)
+	if got != "yoga mat exercise pilates" || dropped != nil {
+		t.Fatalf("got=%q dropped=%v", got, dropped)
+	}
+	got, dropped = FitSearchTerms("alpha beta gamma delta", 16)
+	if got != "alpha beta gamma" || !reflect.DeepEqual(dropped, []string{"delta"}) {
+		t.Fatalf("got=%q dropped=%v", got, dropped)
+	}
+	got, _ = FitSearchTerms(strings.Repeat("瑜伽垫 ", 100), SearchTermsByteBudget)
+	if got != "瑜伽垫" {
+		t.Fatalf("got=%q", got)
+	}
+}
@@ -0,0 +1,73 @@
+package listing
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+var englishWordPattern = regexp.MustCompile(`[A-Za-z]+(?:'[A-Za-z]+)*`)
+
+// spellSuffixes 为词典只收原形时尝试去掉的常见词尾（单词表通常不含屈折变化）。
+var spellSuffixes = []string{"'s", "s", "es", "ed", "d", "ing", "ly", "er", "est"}
+
+// FindMisspellings 返回 md 中不在词典 dict（小写）里的英文单词，按出现顺序、每个词只报一次。
+// 少于 3 个字母的词、全大写缩写以及 iPhone 这类词中含大写的品牌写法不检查。
+func FindMisspellings(md string, dict map[string]struct{}) []string {
+	if len(dict) == 0 {
+		return nil
+	}
+	seen := map[string]bool{}
+	var out []string
+	for _, word := range englishWordPattern.FindAllString(md, -1) {
+		if len(word) < 3 || hasInnerUpper(word) {
+			continue
+		}
+		key := strings.ToLower(word)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		if !knownWord(key, dict) {
+			out = append(out, word)
+		}
+	}
+	return out
+}
+
+func hasInnerUpper(word string) bool {
+	for _, r := range word[1:] {
+		if unicode.IsUpper(r) {
+			return true
+		}
+	}
+	return false
+}
+
+func knownWord(word string, dict map[string]struct{}) bool {
+	if _, ok := dict[word]; ok {
+		return true
+	}
+	for _, suffix := range spellSuffixes {
+		stem := strings.TrimSuffix(word, suffix)
+		if stem == word || len(stem) < 2 {
+			continue
+		}
+		if _, ok := dict[stem]; ok {
+			return true
+		}
+		// running -> run、stopped -> stop
+		if n := len(stem); n > 2 && stem[n-1] == stem[n-2] {
+			if _, ok := dict[stem[:n-1]]; ok {
+				return true
+			}
+		}
+		// carries -> carry
+		if strings.HasSuffix(stem, "i") {
+			if _, ok := dict[strings.TrimSuffix(stem, "i")+"y"]; ok {
+				return true
+			}
+		}
+	}
+	return false
+}
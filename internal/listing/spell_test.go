@@ -0,0 +1,22 @@
+package listing
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFindMisspellings(t *testing.T) {
+	dict := map[string]struct{}{}
+	for _, w := range []string{"the", "bottle", "keep", "drink", "cold", "run", "carry", "stop", "easy", "brand"} {
+		dict[w] = struct{}{}
+	}
+	md := "# Title\nThe bottle keeps drinks cold while running.\n- Easily carries, stopped leaks: the botle is grate.\n- iPhone USB ok SYLbrand brand's botle"
+	got := FindMisspellings(md, dict)
+	want := []string{"Title", "while", "leaks", "botle", "grate"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got=%v want=%v", got, want)
+	}
+	if FindMisspellings(md, nil) != nil {
+		t.Fatalf("empty dictionary should skip the check")
+	}
+}
@@ -0,0 +1,239 @@
+package manifest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"syl-listing-pro/internal/i18n"
+)
+
+const (
+	StatusPending   = "pending"
+	StatusSubmitted = "submitted"
+	StatusSucceeded = "succeeded"
+	StatusFailed    = "failed"
+	StatusCancelled = "cancelled"
+	// StatusSkipped 表示 --on-conflict skip 下产物已存在，没有写入新文件。
+	StatusSkipped = "skipped"
+)
+
+var ErrNoRuns = errors.New("no_recorded_runs")
+
+// Manifest 是一次运行的检查点：记录任务、job ID 与产物，供断点续跑和事后追溯。
+type Manifest struct {
+	RunID      string `json:"run_id"`
+	StartedAt  string `json:"started_at"`
+	FinishedAt string `json:"finished_at,omitempty"`
+	OutputDir  string `json:"output_dir"`
+	Num        int    `json:"num"`
+	// KeyProfile 为提交任务时使用的 KEY 配置，续跑与重新生成需沿用同一租户。
+	KeyProfile string `json:"key_profile,omitempty"`
+	// Sections 非空表示本次只重新生成这些分节。
+	Sections []string `json:"sections,omitempty"`
+	// Keywords 为 --keywords 指定的关键词（不含需求文件 frontmatter 中的）。
+	Keywords    []string `json:"keywords,omitempty"`
+	Marketplace string   `json:"marketplace,omitempty"`
+	// SearchTerms 表示本次运行额外生成后台搜索词。
+	SearchTerms bool `json:"search_terms,omitempty"`
+	// Brand、Category、TargetAudience、KeyFeatures 为命令行指定的商品属性（不含需求文件 frontmatter 中的）。
+	Brand          string   `json:"brand,omitempty"`
+	Category       string   `json:"category,omitempty"`
+	TargetAudience string   `json:"target_audience,omitempty"`
+	KeyFeatures    []string `json:"key_features,omitempty"`
+	// Label 为 --label 指定的运行标签（如营销活动名），history / stats 可按它筛选。
+	Label  string   `json:"label,omitempty"`
+	Inputs []string `json:"inputs"`
+	Tasks  []Task   `json:"tasks"`
+}
+
+type Task struct {
+	Key            string   `json:"key"`
+	InputPath      string   `json:"input_path"`
+	Mirrors        []string `json:"mirrors,omitempty"`
+	Index          int      `json:"index"`
+	Label          string   `json:"label,omitempty"`
+	ContentHash    string   `json:"content_hash"`
+	IdempotencyKey string   `json:"idempotency_key,omitempty"`
+	JobID          string   `json:"job_id,omitempty"`
+	Status         string   `json:"status"`
+	Outputs        []string `json:"outputs,omitempty"`
+	RemoteURLs     []string `json:"remote_urls,omitempty"`
+	// ValidationReport 为服务端返回的校验报告，记录接近边界的规则。
+	ValidationReport []string `json:"validation_report,omitempty"`
+	RulesVersion     string   `json:"rules_version,omitempty"`
+	DurationMs       int64    `json:"duration_ms,omitempty"`
+	// SectionDurationsMs 为各分节生成耗时（来自 section_generate_ok 事件，同一分节累加）。
+	SectionDurationsMs map[string]int64 `json:"section_durations_ms,omitempty"`
+	Tokens             int64            `json:"tokens,omitempty"`
+	Credits            float64          `json:"credits,omitempty"`
+	Error              string           `json:"error,omitempty"`
+	// Selected 表示该候选在运行结束后被选为最终稿，FinalOutputs 为复制出的 _final（--auto-pick 时为 _best）文件。
+	Selected     bool     `json:"selected,omitempty"`
+	FinalOutputs []string `json:"final_outputs,omitempty"`
+}
+
+// Done 表示任务已成功完成，续跑时不再处理。
+func (t Task) Done() bool {
+	return t.Status == StatusSucceeded
+}
+
+// Pending 返回尚未成功完成（也未因产物已存在而跳过）的任务数。
+func (m Manifest) Pending() int {
+	n := 0
+	for _, t := range m.Tasks {
+		if !t.Done() && t.Status != StatusSkipped {
+			n++
+		}
+	}
+	return n
+}
+
+func TaskKey(inputPath string, index int) string {
+	return fmt.Sprintf("%s#%d", inputPath, index)
+}
+
+func FilePath(dir, runID string) string {
+	return filepath.Join(dir, runID+".json")
+}
+
+func Load(path string) (Manifest, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return Manifest{}, i18n.Errorf("读取运行记录失败: %w", err)
+	}
+	var m Manifest
+	if err := json.Unmarshal(b, &m); err != nil {
+		return Manifest{}, i18n.Errorf("解析运行记录失败: %s: %w", path, err)
+	}
+	return m, nil
+}
+
+// List 按运行编号倒序（即时间倒序）返回目录下全部运行记录路径。
+func List(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, i18n.Errorf("读取运行记录目录失败: %w", err)
+	}
+	var out []string
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".json") {
+			continue
+		}
+		out = append(out, filepath.Join(dir, e.Name()))
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(out)))
+	return out, nil
+}
+
+func LoadLatest(dir string) (Manifest, string, error) {
+	paths, err := List(dir)
+	if err != nil {
+		return Manifest{}, "", err
+	}
+	if len(paths) == 0 {
+		return Manifest{}, "", ErrNoRuns
+	}
+	m, err := Load(paths[0])
+	if err != nil {
+		return Manifest{}, "", err
+	}
+	return m, paths[0], nil
+}
+
+// Checkpoint 在运行过程中持续落盘 Manifest；nil Checkpoint 的方法均为空操作。
+type Checkpoint struct {
+	mu   sync.Mutex
+	path string
+	m    Manifest
+}
+
+func Create(dir string, m Manifest) (*Checkpoint, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, i18n.Errorf("创建运行记录目录失败: %w", err)
+	}
+	return Open(FilePath(dir, m.RunID), m)
+}
+
+// Open 以已有 Manifest 继续写入指定路径（续跑时使用）。
+func Open(path string, m Manifest) (*Checkpoint, error) {
+	c := &Checkpoint{path: path, m: m}
+	if err := c.save(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (c *Checkpoint) Path() string {
+	if c == nil {
+		return ""
+	}
+	return c.path
+}
+
+func (c *Checkpoint) Snapshot() Manifest {
+	if c == nil {
+		return Manifest{}
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	out := c.m
+	out.Tasks = append([]Task(nil), c.m.Tasks...)
+	return out
+}
+
+func (c *Checkpoint) Update(key string, fn func(*Task)) error {
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i := range c.m.Tasks {
+		if c.m.Tasks[i].Key == key {
+			fn(&c.m.Tasks[i])
+			return c.saveLocked()
+		}
+	}
+	return i18n.Errorf("运行记录中不存在任务: %s", key)
+}
+
+func (c *Checkpoint) Finish(at time.Time) error {
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.m.FinishedAt = at.Format(time.RFC3339)
+	return c.saveLocked()
+}
+
+func (c *Checkpoint) save() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.saveLocked()
+}
+
+func (c *Checkpoint) saveLocked() error {
+	b, err := json.MarshalIndent(c.m, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := c.path + ".tmp"
+	if err := os.WriteFile(tmp, append(b, '\n'), 0o644); err != nil {
+		return i18n.Errorf("写运行记录失败: %w", err)
+	}
+	if err := os.Rename(tmp, c.path); err != nil {
+		_ = os.Remove(tmp)
+		return i18n.Errorf("写运行记录失败: %w", err)
+	}
+	return nil
+}
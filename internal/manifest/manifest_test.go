@@ -0,0 +1,83 @@
+package manifest
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCheckpointUpdateAndLoadLatest(t *testing.T) {
+	dir := t.TempDir()
+	older := Manifest{RunID: "20260101-000000-aaaaaa", Tasks: []Task{{Key: TaskKey("a.md", 1), Status: StatusSucceeded}}}
+	if _, err := Create(dir, older); err != nil {
+		t.Fatalf("Create older error: %v", err)
+	}
+	cp, err := Create(dir, Manifest{
+		RunID: "20260102-000000-bbbbbb",
+		Tasks: []Task{
+			{Key: TaskKey("a.md", 1), Status: StatusPending},
+			{Key: TaskKey("b.md", 1), Status: StatusPending},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Create error: %v", err)
+	}
+	if err := cp.Update(TaskKey("a.md", 1), func(task *Task) {
+		task.Status = StatusSubmitted
+		task.JobID = "job_1"
+	}); err != nil {
+		t.Fatalf("Update error: %v", err)
+	}
+	if err := cp.Update("missing#1", func(*Task) {}); err == nil {
+		t.Fatal("expected error for unknown task")
+	}
+	if err := cp.Finish(time.Date(2026, 1, 2, 0, 1, 0, 0, time.UTC)); err != nil {
+		t.Fatalf("Finish error: %v", err)
+	}
+
+	m, path, err := LoadLatest(dir)
+	if err != nil {
+		t.Fatalf("LoadLatest error: %v", err)
+	}
+	if path != cp.Path() || m.RunID != "20260102-000000-bbbbbb" {
+		t.Fatalf("latest=%s path=%s", m.RunID, path)
+	}
+	if m.Tasks[0].JobID != "job_1" || m.Tasks[0].Status != StatusSubmitted {
+		t.Fatalf("task not persisted: %+v", m.Tasks[0])
+	}
+	if m.Pending() != 2 || m.FinishedAt == "" {
+		t.Fatalf("pending=%d finished=%q", m.Pending(), m.FinishedAt)
+	}
+}
+
+func TestLoadLatest_Empty(t *testing.T) {
+	_, _, err := LoadLatest(filepath.Join(t.TempDir(), "missing"))
+	if !errors.Is(err, ErrNoRuns) {
+		t.Fatalf("unexpected err: %v", err)
+	}
+}
+
+func TestNilCheckpointIsNoop(t *testing.T) {
+	var cp *Checkpoint
+	if err := cp.Update("x", func(*Task) {}); err != nil {
+		t.Fatal(err)
+	}
+	if err := cp.Finish(time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	if cp.Path() != "" || len(cp.Snapshot().Tasks) != 0 {
+		t.Fatal("nil checkpoint should be empty")
+	}
+}
+
+func TestLoad_Corrupted(t *testing.T) {
+	p := filepath.Join(t.TempDir(), "x.json")
+	if err := os.WriteFile(p, []byte("{"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(p); err == nil {
+		t.Fatal("expected parse error")
+	}
+}
@@ -0,0 +1,44 @@
+package output
+
+import (
+	"io"
+	"os"
+	"strings"
+
+	"syl-listing-pro/internal/i18n"
+)
+
+// AppendCatalog 在持有文件锁的情况下把一节 Markdown 追加到 path 末尾，供多人或多个进程共同维护同一份总目录。
+// 文件不存在时创建（按 enc 写 BOM）；已有文件沿用其换行符，与上一节之间空一行。
+func AppendCatalog(path, section string, enc Encoding, nl Newlines) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0o644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if err := lockFile(f); err != nil {
+		return i18n.Errorf("锁定 %s 失败：%w", path, err)
+	}
+	defer unlockFile(f)
+	existing, err := io.ReadAll(f)
+	if err != nil {
+		return err
+	}
+	text := strings.Trim(section, "\n") + "\n"
+	if len(existing) > 0 {
+		enc = EncodingUTF8
+		nl = NewlinesLF
+		if strings.Contains(string(existing), "\r\n") {
+			nl = NewlinesCRLF
+		}
+		switch {
+		case strings.HasSuffix(string(existing), "\n\n"), strings.HasSuffix(string(existing), "\r\n\r\n"):
+		case strings.HasSuffix(string(existing), "\n"):
+			text = "\n" + text
+		default:
+			text = "\n\n" + text
+		}
+	}
+	_, err = f.Write(EncodeText(text, enc, nl))
+	return err
+}
@@ -0,0 +1,63 @@
+package output
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestAppendCatalog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "catalog.md")
+	if err := AppendCatalog(path, "## a\n\nA", EncodingUTF8BOM, NewlinesLF); err != nil {
+		t.Fatalf("AppendCatalog error: %v", err)
+	}
+	if err := AppendCatalog(path, "## b\n\nB\n", EncodingUTF8BOM, NewlinesLF); err != nil {
+		t.Fatalf("AppendCatalog error: %v", err)
+	}
+	b, _ := os.ReadFile(path)
+	if got, want := string(b), "\ufeff## a\n\nA\n\n## b\n\nB\n"; got != want {
+		t.Fatalf("catalog = %q, want %q", got, want)
+	}
+}
+
+func TestAppendCatalog_KeepsExistingNewlines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "catalog.md")
+	if err := os.WriteFile(path, []byte("# 总目录\r\n\r\n说明"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := AppendCatalog(path, "## a\n\nA", EncodingUTF8BOM, NewlinesLF); err != nil {
+		t.Fatalf("AppendCatalog error: %v", err)
+	}
+	b, _ := os.ReadFile(path)
+	if got, want := string(b), "# 总目录\r\n\r\n说明\r\n\r\n## a\r\n\r\nA\r\n"; got != want {
+		t.Fatalf("catalog = %q, want %q", got, want)
+	}
+}
+
+func TestAppendCatalog_Concurrent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "catalog.md")
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if err := AppendCatalog(path, fmt.Sprintf("## p%d\n\n%s", i, strings.Repeat("x", 4096)), EncodingUTF8, NewlinesLF); err != nil {
+				t.Error(err)
+			}
+		}(i)
+	}
+	wg.Wait()
+	b, _ := os.ReadFile(path)
+	sections := strings.Split(strings.TrimSpace(string(b)), "\n\n## ")
+	if len(sections) != 20 {
+		t.Fatalf("expected 20 intact sections, got %d", len(sections))
+	}
+	for _, s := range sections {
+		if !strings.HasSuffix(s, "\n\n"+strings.Repeat("x", 4096)) {
+			t.Fatalf("section interleaved: %.40q", s)
+		}
+	}
+}
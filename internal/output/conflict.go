@@ -0,0 +1,100 @@
+package output
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"syl-listing-pro/internal/i18n"
+)
+
+// ConflictPolicy 决定固定命名模式下目标文件已存在时的处理方式。
+// 空值表示沿用随机后缀命名，不会产生冲突。
+type ConflictPolicy string
+
+const (
+	ConflictOverwrite ConflictPolicy = "overwrite"
+	ConflictSkip      ConflictPolicy = "skip"
+	ConflictSuffix    ConflictPolicy = "suffix"
+)
+
+// ErrExists 表示 skip 策略下目标文件已存在。
+var ErrExists = errors.New("输出文件已存在")
+
+func ParseConflictPolicy(s string) (ConflictPolicy, error) {
+	switch p := ConflictPolicy(strings.ToLower(strings.TrimSpace(s))); p {
+	case "", ConflictOverwrite, ConflictSkip, ConflictSuffix:
+		return p, nil
+	}
+	return "", i18n.Errorf("--on-conflict 仅支持 overwrite、skip、suffix：%s", s)
+}
+
+// Pair 返回主稿/对照稿输出路径，后缀取自 langs（默认 _en/_cn）。policy 为空时等同
+// UniquePair；否则使用不带随机串的固定文件名 <base>[_<candidate>]_en.md，冲突按 policy
+// 处理。candidate 为 0 时不加序号。skip 策略下文件已存在时返回已有路径和 ErrExists。
+func Pair(outDir string, inputPath string, candidate int, policy ConflictPolicy, langs Langs) (string, string, error) {
+	if policy == "" {
+		_, en, cn, err := UniquePair(outDir, inputPath, langs)
+		return en, cn, err
+	}
+	if err := os.MkdirAll(outDir, 0o755); err != nil {
+		return "", "", err
+	}
+	base := outputBaseName(inputPath)
+	if candidate > 0 {
+		base = fmt.Sprintf("%s_%d", base, candidate)
+	}
+	en, cn := pairPaths(outDir, base, langs)
+	switch policy {
+	case ConflictOverwrite:
+		return en, cn, nil
+	case ConflictSkip:
+		if exists(en) || exists(cn) {
+			return en, cn, ErrExists
+		}
+		return en, cn, nil
+	case ConflictSuffix:
+		for i := 1; i < 1000; i++ {
+			if i > 1 {
+				en, cn = pairPaths(outDir, fmt.Sprintf("%s-%d", base, i), langs)
+			}
+			if exists(cn) {
+				continue
+			}
+			// 以独占创建 EN 文件占位，避免并发任务拿到同一个文件名。
+			f, err := os.OpenFile(en, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+			if err != nil {
+				if errors.Is(err, os.ErrExist) {
+					continue
+				}
+				return "", "", err
+			}
+			_ = f.Close()
+			return en, cn, nil
+		}
+		return "", "", i18n.Errorf("生成唯一文件名失败")
+	}
+	return "", "", i18n.Errorf("未知冲突策略：%s", policy)
+}
+
+// FinalPair 返回选定候选的最终稿路径 <base>_final_en.md / <base>_final_cn.md。
+func FinalPair(outDir, inputPath string, langs Langs) (string, string) {
+	return pairPaths(outDir, outputBaseName(inputPath)+"_final", langs)
+}
+
+// BestPair 返回自动评分选出的候选路径 <base>_best_en.md / <base>_best_cn.md。
+func BestPair(outDir, inputPath string, langs Langs) (string, string) {
+	return pairPaths(outDir, outputBaseName(inputPath)+"_best", langs)
+}
+
+func pairPaths(outDir, base string, langs Langs) (string, string) {
+	langs = langs.orDefault()
+	return longPath(filepath.Join(outDir, base+"_"+langs.Primary+".md")), longPath(filepath.Join(outDir, base+"_"+langs.Secondary+".md"))
+}
+
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
@@ -0,0 +1,95 @@
+package output
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseConflictPolicy(t *testing.T) {
+	for _, s := range []string{"", "overwrite", "SKIP", " suffix "} {
+		if _, err := ParseConflictPolicy(s); err != nil {
+			t.Fatalf("ParseConflictPolicy(%q) error: %v", s, err)
+		}
+	}
+	if _, err := ParseConflictPolicy("rename"); err == nil {
+		t.Fatal("expected invalid policy error")
+	}
+}
+
+func TestPair_Deterministic(t *testing.T) {
+	dir := t.TempDir()
+	en, cn, err := Pair(dir, "pinpai.md", 0, ConflictOverwrite, DefaultLangs)
+	if err != nil {
+		t.Fatalf("Pair error: %v", err)
+	}
+	if filepath.Base(en) != "pinpai_en.md" || filepath.Base(cn) != "pinpai_cn.md" {
+		t.Fatalf("unexpected paths: %s %s", en, cn)
+	}
+	en, _, err = Pair(dir, "pinpai.md", 2, ConflictOverwrite, DefaultLangs)
+	if err != nil || filepath.Base(en) != "pinpai_2_en.md" {
+		t.Fatalf("unexpected candidate path: %s err=%v", en, err)
+	}
+}
+
+func TestPair_ConflictPolicies(t *testing.T) {
+	dir := t.TempDir()
+	existing := filepath.Join(dir, "pinpai_en.md")
+	if err := os.WriteFile(existing, []byte("old"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	en, _, err := Pair(dir, "pinpai.md", 0, ConflictOverwrite, DefaultLangs)
+	if err != nil || en != existing {
+		t.Fatalf("overwrite: en=%s err=%v", en, err)
+	}
+
+	en, _, err = Pair(dir, "pinpai.md", 0, ConflictSkip, DefaultLangs)
+	if !errors.Is(err, ErrExists) || en != existing {
+		t.Fatalf("skip: en=%s err=%v", en, err)
+	}
+
+	en, cn, err := Pair(dir, "pinpai.md", 0, ConflictSuffix, DefaultLangs)
+	if err != nil {
+		t.Fatalf("suffix error: %v", err)
+	}
+	if filepath.Base(en) != "pinpai-2_en.md" || filepath.Base(cn) != "pinpai-2_cn.md" {
+		t.Fatalf("unexpected suffix paths: %s %s", en, cn)
+	}
+	en, _, err = Pair(dir, "pinpai.md", 0, ConflictSuffix, DefaultLangs)
+	if err != nil || filepath.Base(en) != "pinpai-3_en.md" {
+		t.Fatalf("second suffix: en=%s err=%v", en, err)
+	}
+}
+
+func TestFinalPair(t *testing.T) {
+	en, cn := FinalPair("out", "dir/pinpai.md", Langs{})
+	if en != filepath.Join("out", "pinpai_final_en.md") || cn != filepath.Join("out", "pinpai_final_cn.md") {
+		t.Fatalf("unexpected final paths: %s %s", en, cn)
+	}
+}
+
+func TestBestPair(t *testing.T) {
+	en, cn := BestPair("out", "dir/pinpai.md", Langs{})
+	if en != filepath.Join("out", "pinpai_best_en.md") || cn != filepath.Join("out", "pinpai_best_cn.md") {
+		t.Fatalf("unexpected best paths: %s %s", en, cn)
+	}
+}
+
+func TestPair_Langs(t *testing.T) {
+	dir := t.TempDir()
+	en, cn, err := Pair(dir, "pinpai.md", 0, ConflictOverwrite, NewLangs([]string{"EN", "de"}))
+	if err != nil || filepath.Base(en) != "pinpai_en.md" || filepath.Base(cn) != "pinpai_de.md" {
+		t.Fatalf("en=%s cn=%s err=%v", en, cn, err)
+	}
+	if got := NewLangs([]string{"en"}); got != DefaultLangs {
+		t.Fatalf("short list should fall back: %+v", got)
+	}
+	if got := NewLangs([]string{"en", "../x"}); got != DefaultLangs {
+		t.Fatalf("invalid code should fall back: %+v", got)
+	}
+	if LangOf(cn) != "de" || LangOf("notes.md") != "" {
+		t.Fatalf("unexpected LangOf")
+	}
+}
@@ -0,0 +1,56 @@
+package output
+
+import (
+	"strings"
+
+	"syl-listing-pro/internal/i18n"
+)
+
+// Encoding 为 Markdown 产物的文本编码。
+type Encoding string
+
+const (
+	EncodingUTF8    Encoding = "utf8"
+	EncodingUTF8BOM Encoding = "utf8bom"
+)
+
+// Newlines 为 Markdown 产物的换行符。
+type Newlines string
+
+const (
+	NewlinesLF   Newlines = "lf"
+	NewlinesCRLF Newlines = "crlf"
+)
+
+func ParseEncoding(s string) (Encoding, error) {
+	switch e := Encoding(strings.ToLower(strings.TrimSpace(s))); e {
+	case "", EncodingUTF8, "utf-8":
+		return EncodingUTF8, nil
+	case EncodingUTF8BOM, "utf-8-bom":
+		return EncodingUTF8BOM, nil
+	}
+	return "", i18n.Errorf("--out-encoding 仅支持 utf8、utf8bom：%s", s)
+}
+
+func ParseNewlines(s string) (Newlines, error) {
+	switch n := Newlines(strings.ToLower(strings.TrimSpace(s))); n {
+	case "", NewlinesLF:
+		return NewlinesLF, nil
+	case NewlinesCRLF:
+		return n, nil
+	}
+	return "", i18n.Errorf("--out-newlines 仅支持 lf、crlf：%s", s)
+}
+
+const utf8BOM = "\ufeff"
+
+// EncodeText 按编码与换行符转换文本；原有的 CRLF 先统一为 LF，避免产生 \r\r\n。
+func EncodeText(text string, enc Encoding, nl Newlines) []byte {
+	if nl == NewlinesCRLF {
+		text = strings.ReplaceAll(strings.ReplaceAll(text, "\r\n", "\n"), "\n", "\r\n")
+	}
+	if enc == EncodingUTF8BOM && !strings.HasPrefix(text, utf8BOM) {
+		text = utf8BOM + text
+	}
+	return []byte(text)
+}
@@ -0,0 +1,26 @@
+package output
+
+import "testing"
+
+func TestEncodeText(t *testing.T) {
+	enc, err := ParseEncoding("UTF8BOM")
+	if err != nil {
+		t.Fatal(err)
+	}
+	nl, err := ParseNewlines("crlf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(EncodeText("# A\nb\r\n", enc, nl)); got != "\ufeff# A\r\nb\r\n" {
+		t.Fatalf("got=%q", got)
+	}
+	if got := string(EncodeText("# A\n", EncodingUTF8, NewlinesLF)); got != "# A\n" {
+		t.Fatalf("default should keep text unchanged, got=%q", got)
+	}
+	if _, err := ParseEncoding("gbk"); err == nil {
+		t.Fatal("expected invalid encoding error")
+	}
+	if _, err := ParseNewlines("cr"); err == nil {
+		t.Fatal("expected invalid newlines error")
+	}
+}
@@ -0,0 +1,147 @@
+package output
+
+import (
+	"encoding/csv"
+	"fmt"
+	"html"
+	"io"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"syl-listing-pro/internal/i18n"
+)
+
+// ExportFormat 为面向其他平台批量导入的汇总格式。
+type ExportFormat string
+
+const (
+	// ExportShopify 为 Shopify 商品 CSV。
+	ExportShopify ExportFormat = "shopify"
+	// ExportEbay 为 eBay File Exchange CSV（Add 操作）。
+	ExportEbay ExportFormat = "ebay"
+)
+
+// ebayTitleLimit 为 eBay 标题的最大字符数。
+const ebayTitleLimit = 80
+
+// ParseExportFormats 校验 --format 并去重，保持给定顺序。
+func ParseExportFormats(values []string) ([]ExportFormat, error) {
+	var out []ExportFormat
+	seen := map[ExportFormat]bool{}
+	for _, v := range values {
+		f := ExportFormat(strings.ToLower(strings.TrimSpace(v)))
+		switch f {
+		case "":
+			continue
+		case ExportShopify, ExportEbay:
+		default:
+			return nil, i18n.Errorf("--format 仅支持 shopify、ebay：%s", v)
+		}
+		if !seen[f] {
+			seen[f] = true
+			out = append(out, f)
+		}
+	}
+	return out, nil
+}
+
+// ExportItem 为导出的一条商品文案。
+type ExportItem struct {
+	// Handle 为商品唯一标识（Shopify handle / eBay Custom label），由调用方保证不重复。
+	Handle      string
+	Title       string
+	Bullets     []string
+	Description string
+}
+
+// BodyHTML 返回五点列表加详情描述的 HTML，两个平台的描述字段共用。
+func (it ExportItem) BodyHTML() string {
+	var b strings.Builder
+	if len(it.Bullets) > 0 {
+		b.WriteString("<ul>")
+		for _, item := range it.Bullets {
+			fmt.Fprintf(&b, "<li>%s</li>", html.EscapeString(item))
+		}
+		b.WriteString("</ul>")
+	}
+	for _, para := range strings.Split(it.Description, "\n\n") {
+		if para = strings.TrimSpace(para); para != "" {
+			fmt.Fprintf(&b, "<p>%s</p>", strings.ReplaceAll(html.EscapeString(para), "\n", "<br>"))
+		}
+	}
+	return b.String()
+}
+
+// WriteShopifyCSV 按 Shopify 商品导入模板写出；价格、库存等需在导入前补充的列留空，默认不上架。
+func WriteShopifyCSV(w io.Writer, items []ExportItem) error {
+	cw := csv.NewWriter(w)
+	_ = cw.Write([]string{"Handle", "Title", "Body (HTML)", "Vendor", "Type", "Tags", "Published", "Status"})
+	for _, it := range items {
+		_ = cw.Write([]string{it.Handle, it.Title, it.BodyHTML(), "", "", "", "FALSE", "draft"})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// ebaySites 为站点到 eBay SiteID、国家与币种的对应；没有对应站点时使用美国站。
+var ebaySites = map[string][3]string{
+	"us": {"US", "US", "USD"},
+	"de": {"Germany", "DE", "EUR"},
+	"fr": {"France", "FR", "EUR"},
+}
+
+// WriteEbayCSV 按 eBay File Exchange 模板写出 Add 行；分类、价格、数量等必填项留空，需在上传前补充。
+// 标题超过 80 个字符时截断，返回被截断的 Handle。
+func WriteEbayCSV(w io.Writer, items []ExportItem, marketplace string) ([]string, error) {
+	site, ok := ebaySites[strings.ToLower(marketplace)]
+	if !ok {
+		site = ebaySites["us"]
+	}
+	cw := csv.NewWriter(w)
+	action := fmt.Sprintf("*Action(SiteID=%s|Country=%s|Currency=%s|Version=1193|CC=UTF-8)", site[0], site[1], site[2])
+	_ = cw.Write([]string{action, "CustomLabel", "*Category", "*Title", "*Description", "*ConditionID", "*Format", "*Duration", "*StartPrice", "*Quantity", "*Location"})
+	var truncated []string
+	for _, it := range items {
+		title := it.Title
+		if utf8.RuneCountInString(title) > ebayTitleLimit {
+			title = truncateWords(title, ebayTitleLimit)
+			truncated = append(truncated, it.Handle)
+		}
+		_ = cw.Write([]string{"Add", it.Handle, "", title, it.BodyHTML(), "", "FixedPrice", "GTC", "", "", ""})
+	}
+	cw.Flush()
+	return truncated, cw.Error()
+}
+
+// truncateWords 把 s 截到不超过 limit 个字符，尽量在单词边界处断开。
+func truncateWords(s string, limit int) string {
+	r := []rune(s)
+	if len(r) <= limit {
+		return s
+	}
+	cut := limit
+	for i := limit; i > limit/2; i-- {
+		if unicode.IsSpace(r[i]) {
+			cut = i
+			break
+		}
+	}
+	return strings.TrimRightFunc(string(r[:cut]), func(c rune) bool { return unicode.IsSpace(c) || unicode.IsPunct(c) })
+}
+
+// Handle 把标题转为 Shopify 风格的 handle：小写字母数字，其他字符改为连字符。
+func Handle(s string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(s) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+			dash = false
+		} else if !dash && b.Len() > 0 {
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+	return strings.Trim(b.String(), "-")
+}
@@ -0,0 +1,83 @@
+package output
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestParseExportFormats(t *testing.T) {
+	got, err := ParseExportFormats([]string{"Shopify", " ebay", "shopify", ""})
+	if err != nil {
+		t.Fatalf("ParseExportFormats error: %v", err)
+	}
+	if len(got) != 2 || got[0] != ExportShopify || got[1] != ExportEbay {
+		t.Fatalf("unexpected formats: %v", got)
+	}
+	if _, err := ParseExportFormats([]string{"amazon"}); err == nil {
+		t.Fatal("expected unsupported format error")
+	}
+}
+
+func TestExportItemBodyHTML(t *testing.T) {
+	it := ExportItem{Bullets: []string{"A & B", "<c>"}, Description: "第一段\n续行\n\n第二段"}
+	want := "<ul><li>A &amp; B</li><li>&lt;c&gt;</li></ul><p>第一段<br>续行</p><p>第二段</p>"
+	if got := it.BodyHTML(); got != want {
+		t.Fatalf("BodyHTML = %q, want %q", got, want)
+	}
+}
+
+func TestWriteShopifyCSV(t *testing.T) {
+	var buf bytes.Buffer
+	items := []ExportItem{{Handle: "yoga-mat", Title: "Yoga Mat, Non-Slip", Bullets: []string{"Thick"}}}
+	if err := WriteShopifyCSV(&buf, items); err != nil {
+		t.Fatalf("WriteShopifyCSV error: %v", err)
+	}
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("invalid csv: %v", err)
+	}
+	if len(rows) != 2 || rows[0][0] != "Handle" || rows[0][2] != "Body (HTML)" {
+		t.Fatalf("unexpected header: %v", rows)
+	}
+	if rows[1][1] != "Yoga Mat, Non-Slip" || rows[1][2] != "<ul><li>Thick</li></ul>" || rows[1][7] != "draft" {
+		t.Fatalf("unexpected row: %v", rows[1])
+	}
+}
+
+func TestWriteEbayCSV_TruncatesTitle(t *testing.T) {
+	long := strings.Repeat("Durable ", 12) + "Mat"
+	items := []ExportItem{{Handle: "mat", Title: long}, {Handle: "bottle", Title: "Bottle"}}
+	var buf bytes.Buffer
+	truncated, err := WriteEbayCSV(&buf, items, "DE")
+	if err != nil {
+		t.Fatalf("WriteEbayCSV error: %v", err)
+	}
+	if len(truncated) != 1 || truncated[0] != "mat" {
+		t.Fatalf("unexpected truncated list: %v", truncated)
+	}
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("invalid csv: %v", err)
+	}
+	if !strings.Contains(rows[0][0], "SiteID=Germany") || !strings.Contains(rows[0][0], "Currency=EUR") {
+		t.Fatalf("unexpected action header: %s", rows[0][0])
+	}
+	title := rows[1][3]
+	if utf8.RuneCountInString(title) > ebayTitleLimit || strings.HasSuffix(title, " ") || !strings.HasSuffix(title, "Durable") {
+		t.Fatalf("unexpected truncated title: %q", title)
+	}
+	if rows[2][0] != "Add" || rows[2][3] != "Bottle" {
+		t.Fatalf("unexpected row: %v", rows[2])
+	}
+}
+
+func TestHandle(t *testing.T) {
+	for in, want := range map[string]string{"Yoga Mat (Blue)": "yoga-mat-blue", "--瑜伽垫_2--": "瑜伽垫-2", "": ""} {
+		if got := Handle(in); got != want {
+			t.Fatalf("Handle(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package output
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Langs 为一对产物的语言后缀：Primary 对应主稿（原 EN），Secondary 对应对照稿（原 CN）。
+// 零值等同 DefaultLangs。
+type Langs struct {
+	Primary   string
+	Secondary string
+}
+
+var DefaultLangs = Langs{Primary: "en", Secondary: "cn"}
+
+var langCodePattern = regexp.MustCompile(`^[a-z]{2,3}$`)
+
+// NewLangs 由服务端返回的语言列表得到后缀；不足两项或含非法代码时回退到 en/cn。
+func NewLangs(langs []string) Langs {
+	if len(langs) < 2 {
+		return DefaultLangs
+	}
+	l := Langs{Primary: strings.ToLower(strings.TrimSpace(langs[0])), Secondary: strings.ToLower(strings.TrimSpace(langs[1]))}
+	if !langCodePattern.MatchString(l.Primary) || !langCodePattern.MatchString(l.Secondary) || l.Primary == l.Secondary {
+		return DefaultLangs
+	}
+	return l
+}
+
+func (l Langs) orDefault() Langs {
+	if l.Primary == "" || l.Secondary == "" {
+		return DefaultLangs
+	}
+	return l
+}
+
+// LangOf 返回产物文件名中的语言后缀，例如 x_de.md -> de；无法识别时返回空串。
+func LangOf(path string) string {
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	i := strings.LastIndex(name, "_")
+	if i < 0 || !langCodePattern.MatchString(name[i+1:]) {
+		return ""
+	}
+	return name[i+1:]
+}
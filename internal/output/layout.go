@@ -0,0 +1,40 @@
+package output
+
+import (
+	"path/filepath"
+	"strings"
+	"time"
+
+	"syl-listing-pro/internal/i18n"
+)
+
+// Layout 决定输出文件在输出目录下的组织方式。
+type Layout string
+
+const (
+	LayoutFlat     Layout = "flat"
+	LayoutPerInput Layout = "per-input"
+	LayoutPerDate  Layout = "per-date"
+)
+
+func ParseLayout(s string) (Layout, error) {
+	switch l := Layout(strings.ToLower(strings.TrimSpace(s))); l {
+	case "", LayoutFlat:
+		return LayoutFlat, nil
+	case LayoutPerInput, LayoutPerDate:
+		return l, nil
+	}
+	return "", i18n.Errorf("--out-layout 仅支持 flat、per-input、per-date：%s", s)
+}
+
+// LayoutDir 返回某个输入文件的实际输出目录：per-input 为 out/<输入文件名>/，
+// per-date 为 out/<YYYY-MM-DD>/，flat 直接使用 out。
+func LayoutDir(outDir string, inputPath string, layout Layout, now time.Time) string {
+	switch layout {
+	case LayoutPerInput:
+		return filepath.Join(outDir, outputBaseName(inputPath))
+	case LayoutPerDate:
+		return filepath.Join(outDir, now.Format("2006-01-02"))
+	}
+	return outDir
+}
@@ -0,0 +1,32 @@
+package output
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLayoutDir(t *testing.T) {
+	now := time.Date(2026, 3, 5, 10, 0, 0, 0, time.Local)
+	cases := []struct {
+		layout string
+		want   string
+	}{
+		{"", "out"},
+		{"flat", "out"},
+		{"per-input", filepath.Join("out", "pinpai")},
+		{"PER-DATE", filepath.Join("out", "2026-03-05")},
+	}
+	for _, c := range cases {
+		l, err := ParseLayout(c.layout)
+		if err != nil {
+			t.Fatalf("ParseLayout(%q) error: %v", c.layout, err)
+		}
+		if got := LayoutDir("out", "/a/b/pinpai.md", l, now); got != c.want {
+			t.Fatalf("LayoutDir(%q)=%s want=%s", c.layout, got, c.want)
+		}
+	}
+	if _, err := ParseLayout("nested"); err == nil {
+		t.Fatal("expected invalid layout error")
+	}
+}
@@ -0,0 +1,8 @@
+//go:build !windows
+
+package output
+
+// longPath 仅在 Windows 上需要处理，其他平台原样返回。
+func longPath(p string) string {
+	return p
+}
@@ -0,0 +1,15 @@
+package output
+
+import "path/filepath"
+
+// longPath 在路径超过 MAX_PATH 时改为带 \\?\ 前缀的绝对路径。
+func longPath(p string) string {
+	abs, err := filepath.Abs(p)
+	if err != nil {
+		return p
+	}
+	if lp := windowsLongPath(abs); lp != abs {
+		return lp
+	}
+	return p
+}
@@ -2,18 +2,24 @@ package output
 
 import (
 	"crypto/rand"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"math/big"
 	"os"
 	"path/filepath"
 	"strings"
+	"unicode/utf16"
+
+	"syl-listing-pro/internal/i18n"
 )
 
 const alphabet = "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
 
 func randomN(n int) (string, error) {
 	if n <= 0 {
-		return "", fmt.Errorf("随机长度必须大于0")
+		return "", i18n.Errorf("随机长度必须大于0")
 	}
 	b := make([]byte, n)
 	for i := range b {
@@ -26,6 +32,12 @@ func randomN(n int) (string, error) {
 	return string(b), nil
 }
 
+// maxBaseRunes 为输出文件基础名的最大字符数；长中文文件名再加上输出目录、候选序号与语言后缀，
+// 容易超过 Windows 的 MAX_PATH（260），超出时截断并附加原名的短哈希以免不同输入撞名。
+const maxBaseRunes = 80
+
+// outputBaseName 由输入文件名得到输出文件基础名：去掉扩展名，替换 Windows 不允许的字符，
+// 避开 CON、NUL、COM1 等保留设备名，并按 maxBaseRunes 截断。各平台结果一致，产物可直接同步到 Windows。
 func outputBaseName(inputPath string) string {
 	base := filepath.Base(strings.TrimSpace(inputPath))
 	if base == "" || base == "." {
@@ -35,14 +47,67 @@ func outputBaseName(inputPath string) string {
 	if ext != "" {
 		base = strings.TrimSuffix(base, ext)
 	}
-	base = strings.TrimSpace(base)
+	base = sanitizeBaseName(base)
 	if base == "" || base == "." {
 		return "listing"
 	}
 	return base
 }
 
-func UniquePair(outDir string, inputPath string) (string, string, string, error) {
+// sanitizeBaseName 替换 Windows 文件名中的非法字符，去掉结尾的点与空格（Windows 会静默删除），
+// 保留设备名前加下划线，过长时截断。
+func sanitizeBaseName(base string) string {
+	base = strings.Map(func(r rune) rune {
+		if r < 0x20 || strings.ContainsRune(`<>:"/\|?*`, r) {
+			return '_'
+		}
+		return r
+	}, strings.TrimSpace(base))
+	base = strings.TrimRight(base, ". ")
+	if r := []rune(base); len(r) > maxBaseRunes {
+		sum := sha1.Sum([]byte(base))
+		base = strings.TrimRight(string(r[:maxBaseRunes-7]), ". ") + "~" + hex.EncodeToString(sum[:])[:6]
+	}
+	if isReservedName(base) {
+		base = "_" + base
+	}
+	return base
+}
+
+// isReservedName 判断基础名是否为 Windows 保留设备名；带扩展名（如 con.backup）同样不可用。
+func isReservedName(base string) bool {
+	stem, _, _ := strings.Cut(base, ".")
+	switch strings.ToUpper(strings.TrimRight(stem, " ")) {
+	case "CON", "PRN", "AUX", "NUL",
+		"COM1", "COM2", "COM3", "COM4", "COM5", "COM6", "COM7", "COM8", "COM9",
+		"LPT1", "LPT2", "LPT3", "LPT4", "LPT5", "LPT6", "LPT7", "LPT8", "LPT9":
+		return true
+	}
+	return false
+}
+
+// windowsLongPath 在 Windows 绝对路径达到 MAX_PATH 时加 \\?\ 前缀（UNC 路径为 \\?\UNC\），
+// 使 Word 转换等外部程序也能访问；未超长、已带前缀或非绝对路径原样返回。
+func windowsLongPath(abs string) string {
+	if len(utf16.Encode([]rune(abs))) < windowsMaxPath || strings.HasPrefix(abs, `\\?\`) {
+		return abs
+	}
+	if strings.HasPrefix(abs, `\\`) {
+		return `\\?\UNC\` + abs[2:]
+	}
+	if len(abs) >= 3 && abs[1] == ':' && (abs[2] == '\\' || abs[2] == '/') {
+		return `\\?\` + strings.ReplaceAll(abs, "/", `\`)
+	}
+	return abs
+}
+
+// windowsMaxPath 为 Windows 传统路径长度上限（含结尾 NUL）。
+const windowsMaxPath = 260
+
+// UniquePair 返回带 4 位随机串的主稿/对照稿路径 <base>_<id>_en.md / _cn.md。
+// 两个 Markdown 以 O_CREATE|O_EXCL 独占创建为占位文件，并发任务不会在检查与写入之间拿到同一个随机串；
+// 同名的 .docx 由已占位的 Markdown 一并保留，仍需确认不存在残留文件。任一名字已被占用时删除本次占位并换一个随机串。
+func UniquePair(outDir string, inputPath string, langs Langs) (string, string, string, error) {
 	if err := os.MkdirAll(outDir, 0o755); err != nil {
 		return "", "", "", err
 	}
@@ -52,15 +117,43 @@ func UniquePair(outDir string, inputPath string) (string, string, string, error)
 		if err != nil {
 			return "", "", "", err
 		}
-		en := filepath.Join(outDir, fmt.Sprintf("%s_%s_en.md", base, s))
-		cn := filepath.Join(outDir, fmt.Sprintf("%s_%s_cn.md", base, s))
-		if _, err := os.Stat(en); err == nil {
-			continue
+		en, cn := pairPaths(outDir, fmt.Sprintf("%s_%s", base, s), langs)
+		ok, err := reservePair(en, cn)
+		if err != nil {
+			return "", "", "", err
+		}
+		if ok {
+			return s, en, cn, nil
+		}
+	}
+	return "", "", "", i18n.Errorf("生成唯一文件名失败")
+}
+
+// reservePair 独占创建 en、cn 占位文件并确认对应 .docx 不存在；名字已被占用时撤销本次创建的占位并返回 false。
+func reservePair(en, cn string) (bool, error) {
+	var created []string
+	release := func() {
+		for _, p := range created {
+			_ = os.Remove(p)
 		}
-		if _, err := os.Stat(cn); err == nil {
-			continue
+	}
+	for _, p := range []string{en, cn} {
+		f, err := os.OpenFile(p, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+		if err != nil {
+			release()
+			if errors.Is(err, os.ErrExist) {
+				return false, nil
+			}
+			return false, err
+		}
+		_ = f.Close()
+		created = append(created, p)
+	}
+	for _, p := range []string{en, cn} {
+		if exists(strings.TrimSuffix(p, filepath.Ext(p)) + ".docx") {
+			release()
+			return false, nil
 		}
-		return s, en, cn, nil
 	}
-	return "", "", "", fmt.Errorf("生成唯一文件名失败")
+	return true, nil
 }
@@ -10,7 +10,7 @@ import (
 
 func TestUniquePair_Success(t *testing.T) {
 	dir := t.TempDir()
-	s, en, cn, err := UniquePair(dir, "pinpai.md")
+	s, en, cn, err := UniquePair(dir, "pinpai.md", DefaultLangs)
 	if err != nil {
 		t.Fatalf("UniquePair error: %v", err)
 	}
@@ -31,7 +31,7 @@ func TestUniquePair_MkdirError(t *testing.T) {
 	if err := os.WriteFile(fileAsDir, []byte("x"), 0o644); err != nil {
 		t.Fatal(err)
 	}
-	if _, _, _, err := UniquePair(fileAsDir, "x.md"); err == nil {
+	if _, _, _, err := UniquePair(fileAsDir, "x.md", DefaultLangs); err == nil {
 		t.Fatal("expected mkdir error")
 	}
 }
@@ -48,7 +48,7 @@ func TestUniquePair_Concurrent_NoCollision(t *testing.T) {
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			s, en, cn, err := UniquePair(dir, "pinpai.md")
+			s, en, cn, err := UniquePair(dir, "pinpai.md", DefaultLangs)
 			if err != nil {
 				mu.Lock()
 				errs = append(errs, err)
@@ -74,3 +74,95 @@ func TestUniquePair_Concurrent_NoCollision(t *testing.T) {
 		t.Fatalf("unique id count=%d want=%d", len(seen), n)
 	}
 }
+
+func TestOutputBaseName_Sanitize(t *testing.T) {
+	cases := map[string]string{
+		"pinpai.md":            "pinpai",
+		"  瑜伽垫 .md":            "瑜伽垫",
+		"a:b|c?.md":            "a_b_c_",
+		"trailing dots...md":   "trailing dots",
+		"CON.md":               "_CON",
+		"nul.backup.md":        "_nul.backup",
+		"console.md":           "console",
+		filepath.Join("d", ""): "d",
+		"":                     "listing",
+	}
+	for in, want := range cases {
+		if got := outputBaseName(in); got != want {
+			t.Fatalf("outputBaseName(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestOutputBaseName_TruncatesLongNames(t *testing.T) {
+	long := strings.Repeat("超长的中文需求文件名", 20)
+	a := outputBaseName(long + "甲.md")
+	b := outputBaseName(long + "乙.md")
+	if n := len([]rune(a)); n != maxBaseRunes {
+		t.Fatalf("base len = %d, want %d", n, maxBaseRunes)
+	}
+	if a == b {
+		t.Fatalf("truncated names collide: %s", a)
+	}
+	if outputBaseName(long+"甲.md") != a {
+		t.Fatal("truncation is not deterministic")
+	}
+}
+
+func TestWindowsLongPath(t *testing.T) {
+	short := `C:\out\pinpai_en.md`
+	if got := windowsLongPath(short); got != short {
+		t.Fatalf("short path changed: %s", got)
+	}
+	long := `C:\out\` + strings.Repeat("x", 260) + `_en.md`
+	if got := windowsLongPath(long); got != `\\?\`+long {
+		t.Fatalf("unexpected long path: %s", got)
+	}
+	if got := windowsLongPath(`C:/out/` + strings.Repeat("x", 260)); got != `\\?\C:\out\`+strings.Repeat("x", 260) {
+		t.Fatalf("slashes not normalized: %s", got)
+	}
+	unc := `\\server\share\` + strings.Repeat("x", 260)
+	if got := windowsLongPath(unc); got != `\\?\UNC\server\share\`+strings.Repeat("x", 260) {
+		t.Fatalf("unexpected UNC path: %s", got)
+	}
+	if got := windowsLongPath(`\\?\` + long); got != `\\?\`+long {
+		t.Fatalf("prefixed path changed: %s", got)
+	}
+}
+
+func TestUniquePair_ReservesPlaceholders(t *testing.T) {
+	dir := t.TempDir()
+	_, en, cn, err := UniquePair(dir, "pinpai.md", DefaultLangs)
+	if err != nil {
+		t.Fatalf("UniquePair error: %v", err)
+	}
+	for _, p := range []string{en, cn} {
+		if _, err := os.Stat(p); err != nil {
+			t.Fatalf("placeholder not created: %v", err)
+		}
+	}
+}
+
+func TestReservePair_RejectsTakenNames(t *testing.T) {
+	dir := t.TempDir()
+	en, cn := pairPaths(dir, "pinpai_abcd", DefaultLangs)
+	if err := os.WriteFile(strings.TrimSuffix(cn, ".md")+".docx", []byte("x"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	ok, err := reservePair(en, cn)
+	if err != nil || ok {
+		t.Fatalf("reservePair = %v, %v; want false", ok, err)
+	}
+	for _, p := range []string{en, cn} {
+		if _, err := os.Stat(p); !os.IsNotExist(err) {
+			t.Fatalf("placeholder %s not released", p)
+		}
+	}
+	if err := os.WriteFile(cn, []byte("x"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	en2, cn2 := pairPaths(dir, "other", DefaultLangs)
+	if ok, err := reservePair(en2, cn2); err != nil || !ok {
+		t.Fatalf("reservePair = %v, %v; want true", ok, err)
+	}
+}
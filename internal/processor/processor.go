@@ -0,0 +1,66 @@
+// Package processor 定义任务成功后的结果处理扩展点。
+//
+// 处理器收到生成结果与本地产物路径，可用于 CMS 上传、翻译记忆库同步等，
+// 无需改动生成主流程。除 Go 接口外，也支持以外部命令实现的处理器：
+// 结果以一行 JSON 写入命令的标准输入，退出码非 0 视为处理失败。
+package processor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"syl-listing-pro/internal/client"
+	"syl-listing-pro/internal/i18n"
+)
+
+// Result 是传给处理器的单个任务结果。
+type Result struct {
+	RunID     string            `json:"run_id"`
+	InputPath string            `json:"input_path"`
+	Index     int               `json:"index"`
+	JobID     string            `json:"job_id"`
+	Listing   client.ResultResp `json:"listing"`
+	Outputs   []string          `json:"outputs"`
+}
+
+// Processor 处理单个成功任务的结果。
+type Processor interface {
+	Name() string
+	Process(ctx context.Context, res Result) error
+}
+
+// Exec 是外部命令处理器，命令行按空白切分，不经过 shell。
+type Exec struct {
+	Command string
+}
+
+func (e Exec) Name() string {
+	return e.Command
+}
+
+func (e Exec) Process(ctx context.Context, res Result) error {
+	fields := strings.Fields(e.Command)
+	if len(fields) == 0 {
+		return i18n.Errorf("处理器命令为空")
+	}
+	payload, err := json.Marshal(res)
+	if err != nil {
+		return err
+	}
+	cmd := exec.CommandContext(ctx, fields[0], fields[1:]...)
+	cmd.Stdin = bytes.NewReader(append(payload, '\n'))
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if r := []rune(msg); len(r) > 300 {
+			msg = string(r[:300])
+		}
+		return fmt.Errorf("%w: %s", err, msg)
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package processor
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"syl-listing-pro/internal/client"
+)
+
+func TestExec_ReceivesResultOnStdin(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell script test is unix-only")
+	}
+	dir := t.TempDir()
+	got := filepath.Join(dir, "got.json")
+	script := filepath.Join(dir, "proc.sh")
+	if err := os.WriteFile(script, []byte("#!/bin/sh\ncat > \"$1\"\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	p := Exec{Command: script + " " + got}
+	res := Result{RunID: "r1", InputPath: "/in/a.md", Index: 1, JobID: "j1", Listing: client.ResultResp{ENMarkdown: "# en"}, Outputs: []string{"/out/a_en.md"}}
+	if err := p.Process(context.Background(), res); err != nil {
+		t.Fatalf("Process error: %v", err)
+	}
+	b, err := os.ReadFile(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded Result
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		t.Fatalf("invalid payload %q: %v", b, err)
+	}
+	if decoded.JobID != "j1" || decoded.Listing.ENMarkdown != "# en" || len(decoded.Outputs) != 1 {
+		t.Fatalf("unexpected payload: %+v", decoded)
+	}
+}
+
+func TestExec_NonZeroExit(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell script test is unix-only")
+	}
+	script := filepath.Join(t.TempDir(), "fail.sh")
+	if err := os.WriteFile(script, []byte("#!/bin/sh\necho boom >&2\nexit 3\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	err := Exec{Command: script}.Process(context.Background(), Result{})
+	if err == nil || !strings.Contains(err.Error(), "boom") {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if err := (Exec{}).Process(context.Background(), Result{}); err == nil {
+		t.Fatal("expected empty command error")
+	}
+}
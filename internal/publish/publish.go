@@ -0,0 +1,211 @@
+// Package publish 把生成的文件上传到对象存储（S3、阿里云 OSS、GCS）。
+// 三家均通过各自的 S3 兼容接口访问，使用 AWS Signature V4 签名。
+package publish
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"syl-listing-pro/internal/i18n"
+)
+
+const (
+	ProviderS3  = "s3"
+	ProviderOSS = "oss"
+	ProviderGCS = "gcs"
+)
+
+type Config struct {
+	Provider        string
+	Endpoint        string
+	Region          string
+	Bucket          string
+	Prefix          string
+	AccessKeyID     string
+	SecretAccessKey string
+	// PathStyle 为 true 时使用 <endpoint>/<bucket>/<key>，否则使用 <bucket>.<endpoint>/<key>。
+	PathStyle bool
+}
+
+// Uploader 上传本地文件并返回远端 URL。
+type Uploader interface {
+	Upload(ctx context.Context, localPath string, key string) (string, error)
+}
+
+type s3Uploader struct {
+	cfg      Config
+	endpoint *url.URL
+	http     *http.Client
+	now      func() time.Time
+}
+
+func New(cfg Config) (Uploader, error) {
+	cfg.Provider = strings.ToLower(strings.TrimSpace(cfg.Provider))
+	if cfg.Bucket == "" {
+		return nil, i18n.Errorf("未配置上传 bucket")
+	}
+	if cfg.AccessKeyID == "" || cfg.SecretAccessKey == "" {
+		return nil, i18n.Errorf("未配置上传凭证")
+	}
+	if cfg.Region == "" {
+		switch cfg.Provider {
+		case ProviderGCS:
+			cfg.Region = "auto"
+		case ProviderOSS:
+			cfg.Region = "cn-hangzhou"
+		default:
+			cfg.Region = "us-east-1"
+		}
+	}
+	if cfg.Endpoint == "" {
+		switch cfg.Provider {
+		case ProviderS3:
+			cfg.Endpoint = fmt.Sprintf("https://s3.%s.amazonaws.com", cfg.Region)
+		case ProviderOSS:
+			cfg.Endpoint = fmt.Sprintf("https://oss-%s.aliyuncs.com", cfg.Region)
+		case ProviderGCS:
+			cfg.Endpoint = "https://storage.googleapis.com"
+		default:
+			return nil, i18n.Errorf("不支持的上传服务：%s（仅支持 s3、oss、gcs）", cfg.Provider)
+		}
+	}
+	ep, err := url.Parse(strings.TrimRight(cfg.Endpoint, "/"))
+	if err != nil || ep.Scheme == "" || ep.Host == "" {
+		return nil, i18n.Errorf("上传 endpoint 无效：%s", cfg.Endpoint)
+	}
+	return &s3Uploader{cfg: cfg, endpoint: ep, http: &http.Client{Timeout: 2 * time.Minute}, now: time.Now}, nil
+}
+
+// ObjectKey 拼接对象键：<prefix>/<runID>/<文件名>。
+func ObjectKey(prefix string, runID string, localPath string) string {
+	parts := make([]string, 0, 3)
+	if p := strings.Trim(prefix, "/"); p != "" {
+		parts = append(parts, p)
+	}
+	if runID != "" {
+		parts = append(parts, runID)
+	}
+	parts = append(parts, filepath.Base(localPath))
+	return path.Join(parts...)
+}
+
+func (u *s3Uploader) objectURL(key string) *url.URL {
+	out := *u.endpoint
+	escaped := escapePath(key)
+	if u.cfg.PathStyle {
+		out.Path = "/" + u.cfg.Bucket + "/" + key
+		out.RawPath = "/" + u.cfg.Bucket + "/" + escaped
+	} else {
+		out.Host = u.cfg.Bucket + "." + out.Host
+		out.Path = "/" + key
+		out.RawPath = "/" + escaped
+	}
+	return &out
+}
+
+func (u *s3Uploader) Upload(ctx context.Context, localPath string, key string) (string, error) {
+	body, err := os.ReadFile(localPath)
+	if err != nil {
+		return "", i18n.Errorf("读取待上传文件失败: %w", err)
+	}
+	target := u.objectURL(key)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, target.String(), bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.ContentLength = int64(len(body))
+	if ct := mime.TypeByExtension(filepath.Ext(localPath)); ct != "" {
+		req.Header.Set("Content-Type", ct)
+	}
+	u.sign(req, body)
+	resp, err := u.http.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return "", i18n.Errorf("上传失败: HTTP %d %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	return target.String(), nil
+}
+
+// sign 按 AWS Signature V4 为请求添加认证头。
+func (u *s3Uploader) sign(req *http.Request, body []byte) {
+	now := u.now().UTC()
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	payloadHash := sha256Hex(body)
+	req.Header.Set("Host", req.URL.Host)
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	names := make([]string, 0, len(req.Header))
+	for k := range req.Header {
+		names = append(names, strings.ToLower(k))
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, k := range names {
+		canonicalHeaders.WriteString(k + ":" + strings.TrimSpace(req.Header.Get(k)) + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+	scope := strings.Join([]string{date, u.cfg.Region, "s3", "aws4_request"}, "/")
+	stringToSign := strings.Join([]string{"AWS4-HMAC-SHA256", amzDate, scope, sha256Hex([]byte(canonicalRequest))}, "\n")
+	key := hmacSHA256([]byte("AWS4"+u.cfg.SecretAccessKey), date)
+	key = hmacSHA256(key, u.cfg.Region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		u.cfg.AccessKeyID, scope, signedHeaders, signature))
+	req.Header.Del("Host")
+}
+
+// escapePath 按 SigV4 规则编码对象键：除非保留字符与 / 外全部百分号编码。
+func escapePath(key string) string {
+	var b strings.Builder
+	for i := 0; i < len(key); i++ {
+		c := key[i]
+		if c == '/' || c == '-' || c == '_' || c == '.' || c == '~' ||
+			('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || ('0' <= c && c <= '9') {
+			b.WriteByte(c)
+			continue
+		}
+		fmt.Fprintf(&b, "%%%02X", c)
+	}
+	return b.String()
+}
+
+func sha256Hex(b []byte) string {
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	m := hmac.New(sha256.New, key)
+	m.Write([]byte(data))
+	return m.Sum(nil)
+}
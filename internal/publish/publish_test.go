@@ -0,0 +1,104 @@
+package publish
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNew_Defaults(t *testing.T) {
+	cases := map[string]string{
+		ProviderS3:  "s3.us-east-1.amazonaws.com",
+		ProviderOSS: "oss-cn-hangzhou.aliyuncs.com",
+		ProviderGCS: "storage.googleapis.com",
+	}
+	for provider, host := range cases {
+		u, err := New(Config{Provider: provider, Bucket: "b", AccessKeyID: "id", SecretAccessKey: "secret"})
+		if err != nil {
+			t.Fatalf("New(%s) error: %v", provider, err)
+		}
+		got := u.(*s3Uploader).objectURL("run/a_en.md").String()
+		if got != "https://b."+host+"/run/a_en.md" {
+			t.Fatalf("%s object url=%s", provider, got)
+		}
+	}
+	if _, err := New(Config{Provider: "ftp", Bucket: "b", AccessKeyID: "id", SecretAccessKey: "secret"}); err == nil {
+		t.Fatal("expected unsupported provider error")
+	}
+	if _, err := New(Config{Provider: ProviderS3, Bucket: "b"}); err == nil {
+		t.Fatal("expected missing credentials error")
+	}
+}
+
+func TestObjectKey(t *testing.T) {
+	if got := ObjectKey("/listings/", "20260101-000000-abcdef", "/tmp/out/a_en.md"); got != "listings/20260101-000000-abcdef/a_en.md" {
+		t.Fatalf("ObjectKey=%s", got)
+	}
+	if got := ObjectKey("", "", "a.md"); got != "a.md" {
+		t.Fatalf("ObjectKey=%s", got)
+	}
+}
+
+func TestUpload_SignsAndPuts(t *testing.T) {
+	var gotPath, gotAuth, gotBody, gotHash string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			t.Errorf("method=%s", r.Method)
+		}
+		gotPath = r.URL.EscapedPath()
+		gotAuth = r.Header.Get("Authorization")
+		gotHash = r.Header.Get("X-Amz-Content-Sha256")
+		b, _ := io.ReadAll(r.Body)
+		gotBody = string(b)
+	}))
+	defer ts.Close()
+
+	u, err := New(Config{Provider: ProviderS3, Endpoint: ts.URL, Region: "us-west-2", Bucket: "bkt", AccessKeyID: "AKID", SecretAccessKey: "secret", PathStyle: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	u.(*s3Uploader).now = func() time.Time { return time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC) }
+	local := filepath.Join(t.TempDir(), "a b_en.md")
+	if err := os.WriteFile(local, []byte("# hi"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	remote, err := u.Upload(context.Background(), local, "run/a b_en.md")
+	if err != nil {
+		t.Fatalf("Upload error: %v", err)
+	}
+	if remote != ts.URL+"/bkt/run/a%20b_en.md" {
+		t.Fatalf("remote=%s", remote)
+	}
+	if gotPath != "/bkt/run/a%20b_en.md" || gotBody != "# hi" {
+		t.Fatalf("path=%s body=%q", gotPath, gotBody)
+	}
+	if gotHash != sha256Hex([]byte("# hi")) {
+		t.Fatalf("payload hash=%s", gotHash)
+	}
+	if !strings.HasPrefix(gotAuth, "AWS4-HMAC-SHA256 Credential=AKID/20260102/us-west-2/s3/aws4_request, SignedHeaders=") ||
+		!strings.Contains(gotAuth, "host;") {
+		t.Fatalf("authorization=%s", gotAuth)
+	}
+}
+
+func TestUpload_HTTPError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "AccessDenied", http.StatusForbidden)
+	}))
+	defer ts.Close()
+	u, err := New(Config{Provider: ProviderGCS, Endpoint: ts.URL, Bucket: "bkt", AccessKeyID: "id", SecretAccessKey: "s", PathStyle: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	local := filepath.Join(t.TempDir(), "a.md")
+	_ = os.WriteFile(local, []byte("x"), 0o644)
+	if _, err := u.Upload(context.Background(), local, "a.md"); err == nil || !strings.Contains(err.Error(), "403") {
+		t.Fatalf("unexpected err: %v", err)
+	}
+}
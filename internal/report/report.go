@@ -0,0 +1,145 @@
+// Package report 根据运行记录生成可分享的 HTML 报告。
+package report
+
+import (
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"syl-listing-pro/internal/listing"
+	"syl-listing-pro/internal/manifest"
+)
+
+// FileName 返回某次运行的报告文件名。
+func FileName(runID string) string {
+	return fmt.Sprintf("report_%s.html", runID)
+}
+
+type link struct {
+	Name string
+	Href string
+}
+
+type row struct {
+	Label        string
+	Input        string
+	Status       string
+	Duration     string
+	RulesVersion string
+	ENTitle      string
+	CNTitle      string
+	Error        string
+	Links        []link
+}
+
+type page struct {
+	RunID      string
+	StartedAt  string
+	FinishedAt string
+	Counts     map[string]int
+	Rows       []row
+}
+
+var pageTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html lang="zh-CN">
+<head>
+<meta charset="utf-8">
+<title>运行报告 {{.RunID}}</title>
+<style>
+body{font-family:-apple-system,"PingFang SC","Microsoft YaHei",sans-serif;margin:24px;color:#222}
+table{border-collapse:collapse;width:100%}
+th,td{border:1px solid #ddd;padding:6px 8px;text-align:left;vertical-align:top;font-size:13px}
+th{background:#f5f5f5}
+.succeeded{color:#1a7f37}.failed{color:#cf222e}.cancelled,.pending,.submitted{color:#9a6700}
+.err{color:#cf222e;white-space:pre-wrap}
+</style>
+</head>
+<body>
+<h1>运行报告 {{.RunID}}</h1>
+<p>开始：{{.StartedAt}}　结束：{{.FinishedAt}}　成功 {{index .Counts "succeeded"}}，失败 {{index .Counts "failed"}}，取消 {{index .Counts "cancelled"}}</p>
+<table>
+<tr><th>任务</th><th>输入</th><th>状态</th><th>耗时</th><th>规则版本</th><th>EN 标题</th><th>CN 标题</th><th>产物</th></tr>
+{{range .Rows}}<tr>
+<td>{{.Label}}</td>
+<td>{{.Input}}</td>
+<td class="{{.Status}}">{{.Status}}{{if .Error}}<div class="err">{{.Error}}</div>{{end}}</td>
+<td>{{.Duration}}</td>
+<td>{{.RulesVersion}}</td>
+<td>{{.ENTitle}}</td>
+<td>{{.CNTitle}}</td>
+<td>{{range .Links}}<a href="{{.Href}}">{{.Name}}</a><br>{{end}}</td>
+</tr>
+{{end}}</table>
+</body>
+</html>
+`))
+
+// Write 把运行记录渲染为 HTML 写入 dir，产物链接使用相对 dir 的路径。返回报告路径。
+func Write(dir string, m manifest.Manifest) (string, error) {
+	p := page{RunID: m.RunID, StartedAt: m.StartedAt, FinishedAt: m.FinishedAt, Counts: map[string]int{}}
+	for _, t := range m.Tasks {
+		p.Counts[t.Status]++
+		r := row{
+			Label:        t.Label,
+			Input:        t.InputPath,
+			Status:       t.Status,
+			RulesVersion: t.RulesVersion,
+			Error:        t.Error,
+		}
+		if r.Label == "" {
+			r.Label = filepath.Base(t.InputPath)
+		}
+		if t.DurationMs > 0 {
+			r.Duration = (time.Duration(t.DurationMs) * time.Millisecond).Round(time.Second).String()
+		}
+		for _, out := range t.Outputs {
+			// 前两个 .md 依次为主稿与对照稿（后缀随语言对变化，默认 _en/_cn）。
+			switch {
+			case strings.HasSuffix(out, ".md") && r.ENTitle == "":
+				r.ENTitle = titlePreview(out)
+			case strings.HasSuffix(out, ".md") && r.CNTitle == "":
+				r.CNTitle = titlePreview(out)
+			}
+			href := out
+			if rel, err := filepath.Rel(dir, out); err == nil {
+				href = filepath.ToSlash(rel)
+			}
+			r.Links = append(r.Links, link{Name: filepath.Base(out), Href: href})
+		}
+		p.Rows = append(p.Rows, r)
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, FileName(m.RunID))
+	f, err := os.Create(path)
+	if err != nil {
+		return "", err
+	}
+	if err := pageTemplate.Execute(f, p); err != nil {
+		_ = f.Close()
+		return "", err
+	}
+	return path, f.Close()
+}
+
+// titlePreview 读取产物中的标题分节首行；找不到标题分节时取第一行正文。
+func titlePreview(path string) string {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	l := listing.Parse(string(b))
+	s, ok := l.Section(listing.KindTitle)
+	if !ok && len(l.Sections) > 0 {
+		s = l.Sections[0]
+	}
+	line, _, _ := strings.Cut(s.Body, "\n")
+	if line == "" {
+		line = s.Heading
+	}
+	return strings.TrimSpace(line)
+}
@@ -0,0 +1,48 @@
+package report
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"syl-listing-pro/internal/manifest"
+)
+
+func TestWrite(t *testing.T) {
+	dir := t.TempDir()
+	en := filepath.Join(dir, "req_ab12_en.md")
+	cn := filepath.Join(dir, "req_ab12_cn.md")
+	_ = os.WriteFile(en, []byte("# Title\nSteel <Bottle>\n## Bullet Points\n- a\n"), 0o644)
+	_ = os.WriteFile(cn, []byte("# 标题\n不锈钢水杯\n"), 0o644)
+	m := manifest.Manifest{
+		RunID:     "20260101-000000-abcdef",
+		StartedAt: "2026-01-01T00:00:00Z",
+		Tasks: []manifest.Task{
+			{InputPath: "/in/req.md", Status: manifest.StatusSucceeded, RulesVersion: "rules-v1", DurationMs: 61000, Outputs: []string{en, cn}},
+			{InputPath: "/in/bad.md", Label: "bad.md", Status: manifest.StatusFailed, Error: "engine failed"},
+		},
+	}
+	path, err := Write(dir, m)
+	if err != nil {
+		t.Fatalf("Write error: %v", err)
+	}
+	if filepath.Base(path) != "report_20260101-000000-abcdef.html" {
+		t.Fatalf("unexpected path: %s", path)
+	}
+	b, _ := os.ReadFile(path)
+	html := string(b)
+	for _, want := range []string{
+		"成功 1，失败 1，取消 0",
+		"Steel &lt;Bottle&gt;",
+		"不锈钢水杯",
+		"rules-v1",
+		"1m1s",
+		`href="req_ab12_en.md"`,
+		"engine failed",
+	} {
+		if !strings.Contains(html, want) {
+			t.Fatalf("report missing %q:\n%s", want, html)
+		}
+	}
+}
@@ -1,23 +1,83 @@
 package util
 
 import (
-	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
+
+	"syl-listing-pro/internal/i18n"
 )
 
+// StateDirEnvName 指定全部本地状态（.env、运行记录、崩溃报告、词表等）所在目录；
+// 设置后不再读取用户目录，适用于 HOME 只读或不存在的 CI 镜像与容器。
+const StateDirEnvName = "SYL_STATE_DIR"
+
+// DefaultAppDir 返回本地状态目录：设置了 SYL_STATE_DIR 时为该目录（相对路径按当前目录解析），否则为 ~/.syl-listing-pro。
 func DefaultAppDir() (string, error) {
+	if dir := strings.TrimSpace(os.Getenv(StateDirEnvName)); dir != "" {
+		abs, err := filepath.Abs(dir)
+		if err != nil {
+			return "", i18n.Errorf("解析 %s 失败: %w", StateDirEnvName, err)
+		}
+		return abs, nil
+	}
 	home, err := os.UserHomeDir()
 	if err != nil {
-		return "", fmt.Errorf("读取用户目录失败: %w", err)
+		return "", i18n.Errorf("读取用户目录失败: %w", err)
 	}
 	return filepath.Join(home, ".syl-listing-pro"), nil
 }
 
+// envPathOverride 为 --config 指定的配置文件路径，非空时取代默认的 ~/.syl-listing-pro/.env。
+var envPathOverride string
+
+// SetEnvPath 让后续读写配置都使用 p；传空字符串恢复默认位置。
+func SetEnvPath(p string) {
+	envPathOverride = p
+}
+
 func DefaultEnvPath() (string, error) {
+	if envPathOverride != "" {
+		return envPathOverride, nil
+	}
 	base, err := DefaultAppDir()
 	if err != nil {
 		return "", err
 	}
 	return filepath.Join(base, ".env"), nil
 }
+
+func DefaultRunsDir() (string, error) {
+	base, err := DefaultAppDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(base, "runs"), nil
+}
+
+// DefaultBannedWordsPath 返回本地禁用词表的默认位置。
+func DefaultBannedWordsPath() (string, error) {
+	base, err := DefaultAppDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(base, "banned_words.txt"), nil
+}
+
+// DefaultCrashDir 返回任务 panic 时崩溃报告的写入目录。
+func DefaultCrashDir() (string, error) {
+	base, err := DefaultAppDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(base, "crash"), nil
+}
+
+// DefaultUserDictionaryPath 返回拼写检查用户词典（品牌词等）的默认位置。
+func DefaultUserDictionaryPath() (string, error) {
+	base, err := DefaultAppDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(base, "dictionary.txt"), nil
+}
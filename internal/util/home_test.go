@@ -26,4 +26,45 @@ func TestDefaultAppDirAndEnvPath(t *testing.T) {
 	if envPath != wantEnv {
 		t.Fatalf("envPath=%q want=%q", envPath, wantEnv)
 	}
+
+	runsDir, err := DefaultRunsDir()
+	if err != nil {
+		t.Fatalf("DefaultRunsDir error: %v", err)
+	}
+	if want := filepath.Join(wantApp, "runs"); runsDir != want {
+		t.Fatalf("runsDir=%q want=%q", runsDir, want)
+	}
+}
+
+func TestSetEnvPath(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	custom := filepath.Join(t.TempDir(), "team.env")
+	SetEnvPath(custom)
+	defer SetEnvPath("")
+	if got, err := DefaultEnvPath(); err != nil || got != custom {
+		t.Fatalf("DefaultEnvPath=%q, %v; want %q", got, err, custom)
+	}
+	SetEnvPath("")
+	if got, _ := DefaultEnvPath(); got == custom {
+		t.Fatal("override not cleared")
+	}
+}
+
+func TestDefaultAppDir_StateDir(t *testing.T) {
+	t.Setenv("HOME", "")
+	state := t.TempDir()
+	t.Setenv(StateDirEnvName, state)
+	appDir, err := DefaultAppDir()
+	if err != nil {
+		t.Fatalf("DefaultAppDir error: %v", err)
+	}
+	if appDir != state {
+		t.Fatalf("appDir=%q want=%q", appDir, state)
+	}
+	if p, _ := DefaultEnvPath(); p != filepath.Join(state, ".env") {
+		t.Fatalf("envPath=%q", p)
+	}
+	if p, _ := DefaultRunsDir(); p != filepath.Join(state, "runs") {
+		t.Fatalf("runsDir=%q", p)
+	}
 }